@@ -11,16 +11,25 @@ import (
 
 type (
 	Config struct {
-		Jwt        JWT
-		HTTP       HTTP
-		Log        Log
-		Pg         PG
-		Security   Security
-		Prometheus Prometheus
+		Jwt         JWT
+		HTTP        HTTP
+		GRPC        GRPC
+		Log         Log
+		Repo        Repo
+		Pg          PG
+		Security    Security
+		Cache       Cache
+		Reception   Reception
+		Events      Events
+		Webhooks    Webhooks
+		Idempotency Idempotency
+		Prometheus  Prometheus
 	}
 
 	JWT struct {
-		SecretKey string `env:"JWT_SECRET_KEY" env-required:"true"`
+		SecretKey  string        `env:"JWT_SECRET_KEY" env-required:"true"`
+		AccessTTL  time.Duration `env:"JWT_ACCESS_TTL" env-default:"15m"`
+		RefreshTTL time.Duration `env:"JWT_REFRESH_TTL" env-default:"720h"`
 	}
 
 	HTTP struct {
@@ -33,17 +42,66 @@ type (
 		ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"5s"`
 	}
 
+	GRPC struct {
+		Enabled         bool          `env:"GRPC_ENABLED" env-default:"true"`
+		Port            string        `env:"GRPC_PORT" env-default:"50051"`
+		ShutdownTimeout time.Duration `env:"GRPC_SHUTDOWN_TIMEOUT" env-default:"5s"`
+	}
+
 	Log struct {
 		Level string `env:"LOG_LEVEL" env-required:"true"`
 	}
 
+	Repo struct {
+		Backend string `env:"REPO_BACKEND" env-default:"postgres"`
+	}
+
 	PG struct {
-		PoolMax int    `env:"PG_POOL_MAX" env-required:"true"`
-		URL     string `env:"PG_URL" env-required:"true"`
+		PoolMax      int    `env:"PG_POOL_MAX"`
+		URL          string `env:"PG_URL"`
+		TxIsolation  string `env:"PG_TX_ISOLATION" env-default:"read committed"`
+		TxMaxRetries int    `env:"PG_TX_MAX_RETRIES" env-default:"3"`
 	}
 
 	Security struct {
-		PasswordCost int `env:"SECURITY_PASSWORD_COST" env-default:"10"`
+		PasswordCost          int    `env:"SECURITY_PASSWORD_COST" env-default:"10"`
+		EnforcePVZAssignment  bool   `env:"SECURITY_ENFORCE_PVZ_ASSIGNMENT" env-default:"true"`
+		PickupCodeSecret      string `env:"SECURITY_PICKUP_CODE_SECRET" env-required:"true"`
+		PickupCodeMaxAttempts int    `env:"SECURITY_PICKUP_CODE_MAX_ATTEMPTS" env-default:"5"`
+	}
+
+	Cache struct {
+		CityTTL time.Duration `env:"CACHE_CITY_TTL" env-default:"1m"`
+	}
+
+	Reception struct {
+		ReopenWindow  time.Duration `env:"RECEPTION_REOPEN_WINDOW" env-default:"24h"`
+		StaleTimeout  time.Duration `env:"RECEPTION_STALE_TIMEOUT" env-default:"12h"`
+		SweepInterval time.Duration `env:"RECEPTION_SWEEP_INTERVAL" env-default:"1m"`
+	}
+
+	Events struct {
+		Publisher      string        `env:"EVENTS_PUBLISHER" env-default:"log"`
+		LogPath        string        `env:"EVENTS_LOG_PATH"`
+		WebhookURL     string        `env:"EVENTS_WEBHOOK_URL"`
+		WebhookTimeout time.Duration `env:"EVENTS_WEBHOOK_TIMEOUT" env-default:"5s"`
+		RelayInterval  time.Duration `env:"EVENTS_RELAY_INTERVAL" env-default:"1s"`
+		RelayBatchSize int           `env:"EVENTS_RELAY_BATCH_SIZE" env-default:"100"`
+	}
+
+	Webhooks struct {
+		DeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" env-default:"1s"`
+		BatchSize        int           `env:"WEBHOOK_BATCH_SIZE" env-default:"20"`
+		Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"5s"`
+		MaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"10"`
+		RetryBaseDelay   time.Duration `env:"WEBHOOK_RETRY_BASE_DELAY" env-default:"10s"`
+		RetryMaxDelay    time.Duration `env:"WEBHOOK_RETRY_MAX_DELAY" env-default:"1h"`
+	}
+
+	Idempotency struct {
+		TTL             time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+		LockTimeout     time.Duration `env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"1m"`
+		CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
 	}
 
 	Prometheus struct {
@@ -69,12 +127,89 @@ func MustLoad() *Config {
 	if cfg.Security.PasswordCost > 12 {
 		log.Fatal("SECURITY_PASSWORD_COST is too high. It should be <13")
 	}
+	if cfg.Security.PickupCodeMaxAttempts <= 0 {
+		log.Fatal("SECURITY_PICKUP_CODE_MAX_ATTEMPTS must be positive")
+	}
 	if cfg.HTTP.ReadTimeout < 0 {
 		log.Fatal("HTTP_READ_TIMEOUT cannot be negative")
 	}
 	if cfg.HTTP.WriteTimeout < 0 {
 		log.Fatal("HTTP_WRITE_TIMEOUT cannot be negative")
 	}
+	switch cfg.Repo.Backend {
+	case "memory":
+	case "postgres":
+		if cfg.Pg.URL == "" {
+			log.Fatal("PG_URL is required for the postgres backend")
+		}
+		if cfg.Pg.PoolMax <= 0 {
+			log.Fatal("PG_POOL_MAX must be positive for the postgres backend")
+		}
+	default:
+		log.Fatal("REPO_BACKEND must be one of: postgres, memory")
+	}
+	switch cfg.Pg.TxIsolation {
+	case "read committed", "repeatable read", "serializable":
+	default:
+		log.Fatal("PG_TX_ISOLATION must be one of: read committed, repeatable read, serializable")
+	}
+	if cfg.Pg.TxMaxRetries < 0 {
+		log.Fatal("PG_TX_MAX_RETRIES cannot be negative")
+	}
+	if cfg.Reception.ReopenWindow < 0 {
+		log.Fatal("RECEPTION_REOPEN_WINDOW cannot be negative")
+	}
+	if cfg.Reception.StaleTimeout < 0 {
+		log.Fatal("RECEPTION_STALE_TIMEOUT cannot be negative")
+	}
+	if cfg.Reception.SweepInterval <= 0 {
+		log.Fatal("RECEPTION_SWEEP_INTERVAL must be positive")
+	}
+	switch cfg.Events.Publisher {
+	case "log":
+	case "webhook":
+		if cfg.Events.WebhookURL == "" {
+			log.Fatal("EVENTS_WEBHOOK_URL is required for the webhook publisher")
+		}
+	default:
+		log.Fatal("EVENTS_PUBLISHER must be one of: log, webhook")
+	}
+	if cfg.Events.RelayInterval <= 0 {
+		log.Fatal("EVENTS_RELAY_INTERVAL must be positive")
+	}
+	if cfg.Events.RelayBatchSize <= 0 {
+		log.Fatal("EVENTS_RELAY_BATCH_SIZE must be positive")
+	}
+	if cfg.Webhooks.DeliveryInterval <= 0 {
+		log.Fatal("WEBHOOK_DELIVERY_INTERVAL must be positive")
+	}
+	if cfg.Webhooks.BatchSize <= 0 {
+		log.Fatal("WEBHOOK_BATCH_SIZE must be positive")
+	}
+	if cfg.Webhooks.Timeout <= 0 {
+		log.Fatal("WEBHOOK_TIMEOUT must be positive")
+	}
+	if cfg.Webhooks.MaxAttempts <= 0 {
+		log.Fatal("WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	if cfg.Webhooks.RetryBaseDelay <= 0 || cfg.Webhooks.RetryMaxDelay < cfg.Webhooks.RetryBaseDelay {
+		log.Fatal("WEBHOOK_RETRY_BASE_DELAY must be positive and not exceed WEBHOOK_RETRY_MAX_DELAY")
+	}
+	if cfg.Idempotency.TTL <= 0 {
+		log.Fatal("IDEMPOTENCY_TTL must be positive")
+	}
+	if cfg.Idempotency.LockTimeout <= 0 {
+		log.Fatal("IDEMPOTENCY_LOCK_TIMEOUT must be positive")
+	}
+	if cfg.Idempotency.CleanupInterval <= 0 {
+		log.Fatal("IDEMPOTENCY_CLEANUP_INTERVAL must be positive")
+	}
+	if cfg.Jwt.AccessTTL <= 0 {
+		log.Fatal("JWT_ACCESS_TTL must be positive")
+	}
+	if cfg.Jwt.RefreshTTL <= cfg.Jwt.AccessTTL {
+		log.Fatal("JWT_REFRESH_TTL must be greater than JWT_ACCESS_TTL")
+	}
 
 	return &cfg
 }
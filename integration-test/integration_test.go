@@ -5,13 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"strconv"
 	"testing"
+
+	"github.com/google/uuid"
 )
 
 type TokenResponse struct {
 	Token string `json:"token"`
 }
 
+type UserResponse struct {
+	ID string `json:"id"`
+}
+
 type PVZRequest struct {
 	City string `json:"city"`
 }
@@ -106,6 +114,87 @@ func createPVZ(t *testing.T, token, city string) string {
 	return pvz.ID
 }
 
+// enforcesPVZAssignment mirrors SECURITY_ENFORCE_PVZ_ASSIGNMENT of the service under test.
+func enforcesPVZAssignment(t *testing.T) bool {
+	value := os.Getenv("SECURITY_ENFORCE_PVZ_ASSIGNMENT")
+	if value == "" {
+		return true
+	}
+
+	enforce, err := strconv.ParseBool(value)
+	if err != nil {
+		t.Fatalf("Invalid SECURITY_ENFORCE_PVZ_ASSIGNMENT: %v", err)
+	}
+	return enforce
+}
+
+func postJSON(t *testing.T, url, token string, body []byte) *http.Response {
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Skipf("Skipping test: Failed to reach %s: %v", url, err)
+	}
+	return resp
+}
+
+func registerEmployee(t *testing.T) (string, string) {
+	email := fmt.Sprintf("employee-%s@example.com", uuid.NewString())
+	password := "password"
+
+	resp := postJSON(t, "http://localhost:8080/register", "",
+		[]byte(fmt.Sprintf(`{"email": "%s", "password": "%s", "role": "employee"}`, email, password)))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+	}
+
+	var user UserResponse
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		t.Fatalf("Failed to decode register response: %v", err)
+	}
+
+	resp = postJSON(t, "http://localhost:8080/login", "",
+		[]byte(fmt.Sprintf(`{"email": "%s", "password": "%s"}`, email, password)))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var tokenResp TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		t.Fatalf("Failed to decode login response: %v", err)
+	}
+
+	return user.ID, tokenResp.Token
+}
+
+func assignEmployee(t *testing.T, moderatorToken, pvzID, userID string) {
+	url := fmt.Sprintf("http://localhost:8080/pvz/%s/employees", pvzID)
+	resp := postJSON(t, url, moderatorToken, []byte(fmt.Sprintf(`{"userId": "%s"}`, userID)))
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+	}
+}
+
+func createReceptionStatus(t *testing.T, token, pvzID string) int {
+	resp := postJSON(t, "http://localhost:8080/receptions", token, []byte(fmt.Sprintf(`{"pvzId": "%s"}`, pvzID)))
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
 func createReception(t *testing.T, token, pvzID string) string {
 	url := "http://localhost:8080/receptions"
 	body := []byte(fmt.Sprintf(`{"pvzId": "%s"}`, pvzID))
@@ -223,6 +312,14 @@ func TestIntegrationFlow(t *testing.T) {
 	// Шаг 2: Создаем ПВЗ
 	pvzID := createPVZ(t, moderatorToken, "Москва")
 
+	// Токен из /dummyLogin не привязан к пользователю, поэтому при проверке закрепления сотрудник получает 403
+	if enforcesPVZAssignment(t) {
+		if status := createReceptionStatus(t, employeeToken, pvzID); status != http.StatusForbidden {
+			t.Fatalf("Expected status 403, got %d", status)
+		}
+		return
+	}
+
 	// Шаг 3: Создаем приёмку
 	_ = createReception(t, employeeToken, pvzID)
 
@@ -246,3 +343,29 @@ func TestIntegrationFlow(t *testing.T) {
 	closeReception(t, employeeToken, pvzID)
 
 }
+
+func TestIntegrationFlowWithAssignment(t *testing.T) {
+	moderatorToken := getDummyToken(t, "moderator")
+	employeeID, employeeToken := registerEmployee(t)
+
+	pvzID := createPVZ(t, moderatorToken, "Казань")
+	otherPVZID := createPVZ(t, moderatorToken, "Казань")
+
+	assignEmployee(t, moderatorToken, pvzID, employeeID)
+
+	// Закрепленный сотрудник работает с ПВЗ в обоих режимах
+	_ = createReception(t, employeeToken, pvzID)
+	for i := 0; i < 5; i++ {
+		addProduct(t, employeeToken, pvzID, "обувь")
+	}
+	closeReception(t, employeeToken, pvzID)
+
+	// С чужим ПВЗ — только если проверка закрепления выключена
+	expected := http.StatusCreated
+	if enforcesPVZAssignment(t) {
+		expected = http.StatusForbidden
+	}
+	if status := createReceptionStatus(t, employeeToken, otherPVZID); status != expected {
+		t.Fatalf("Expected status %d, got %d", expected, status)
+	}
+}
@@ -2,50 +2,108 @@ package app
 
 import (
 	"PVZ-avito-tech/config"
+	grpcV1 "PVZ-avito-tech/internal/controller/grpc/v1"
 	v1 "PVZ-avito-tech/internal/controller/http/v1"
-	"PVZ-avito-tech/internal/infrastructure/repo/persistent"
+	"PVZ-avito-tech/internal/controller/jobs"
+	"PVZ-avito-tech/internal/infrastructure/publisher"
+	webhookPublisher "PVZ-avito-tech/internal/infrastructure/publisher/webhook"
 	"PVZ-avito-tech/internal/infrastructure/security/password"
 	"PVZ-avito-tech/internal/pkg/auth/jwt"
+	"PVZ-avito-tech/internal/pkg/grpcserver"
 	"PVZ-avito-tech/internal/pkg/httpserver"
 	"PVZ-avito-tech/internal/pkg/logger"
-	"PVZ-avito-tech/internal/pkg/postgres"
+	"PVZ-avito-tech/internal/pkg/worker"
+	"PVZ-avito-tech/internal/usecase/assignment"
+	"PVZ-avito-tech/internal/usecase/audit"
 	"PVZ-avito-tech/internal/usecase/auth"
+	"PVZ-avito-tech/internal/usecase/catalogue"
+	"PVZ-avito-tech/internal/usecase/city"
 	"PVZ-avito-tech/internal/usecase/dummy"
+	"PVZ-avito-tech/internal/usecase/events"
+	"PVZ-avito-tech/internal/usecase/idempotency"
+	"PVZ-avito-tech/internal/usecase/outbox"
 	"PVZ-avito-tech/internal/usecase/product"
 	"PVZ-avito-tech/internal/usecase/pvz"
 	"PVZ-avito-tech/internal/usecase/reception"
+	"PVZ-avito-tech/internal/usecase/report"
+	"PVZ-avito-tech/internal/usecase/returns"
+	"PVZ-avito-tech/internal/usecase/token"
+	"PVZ-avito-tech/internal/usecase/webhook"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
-	jwtService, err := jwt.NewService([]byte(cfg.Jwt.SecretKey))
-	if err != nil {
-		l.Fatal("cant create jwt in Run()", err)
-	}
 	hasher := password.NewBcryptHasher(cfg)
 
 	// repo
-	pg, err := postgres.New(cfg.Pg.URL, postgres.MaxPoolSize(cfg.Pg.PoolMax))
+	repos, closeRepos, err := newRepositories(cfg)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - newRepositories: %w", err))
 	}
-	defer pg.Close()
+	defer closeRepos()
 
-	userRepo := persistent.NewUserRepo(pg)
-	productRepo := persistent.NewProductRepo(pg)
-	receptionRepo := persistent.NewReceptionRepo(pg)
-	pvzRepo := persistent.NewPVZRepo(pg)
+	eventPublisher, closePublisher, err := newEventPublisher(cfg)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newEventPublisher: %w", err))
+	}
+	defer closePublisher()
+
+	jwtService, err := jwt.NewService(
+		[]byte(cfg.Jwt.SecretKey),
+		jwt.AccessTTL(cfg.Jwt.AccessTTL),
+		jwt.Revocations(repos.revokedTokens),
+	)
+	if err != nil {
+		l.Fatal("cant create jwt in Run()", err)
+	}
 
 	// usecase
-	userUC := auth.NewUserUsecase(userRepo, hasher)
+	userUC := auth.NewUserUsecase(repos.users, hasher)
 	dummyUC := dummy.NewDummyAuthUseCase(jwtService)
-	pvzUC := pvz.NewPVZUseCase(pvzRepo, receptionRepo, productRepo, l)
-	receptionUC := reception.NewUseCase(receptionRepo)
-	productUC := product.NewProductUsecase(productRepo)
+	tokenUC := token.NewUseCase(jwtService, repos.refreshTokens, repos.revokedTokens, repos.tx, cfg.Jwt.RefreshTTL)
+	pvzUC := pvz.NewPVZUseCase(repos.pvz, repos.receptions, repos.products, repos.cities, repos.tx, l)
+	receptionUC := reception.NewUseCase(repos.receptions, repos.products, repos.tx, cfg.Reception.ReopenWindow)
+	productUC := product.NewProductUsecase(
+		repos.products,
+		[]byte(cfg.Security.PickupCodeSecret),
+		cfg.Security.PickupCodeMaxAttempts,
+	)
+	assignmentUC := assignment.NewUseCase(repos.assignments, cfg.Security.EnforcePVZAssignment)
+	if !cfg.Security.EnforcePVZAssignment {
+		l.Warn("SECURITY_ENFORCE_PVZ_ASSIGNMENT=false: employees can act on any PVZ, use only for demo setups")
+	}
+	cityUC := city.NewUseCase(repos.cities)
+	catalogueUC := catalogue.NewUseCase(repos.catalogue)
+	returnsUC := returns.NewUseCase(repos.returns)
+	webhooksUC := webhook.NewUseCase(
+		repos.webhooks,
+		repos.cities,
+		webhookPublisher.NewSender(
+			webhookPublisher.Timeout(cfg.Webhooks.Timeout),
+			webhookPublisher.PublicAddressesOnly(),
+		),
+		webhook.RetryPolicy{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+			MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			BatchSize:   cfg.Webhooks.BatchSize,
+			Lease:       time.Duration(cfg.Webhooks.BatchSize+1) * cfg.Webhooks.Timeout,
+		},
+	)
+	eventsUC := events.NewUseCase(repos.eventStream, repos.pvz, repos.cities)
+	auditUC := audit.NewUseCase(repos.audit)
+	reportsUC := report.NewUseCase(repos.reports)
+	idempotencyUC := idempotency.NewUseCase(repos.idempotency, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout)
+	outboxUC := outbox.NewUseCase(
+		repos.outbox,
+		publisher.Multi(webhooksUC, eventPublisher),
+		cfg.Events.RelayBatchSize,
+	)
 
 	// controlerS
 	router := v1.NewRouter(
@@ -53,9 +111,19 @@ func Run(cfg *config.Config) {
 		l,
 		userUC,
 		dummyUC,
+		tokenUC,
 		receptionUC,
 		pvzUC,
 		productUC,
+		assignmentUC,
+		cityUC,
+		catalogueUC,
+		returnsUC,
+		webhooksUC,
+		eventsUC,
+		auditUC,
+		reportsUC,
+		idempotencyUC,
 		jwtService,
 	)
 	routerMetrics := v1.NewRouterMetrics(
@@ -80,6 +148,66 @@ func Run(cfg *config.Config) {
 		serverForMetrics.Start()
 	}
 
+	var grpcServer *grpcserver.Server
+	var grpcNotify <-chan error
+	if cfg.GRPC.Enabled {
+		grpcServer = grpcserver.New(
+			cfg,
+			grpcV1.NewServer(
+				l,
+				pvzUC,
+				receptionUC,
+				productUC,
+				assignmentUC,
+				jwtService,
+			),
+		)
+
+		grpcServer.Start()
+		grpcNotify = grpcServer.Notify()
+	}
+
+	staleReceptionsWorker := worker.New(
+		"stale-receptions",
+		jobs.NewStaleReceptions(receptionUC, cfg.Reception.StaleTimeout, l).Run,
+		l,
+		worker.Interval(cfg.Reception.SweepInterval),
+	)
+	staleReceptionsWorker.Start()
+
+	outboxRelayWorker := worker.New(
+		"outbox-relay",
+		jobs.NewOutboxRelay(outboxUC).Run,
+		l,
+		worker.Interval(cfg.Events.RelayInterval),
+	)
+	outboxRelayWorker.Start()
+
+	webhookDeliveryWorker := worker.New(
+		"webhook-deliveries",
+		jobs.NewWebhookDeliveries(webhooksUC).Run,
+		l,
+		worker.Interval(cfg.Webhooks.DeliveryInterval),
+		worker.ShutdownTimeout(cfg.Webhooks.Timeout+time.Second),
+	)
+	webhookDeliveryWorker.Start()
+
+	eventStreamWorker := worker.New(
+		"event-stream",
+		eventsUC.Listen,
+		l,
+		worker.Interval(time.Second),
+	)
+	eventStreamWorker.Start()
+
+	idempotencyCleanupWorker := worker.New(
+		"idempotency-cleanup",
+		jobs.NewIdempotencyKeysCleanup(idempotencyUC).Run,
+		l,
+		worker.Interval(cfg.Idempotency.CleanupInterval),
+	)
+	idempotencyCleanupWorker.Start()
+
 	server.Start()
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -91,13 +219,48 @@ func Run(cfg *config.Config) {
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
 	case err = <-server.Notify():
 		l.Error(fmt.Errorf("app - Run - rmqServer.Notify: %w", err))
+	case err = <-grpcNotify:
+		l.Error(fmt.Errorf("app - Run - grpcServer.Notify: %w", err))
 	}
 
 	// Shutdown
+	err = staleReceptionsWorker.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - staleReceptionsWorker.Shutdown: %w", err))
+	}
+
+	err = outboxRelayWorker.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - outboxRelayWorker.Shutdown: %w", err))
+	}
+
+	err = webhookDeliveryWorker.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - webhookDeliveryWorker.Shutdown: %w", err))
+	}
+
+	err = idempotencyCleanupWorker.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - idempotencyCleanupWorker.Shutdown: %w", err))
+	}
+
+	// Stopping the listener ends open event streams so the HTTP server can drain.
+	err = eventStreamWorker.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - eventStreamWorker.Shutdown: %w", err))
+	}
+
 	err = server.Shutdown()
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
+	if grpcServer != nil {
+		err = grpcServer.Shutdown()
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - grpcServer.Shutdown: %w", err))
+		}
+	}
+
 	err = serverForMetrics.Shutdown()
 
 	if err != nil {
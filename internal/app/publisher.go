@@ -0,0 +1,29 @@
+package app
+
+import (
+	"PVZ-avito-tech/config"
+	"PVZ-avito-tech/internal/infrastructure/publisher"
+	"PVZ-avito-tech/internal/infrastructure/publisher/file"
+	"PVZ-avito-tech/internal/infrastructure/publisher/webhook"
+	"os"
+)
+
+func newEventPublisher(cfg *config.Config) (publisher.Publisher, func() error, error) {
+	switch cfg.Events.Publisher {
+	case "webhook":
+		return webhook.NewPublisher(
+			cfg.Events.WebhookURL,
+			webhook.Timeout(cfg.Events.WebhookTimeout),
+		), func() error { return nil }, nil
+	default:
+		if cfg.Events.LogPath == "" {
+			p := file.NewPublisher(os.Stdout)
+			return p, p.Close, nil
+		}
+		p, err := file.Open(cfg.Events.LogPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return p, p.Close, nil
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"PVZ-avito-tech/config"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"PVZ-avito-tech/internal/infrastructure/repo/cached"
+	"PVZ-avito-tech/internal/infrastructure/repo/inmemory"
+	"PVZ-avito-tech/internal/infrastructure/repo/persistent"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type repositories struct {
+	users         repo.UserRepo
+	refreshTokens repo.RefreshTokenRepo
+	revokedTokens repo.RevokedTokenRepo
+	pvz           repo.PVZRepo
+	receptions    repo.ReceptionRepo
+	products      repo.ProductRepo
+	assignments   repo.PVZAssignmentRepo
+	cities        repo.CityRepo
+	catalogue     repo.CatalogueRepo
+	returns       repo.ReturnRepo
+	outbox        repo.OutboxRepo
+	webhooks      repo.WebhookRepo
+	eventStream   repo.EventStreamRepo
+	audit         repo.AuditRepo
+	reports       repo.ReportRepo
+	idempotency   repo.IdempotencyRepo
+	tx            repo.TxManager
+}
+
+func newRepositories(cfg *config.Config) (*repositories, func(), error) {
+	switch cfg.Repo.Backend {
+	case "memory":
+		s := inmemory.NewStorage()
+		return &repositories{
+			users:         inmemory.NewUserRepo(s),
+			refreshTokens: inmemory.NewRefreshTokenRepo(s),
+			revokedTokens: inmemory.NewRevokedTokenRepo(s),
+			pvz:           inmemory.NewPVZRepo(s),
+			receptions:    inmemory.NewReceptionRepo(s),
+			products:      inmemory.NewProductRepo(s),
+			assignments:   inmemory.NewPVZAssignmentRepo(s),
+			cities:        inmemory.NewCityRepo(s),
+			catalogue:     inmemory.NewCatalogueRepo(s),
+			returns:       inmemory.NewReturnRepo(s),
+			outbox:        inmemory.NewOutboxRepo(s),
+			webhooks:      inmemory.NewWebhookRepo(s),
+			eventStream:   inmemory.NewEventStreamRepo(s),
+			audit:         inmemory.NewAuditRepo(s),
+			reports:       inmemory.NewReportRepo(s),
+			idempotency:   inmemory.NewIdempotencyRepo(s),
+			tx:            inmemory.NewTxManager(s),
+		}, func() {}, nil
+	default:
+		pg, err := postgres.New(cfg.Pg.URL, postgres.MaxPoolSize(cfg.Pg.PoolMax))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &repositories{
+			users:         persistent.NewUserRepo(pg),
+			refreshTokens: persistent.NewRefreshTokenRepo(pg),
+			revokedTokens: persistent.NewRevokedTokenRepo(pg),
+			pvz:           persistent.NewPVZRepo(pg),
+			receptions:    persistent.NewReceptionRepo(pg),
+			products:      persistent.NewProductRepo(pg),
+			assignments:   persistent.NewPVZAssignmentRepo(pg),
+			cities:        cached.NewCityRepo(persistent.NewCityRepo(pg), cfg.Cache.CityTTL),
+			catalogue:     persistent.NewCatalogueRepo(pg),
+			returns:       persistent.NewReturnRepo(pg),
+			outbox:        persistent.NewOutboxRepo(pg),
+			webhooks:      persistent.NewWebhookRepo(pg),
+			eventStream:   persistent.NewEventStreamRepo(pg),
+			audit:         persistent.NewAuditRepo(pg),
+			reports:       persistent.NewReportRepo(pg),
+			idempotency:   persistent.NewIdempotencyRepo(pg),
+			tx: postgres.NewTxManager(
+				pg,
+				postgres.TxIsolation(pgx.TxIsoLevel(cfg.Pg.TxIsolation)),
+				postgres.TxMaxRetries(cfg.Pg.TxMaxRetries),
+			),
+		}, pg.Close, nil
+	}
+}
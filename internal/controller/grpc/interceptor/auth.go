@@ -0,0 +1,97 @@
+package interceptor
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AuthorizationMetadataKey = "authorization"
+	BearerSchema             = "Bearer "
+)
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal entity.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func CurrentPrincipal(ctx context.Context) (entity.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(entity.Principal)
+	return principal, ok
+}
+
+func Auth(
+	jwtService auth.TokenService,
+	roles map[string][]entity.UserRole,
+	logger logger.Interface,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		allowed, ok := roles[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method is not allowed")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(AuthorizationMetadataKey)
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+		}
+
+		if !strings.HasPrefix(values[0], BearerSchema) {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+
+		claims, err := jwtService.Validate(ctx, strings.TrimPrefix(values[0], BearerSchema))
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.ErrRevokedToken):
+				logger.Warn("JWT has been revoked")
+				return nil, status.Error(codes.Unauthenticated, auth.ErrRevokedToken.Error())
+			case errors.Is(err, auth.ErrInvalidToken):
+				logger.Error("JWT validation failed: %v", err)
+				return nil, status.Error(codes.Unauthenticated, "invalid token")
+			default:
+				logger.Error("JWT revocation check failed: %v", err)
+				return nil, status.Error(codes.Internal, entity.ErrInternal.Error())
+			}
+		}
+
+		if err := claims.Role.ValidateRole(); err != nil {
+			logger.Error("Invalid role in token: %s", claims.Role)
+			return nil, status.Error(codes.Unauthenticated, "invalid role in token")
+		}
+
+		for _, role := range allowed {
+			if claims.Role == role {
+				ctx = requestctx.WithPrincipal(ContextWithPrincipal(ctx, claims.Principal()), claims.Principal())
+				return handler(ctx, req)
+			}
+		}
+
+		return nil, status.Error(codes.PermissionDenied, "required role: "+strings.Join(userRolesToStrings(allowed), ", "))
+	}
+}
+
+func userRolesToStrings(roles []entity.UserRole) []string {
+	res := make([]string, len(roles))
+	for i, r := range roles {
+		res[i] = string(r)
+	}
+	return res
+}
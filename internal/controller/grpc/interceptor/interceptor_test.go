@@ -0,0 +1,203 @@
+package interceptor_test
+
+import (
+	"PVZ-avito-tech/internal/controller/grpc/interceptor"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo/inmemory"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type MockTokenService struct {
+	mock.Mock
+}
+
+func (m *MockTokenService) Generate(principal entity.Principal) (string, error) {
+	args := m.Called(principal)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenService) Validate(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Claims), args.Error(1)
+}
+
+func TestAuth(t *testing.T) {
+	const method = "/pvz.v1.PVZService/CreateReception"
+	userID := uuid.New()
+	roles := map[string][]entity.UserRole{
+		method: {entity.UserRoleEmployee},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		authMetadata string
+		mockSetup    func(*MockTokenService)
+		expectedCode codes.Code
+	}{
+		{
+			name:         "employee is allowed",
+			method:       method,
+			authMetadata: "Bearer employee",
+			mockSetup: func(tokenService *MockTokenService) {
+				tokenService.On("Validate", mock.Anything, "employee").Return(&auth.Claims{
+					Role:             entity.UserRoleEmployee,
+					RegisteredClaims: jwt.RegisteredClaims{Subject: userID.String()},
+				}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "moderator is denied",
+			method:       method,
+			authMetadata: "Bearer moderator",
+			mockSetup: func(tokenService *MockTokenService) {
+				tokenService.On("Validate", mock.Anything, "moderator").Return(&auth.Claims{
+					Role: entity.UserRoleModerator,
+				}, nil)
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "missing metadata",
+			method:       method,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "invalid metadata format",
+			method:       method,
+			authMetadata: "Basic token",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "revoked token",
+			method:       method,
+			authMetadata: "Bearer revoked",
+			mockSetup: func(tokenService *MockTokenService) {
+				tokenService.On("Validate", mock.Anything, "revoked").Return(nil, auth.ErrRevokedToken)
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "revocation list unavailable",
+			method:       method,
+			authMetadata: "Bearer unchecked",
+			mockSetup: func(tokenService *MockTokenService) {
+				tokenService.On("Validate", mock.Anything, "unchecked").Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "unknown method",
+			method:       "/pvz.v1.PVZService/Unknown",
+			authMetadata: "Bearer employee",
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := new(MockTokenService)
+			if tt.mockSetup != nil {
+				tt.mockSetup(tokenService)
+			}
+
+			ctx := context.Background()
+			if tt.authMetadata != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(interceptor.AuthorizationMetadataKey, tt.authMetadata))
+			}
+
+			var principal, ctxPrincipal entity.Principal
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				principal, _ = interceptor.CurrentPrincipal(ctx)
+				ctxPrincipal, _ = requestctx.Principal(ctx)
+				return "ok", nil
+			}
+
+			authInterceptor := interceptor.Auth(tokenService, roles, logger.NewMock())
+			_, err := authInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, userID, principal.UserID)
+				assert.Equal(t, entity.UserRoleEmployee, principal.Role)
+				assert.Equal(t, principal, ctxPrincipal)
+			}
+
+			tokenService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuth_AuditsCaller(t *testing.T) {
+	const method = "/pvz.v1.PVZService/CreateReception"
+	userID := uuid.New()
+	roles := map[string][]entity.UserRole{
+		method: {entity.UserRoleEmployee},
+	}
+
+	tokenService := new(MockTokenService)
+	tokenService.On("Validate", mock.Anything, "employee").Return(&auth.Claims{
+		Role:             entity.UserRoleEmployee,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: userID.String()},
+	}, nil)
+
+	storage := inmemory.NewStorage()
+	pvz := &entity.PVZ{City: entity.CityMoscow}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, inmemory.NewPVZRepo(storage).Create(ctx, pvz)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(interceptor.AuthorizationMetadataKey, "Bearer employee"))
+	authInterceptor := interceptor.Auth(tokenService, roles, logger.NewMock())
+	_, err := authInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	require.NoError(t, err)
+
+	entries, err := inmemory.NewAuditRepo(storage).List(context.Background(), entity.AuditFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entity.AuditPVZCreated, entries[0].Action)
+	require.NotNil(t, entries[0].ActorID)
+	assert.Equal(t, userID, *entries[0].ActorID)
+	assert.Equal(t, entity.UserRoleEmployee, entries[0].ActorRole)
+}
+
+func TestPrometheusInterceptor(t *testing.T) {
+	const method = "/pvz.v1.PVZService/GetPVZList"
+
+	before := testutil.ToFloat64(metrics.GRPCRequestsTotal.WithLabelValues(method, codes.NotFound.String()))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	_, err := interceptor.PrometheusInterceptor()(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		handler,
+	)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.GRPCRequestsTotal.WithLabelValues(method, codes.NotFound.String())))
+}
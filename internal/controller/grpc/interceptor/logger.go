@@ -0,0 +1,45 @@
+package interceptor
+
+import (
+	"PVZ-avito-tech/internal/pkg/logger"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func buildRequestMessage(ctx context.Context, method string, err error, duration time.Duration) string {
+	var result strings.Builder
+
+	if p, ok := peer.FromContext(ctx); ok {
+		result.WriteString(p.Addr.String())
+	}
+	result.WriteString(" - ")
+	result.WriteString(method)
+	result.WriteString(" - ")
+	result.WriteString(status.Code(err).String())
+	result.WriteString(" - ")
+	result.WriteString(duration.String())
+
+	return result.String()
+}
+
+func Logger(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		msg := buildRequestMessage(ctx, info.FullMethod, err, time.Since(start))
+		l.Info(msg)
+
+		return resp, err
+	}
+}
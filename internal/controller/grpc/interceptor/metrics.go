@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func PrometheusInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		duration := time.Since(start).Seconds()
+		code := status.Code(err).String()
+
+		metrics.GRPCRequestsTotal.WithLabelValues(
+			info.FullMethod,
+			code,
+		).Inc()
+
+		metrics.GRPCRequestDuration.WithLabelValues(
+			info.FullMethod,
+		).Observe(duration)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,42 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Service) GetPVZList(ctx context.Context, req *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
+	filter := dto.ReceptionFilter{
+		Page:  int(req.GetPage()),
+		Limit: int(req.GetLimit()),
+	}
+	if req.GetStartDate() != nil {
+		filter.StartDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		filter.EndDate = req.GetEndDate().AsTime()
+	}
+
+	filter.Apply(
+		dto.WithPaginationDefaults(),
+	)
+	pvzList, err := s.pvzUC.GetPVZWithReceptions(ctx, filter)
+	if err != nil {
+		s.logger.Error(entity.ErrGetPVZList, err)
+		return nil, status.Error(codes.Internal, entity.ErrGetPVZList.Error())
+	}
+
+	resp := &pvz_v1.GetPVZListResponse{
+		Pvzs: make([]*pvz_v1.PVZWithReceptions, 0, len(*pvzList)),
+	}
+	for _, info := range *pvzList {
+		resp.Pvzs = append(resp.Pvzs, pvzInfoToProto(info))
+	}
+
+	return resp, nil
+}
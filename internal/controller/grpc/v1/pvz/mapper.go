@@ -0,0 +1,77 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func uuidPtrToString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func receptionToProto(r *entity.Reception) *pvz_v1.Reception {
+	return &pvz_v1.Reception{
+		Id:        r.ID.String(),
+		DateTime:  timestamppb.New(r.DateTime),
+		PvzId:     r.PVZID.String(),
+		Status:    string(r.Status),
+		CreatedBy: uuidPtrToString(r.CreatedBy),
+	}
+}
+
+func productToProto(p *entity.Product) *pvz_v1.Product {
+	return &pvz_v1.Product{
+		Id:          p.ID.String(),
+		DateTime:    timestamppb.New(p.DateTime),
+		Type:        string(p.Type),
+		ReceptionId: p.ReceptionID.String(),
+		Category:    p.Category,
+		Barcode:     p.Barcode,
+		CreatedBy:   uuidPtrToString(p.CreatedBy),
+	}
+}
+
+func pvzInfoToProto(info dto.PVZInfo) *pvz_v1.PVZWithReceptions {
+	receptions := make([]*pvz_v1.ReceptionWithProducts, 0, len(info.Receptions))
+	for _, group := range info.Receptions {
+		products := make([]*pvz_v1.Product, 0, len(group.Products))
+		for _, p := range group.Products {
+			products = append(products, &pvz_v1.Product{
+				Id:          p.ID.String(),
+				DateTime:    timestamppb.New(p.DateTime),
+				Type:        string(p.Type),
+				ReceptionId: p.ReceptionID.String(),
+				Category:    p.Category,
+				Barcode:     p.Barcode,
+				CreatedBy:   uuidPtrToString(p.CreatedBy),
+			})
+		}
+
+		receptions = append(receptions, &pvz_v1.ReceptionWithProducts{
+			Reception: &pvz_v1.Reception{
+				Id:        group.Reception.ID.String(),
+				DateTime:  timestamppb.New(group.Reception.DateTime),
+				PvzId:     group.Reception.PVZID.String(),
+				Status:    string(group.Reception.Status),
+				CreatedBy: uuidPtrToString(group.Reception.CreatedBy),
+			},
+			Products: products,
+		})
+	}
+
+	return &pvz_v1.PVZWithReceptions{
+		Pvz: &pvz_v1.PVZ{
+			Id:               info.PVZ.ID.String(),
+			RegistrationDate: timestamppb.New(info.PVZ.RegistrationDate),
+			City:             string(info.PVZ.City),
+		},
+		Receptions: receptions,
+	}
+}
@@ -0,0 +1,42 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+	"context"
+)
+
+func (s *Service) AddProduct(ctx context.Context, req *pvz_v1.AddProductRequest) (*pvz_v1.Product, error) {
+	pvzID, principal, err := s.authorizePVZ(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productUC.AddProduct(ctx, &dto.PostAddProductRequest{
+		PvzID:       pvzID,
+		ProductType: entity.ProductType(req.GetType()),
+		Category:    req.GetCategory(),
+		Barcode:     req.GetBarcode(),
+	}, principal)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	metrics.ProductsAdded.Inc()
+	return productToProto(product), nil
+}
+
+func (s *Service) DeleteLastProduct(ctx context.Context, req *pvz_v1.DeleteLastProductRequest) (*pvz_v1.DeleteLastProductResponse, error) {
+	pvzID, _, err := s.authorizePVZ(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.productUC.DeleteProductLIFO(ctx, pvzID); err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return &pvz_v1.DeleteLastProductResponse{}, nil
+}
@@ -0,0 +1,37 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+	"context"
+)
+
+func (s *Service) CreateReception(ctx context.Context, req *pvz_v1.CreateReceptionRequest) (*pvz_v1.Reception, error) {
+	pvzID, principal, err := s.authorizePVZ(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	reception, err := s.receptionUC.CreateReception(ctx, dto.ReceptionsRequest{PvzId: pvzID}, principal)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	metrics.ReceptionsCreated.Inc()
+	return receptionToProto(reception), nil
+}
+
+func (s *Service) CloseLastReception(ctx context.Context, req *pvz_v1.CloseLastReceptionRequest) (*pvz_v1.Reception, error) {
+	pvzID, principal, err := s.authorizePVZ(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, err
+	}
+
+	reception, err := s.receptionUC.CloseReception(ctx, pvzID, principal)
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+
+	return receptionToProto(reception), nil
+}
@@ -0,0 +1,92 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/grpc/interceptor"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Service struct {
+	pvz_v1.UnimplementedPVZServiceServer
+
+	logger       logger.Interface
+	pvzUC        usecase.PVZUseCase
+	receptionUC  usecase.ReceptionUseCase
+	productUC    usecase.ProductUseCase
+	assignmentUC usecase.PVZAssignment
+}
+
+func NewService(
+	logger logger.Interface,
+	pvzUC usecase.PVZUseCase,
+	receptionUC usecase.ReceptionUseCase,
+	productUC usecase.ProductUseCase,
+	assignmentUC usecase.PVZAssignment,
+) *Service {
+	return &Service{
+		logger:       logger,
+		pvzUC:        pvzUC,
+		receptionUC:  receptionUC,
+		productUC:    productUC,
+		assignmentUC: assignmentUC,
+	}
+}
+
+func (s *Service) authorizePVZ(ctx context.Context, rawPVZID string) (uuid.UUID, entity.Principal, error) {
+	pvzID, err := uuid.Parse(rawPVZID)
+	if err != nil {
+		return uuid.Nil, entity.Principal{}, status.Error(codes.InvalidArgument, "invalid pvz id")
+	}
+
+	principal, ok := interceptor.CurrentPrincipal(ctx)
+	if !ok {
+		s.logger.Error("principal not found in context")
+		return uuid.Nil, entity.Principal{}, status.Error(codes.Internal, entity.ErrInternal.Error())
+	}
+
+	if err := s.assignmentUC.CheckAccess(ctx, principal, pvzID); err != nil {
+		return uuid.Nil, entity.Principal{}, s.toStatus(err)
+	}
+
+	return pvzID, principal, nil
+}
+
+func (s *Service) toStatus(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrPVZAccessDenied):
+		s.logger.Warn(err.Error())
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, entity.ErrPVZNotFound):
+		s.logger.Warn(err.Error())
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, entity.ErrInvalidProductType),
+		errors.Is(err, entity.ErrInvalidProductCategory),
+		errors.Is(err, entity.ErrProductCategoryNotFound),
+		errors.Is(err, entity.ErrInvalidBarcode):
+		s.logger.Warn(err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, entity.ErrBarcodeConflict):
+		s.logger.Warn(err.Error())
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, entity.ErrReceptionConflict),
+		errors.Is(err, entity.ErrProductTypeInactive),
+		errors.Is(err, entity.ErrProductCategoryInactive),
+		errors.Is(err, entity.ErrNoActiveReception),
+		errors.Is(err, entity.ErrNoProducts),
+		errors.Is(err, entity.ErrInvalidProductStatusTransition),
+		errors.Is(err, entity.ErrInvalidReceptionStatusTransition):
+		s.logger.Warn(err.Error())
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		s.logger.Error(err.Error())
+		return status.Error(codes.Internal, entity.ErrInternal.Error())
+	}
+}
@@ -0,0 +1,451 @@
+package pvz_test
+
+import (
+	"PVZ-avito-tech/internal/controller/grpc/interceptor"
+	"PVZ-avito-tech/internal/controller/grpc/v1/pvz"
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockPVZUC struct {
+	mock.Mock
+}
+
+func (m *MockPVZUC) CreatePVZ(ctx context.Context, p *entity.PVZ) (*entity.PVZ, error) {
+	args := m.Called(ctx, p)
+	return args.Get(0).(*entity.PVZ), args.Error(1)
+}
+
+func (m *MockPVZUC) GetPVZWithReceptions(ctx context.Context, filter dto.ReceptionFilter) (*[]dto.PVZInfo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.PVZInfo), args.Error(1)
+}
+
+func (m *MockPVZUC) GetPVZPage(ctx context.Context, filter dto.ReceptionFilter) (*dto.PVZPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PVZPage), args.Error(1)
+}
+
+func (m *MockPVZUC) GetPVZ(ctx context.Context, id uuid.UUID) (*dto.PVZDetails, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PVZDetails), args.Error(1)
+}
+
+type MockReceptionUC struct {
+	mock.Mock
+}
+
+func (m *MockReceptionUC) CreateReception(ctx context.Context, request dto.ReceptionsRequest, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, request, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) CloseReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) GetReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) ListProducts(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+func (m *MockReceptionUC) ReopenReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) CancelReception(ctx context.Context, id uuid.UUID, reason string, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, reason, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReceptionStatusChange), args.Error(1)
+}
+
+func (m *MockReceptionUC) CloseStaleReceptions(ctx context.Context, idleFor time.Duration) ([]entity.Reception, error) {
+	args := m.Called(ctx, idleFor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OpenReceptionStats), args.Error(1)
+}
+
+func (m *MockReceptionUC) DiscrepancyReport(ctx context.Context, id uuid.UUID) (*entity.DiscrepancyReport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscrepancyReport), args.Error(1)
+}
+
+type MockProductUC struct {
+	mock.Mock
+}
+
+func (m *MockProductUC) AddProduct(ctx context.Context, product *dto.PostAddProductRequest, actor entity.Principal) (*entity.Product, error) {
+	args := m.Called(ctx, product, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductUC) AddProducts(ctx context.Context, batch *dto.PostAddProductsBatchRequest, actor entity.Principal) ([]entity.ProductBatchResult, error) {
+	args := m.Called(ctx, batch, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductBatchResult), args.Error(1)
+}
+
+func (m *MockProductUC) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
+	args := m.Called(ctx, pvzID)
+	return args.Error(0)
+}
+
+func (m *MockProductUC) DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, actor entity.Principal) (*entity.ProductDeletion, error) {
+	args := m.Called(ctx, pvzID, productID, reason, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductDeletion), args.Error(1)
+}
+
+func (m *MockProductUC) GetProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductUC) IssueProduct(ctx context.Context, id uuid.UUID, pickupCode string, actor entity.Principal) (*entity.Product, error) {
+	args := m.Called(ctx, id, pickupCode, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductUC) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ProductStatusChange, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductStatusChange), args.Error(1)
+}
+
+func (m *MockProductUC) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductLocation), args.Error(1)
+}
+
+type MockAssignmentUC struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentUC) Assign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID, actor entity.Principal) (*entity.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID, userID, actor)
+	return args.Get(0).(*entity.PVZAssignment), args.Error(1)
+}
+
+func (m *MockAssignmentUC) Unassign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, pvzID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentUC) ListEmployees(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).([]entity.PVZAssignment), args.Error(1)
+}
+
+func (m *MockAssignmentUC) CheckAccess(ctx context.Context, principal entity.Principal, pvzID uuid.UUID) error {
+	args := m.Called(ctx, principal, pvzID)
+	return args.Error(0)
+}
+
+type mocks struct {
+	pvzUC        *MockPVZUC
+	receptionUC  *MockReceptionUC
+	productUC    *MockProductUC
+	assignmentUC *MockAssignmentUC
+}
+
+func newService() (*pvz.Service, mocks) {
+	m := mocks{
+		pvzUC:        new(MockPVZUC),
+		receptionUC:  new(MockReceptionUC),
+		productUC:    new(MockProductUC),
+		assignmentUC: new(MockAssignmentUC),
+	}
+	return pvz.NewService(logger.NewMock(), m.pvzUC, m.receptionUC, m.productUC, m.assignmentUC), m
+}
+
+func TestService_GetPVZList(t *testing.T) {
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		mockSetup    func(m mocks)
+		expectedCode codes.Code
+		expectedLen  int
+	}{
+		{
+			name: "maps pvz with receptions and products",
+			mockSetup: func(m mocks) {
+				m.pvzUC.On("GetPVZWithReceptions", mock.Anything, dto.ReceptionFilter{Page: 1, Limit: 10}).Return(&[]dto.PVZInfo{
+					{
+						PVZ: dto.PVZWithReceptions{ID: pvzID, RegistrationDate: now, City: entity.CityMoscow},
+						Receptions: []*dto.ReceptionGroup{
+							{
+								Reception: dto.ReceptionWithProducts{ID: receptionID, DateTime: now, PVZID: pvzID, Status: entity.InProgressStatus},
+								Products:  []dto.ProductDTO{{ID: uuid.New(), DateTime: now, Type: entity.ShoesProductType, ReceptionID: receptionID}},
+							},
+						},
+					},
+				}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedLen:  1,
+		},
+		{
+			name: "usecase error",
+			mockSetup: func(m mocks) {
+				m.pvzUC.On("GetPVZWithReceptions", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newService()
+			tt.mockSetup(m)
+
+			resp, err := service.GetPVZList(context.Background(), &pvz_v1.GetPVZListRequest{})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Len(t, resp.GetPvzs(), tt.expectedLen)
+				assert.Equal(t, pvzID.String(), resp.GetPvzs()[0].GetPvz().GetId())
+				assert.Equal(t, string(entity.CityMoscow), resp.GetPvzs()[0].GetPvz().GetCity())
+				assert.Len(t, resp.GetPvzs()[0].GetReceptions()[0].GetProducts(), 1)
+			}
+
+			m.pvzUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_CreateReception(t *testing.T) {
+	pvzID := uuid.New()
+	principal := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name         string
+		pvzID        string
+		mockSetup    func(m mocks)
+		expectedCode codes.Code
+	}{
+		{
+			name:  "successful creation",
+			pvzID: pvzID.String(),
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+				m.receptionUC.On("CreateReception", mock.Anything, dto.ReceptionsRequest{PvzId: pvzID}, principal).Return(&entity.Reception{
+					ID:     uuid.New(),
+					PVZID:  pvzID,
+					Status: entity.InProgressStatus,
+				}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "invalid pvz id",
+			pvzID:        "bad",
+			mockSetup:    func(m mocks) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:  "not assigned to pvz",
+			pvzID: pvzID.String(),
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(entity.ErrPVZAccessDenied)
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:  "reception already open",
+			pvzID: pvzID.String(),
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+				m.receptionUC.On("CreateReception", mock.Anything, mock.Anything, principal).Return(nil, entity.ErrReceptionConflict)
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newService()
+			tt.mockSetup(m)
+
+			ctx := interceptor.ContextWithPrincipal(context.Background(), principal)
+			resp, err := service.CreateReception(ctx, &pvz_v1.CreateReceptionRequest{PvzId: tt.pvzID})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, pvzID.String(), resp.GetPvzId())
+				assert.Equal(t, string(entity.InProgressStatus), resp.GetStatus())
+			}
+
+			m.assignmentUC.AssertExpectations(t)
+			m.receptionUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_AddProduct(t *testing.T) {
+	pvzID := uuid.New()
+	principal := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name         string
+		productType  string
+		mockSetup    func(m mocks)
+		expectedCode codes.Code
+	}{
+		{
+			name:        "successful add",
+			productType: string(entity.ElectronicsProductType),
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+				m.productUC.On("AddProduct", mock.Anything, &dto.PostAddProductRequest{
+					PvzID:       pvzID,
+					ProductType: entity.ElectronicsProductType,
+				}, principal).Return(&entity.Product{
+					ID:          uuid.New(),
+					Type:        entity.ElectronicsProductType,
+					ReceptionID: uuid.New(),
+					CreatedBy:   &principal.UserID,
+				}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:        "unknown product type",
+			productType: "мебель",
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+				m.productUC.On("AddProduct", mock.Anything, mock.Anything, principal).Return(nil, entity.ErrInvalidProductType)
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:        "no active reception",
+			productType: string(entity.ShoesProductType),
+			mockSetup: func(m mocks) {
+				m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+				m.productUC.On("AddProduct", mock.Anything, mock.Anything, principal).Return(nil, entity.ErrNoActiveReception)
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newService()
+			tt.mockSetup(m)
+
+			ctx := interceptor.ContextWithPrincipal(context.Background(), principal)
+			resp, err := service.AddProduct(ctx, &pvz_v1.AddProductRequest{PvzId: pvzID.String(), Type: tt.productType})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, tt.productType, resp.GetType())
+				assert.Equal(t, principal.UserID.String(), resp.GetCreatedBy())
+			}
+
+			m.assignmentUC.AssertExpectations(t)
+			m.productUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_DeleteLastProduct(t *testing.T) {
+	pvzID := uuid.New()
+	principal := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleEmployee}
+
+	service, m := newService()
+	m.assignmentUC.On("CheckAccess", mock.Anything, principal, pvzID).Return(nil)
+	m.productUC.On("DeleteProductLIFO", mock.Anything, pvzID).Return(entity.ErrNoProducts)
+
+	ctx := interceptor.ContextWithPrincipal(context.Background(), principal)
+	_, err := service.DeleteLastProduct(ctx, &pvz_v1.DeleteLastProductRequest{PvzId: pvzID.String()})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	m.productUC.AssertExpectations(t)
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"PVZ-avito-tech/internal/controller/grpc/interceptor"
+	"PVZ-avito-tech/internal/controller/grpc/v1/pvz"
+	"PVZ-avito-tech/internal/entity"
+	authPkg "PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	pvz_v1 "PVZ-avito-tech/pkg/pvz/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var methodRoles = map[string][]entity.UserRole{
+	pvz_v1.PVZService_GetPVZList_FullMethodName:         {entity.UserRoleModerator, entity.UserRoleEmployee},
+	pvz_v1.PVZService_CreateReception_FullMethodName:    {entity.UserRoleEmployee},
+	pvz_v1.PVZService_CloseLastReception_FullMethodName: {entity.UserRoleEmployee},
+	pvz_v1.PVZService_AddProduct_FullMethodName:         {entity.UserRoleEmployee},
+	pvz_v1.PVZService_DeleteLastProduct_FullMethodName:  {entity.UserRoleEmployee},
+}
+
+func NewServer(
+	l logger.Interface,
+	pvzUC usecase.PVZUseCase,
+	receptionUC usecase.ReceptionUseCase,
+	productUC usecase.ProductUseCase,
+	assignmentUC usecase.PVZAssignment,
+	jwtService authPkg.TokenService,
+) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.Logger(l),
+			interceptor.PrometheusInterceptor(),
+			interceptor.Auth(jwtService, methodRoles, l),
+		),
+	)
+
+	pvz_v1.RegisterPVZServiceServer(server, pvz.NewService(
+		l,
+		pvzUC,
+		receptionUC,
+		productUC,
+		assignmentUC,
+	))
+	reflection.Register(server)
+
+	return server
+}
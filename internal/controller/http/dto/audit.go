@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"time"
+)
+
+type AuditFilter struct {
+	EntityType entity.AuditEntityType `form:"entityType"`
+	EntityID   string                 `form:"entityId"`
+	ActorID    string                 `form:"actorId"`
+	From       time.Time              `form:"from"`
+	To         time.Time              `form:"to"`
+	BeforeID   int64                  `form:"beforeId" binding:"omitempty,min=1"`
+	Limit      int                    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
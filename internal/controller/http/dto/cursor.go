@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type PVZCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+	Sort      string    `json:"s,omitempty"`
+}
+
+func (c PVZCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodePVZCursor(s string) (*PVZCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c PVZCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+func (f *ReceptionFilter) DecodeCursor() error {
+	if f.Cursor == "" {
+		f.After = nil
+		return nil
+	}
+
+	c, err := DecodePVZCursor(f.Cursor)
+	if err != nil {
+		return err
+	}
+	if c.Sort == "" {
+		c.Sort = PVZSortRegistrationDate
+	}
+	sort := f.Sort
+	if sort == "" {
+		sort = PVZSortRegistrationDate
+	}
+	if c.Sort != sort {
+		return ErrInvalidCursor
+	}
+	f.After = c
+	return nil
+}
+
+func NewPVZPage(items []PVZInfo, filter ReceptionFilter) *PVZPage {
+	page := &PVZPage{Items: items}
+	if filter.Limit > 0 && len(items) == filter.Limit {
+		last := items[len(items)-1].PVZ
+		next := PVZCursor{CreatedAt: last.RegistrationDate, ID: last.ID}
+		if filter.Sort == PVZSortLastActivity {
+			next.CreatedAt = last.LastActivity
+			next.Sort = PVZSortLastActivity
+		}
+		page.NextCursor = next.Encode()
+	}
+	return page
+}
@@ -20,8 +20,8 @@ func TestCreatePVZRequest_Validation(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "invalid city",
-			input:   dto.CreatePVZRequest{City: "invalid"},
+			name:    "empty city",
+			input:   dto.CreatePVZRequest{City: ""},
 			wantErr: true,
 		},
 	}
@@ -52,6 +52,94 @@ func TestReceptionFilter_ApplyOptions(t *testing.T) {
 	}
 }
 
+func TestReceptionFilter_CursorModeDefaults(t *testing.T) {
+	filter := &dto.ReceptionFilter{
+		Page:  3,
+		Limit: 250,
+	}
+
+	filter.Apply(dto.WithCursorMode(), dto.WithPaginationDefaults())
+
+	if filter.Page != 1 {
+		t.Errorf("Expected page 1, got %d", filter.Page)
+	}
+	if filter.Limit != dto.MaxCursorLimit {
+		t.Errorf("Expected limit %d, got %d", dto.MaxCursorLimit, filter.Limit)
+	}
+}
+
+func TestPVZCursor_RoundTrip(t *testing.T) {
+	cursor := dto.PVZCursor{
+		CreatedAt: time.Date(2024, 4, 1, 10, 0, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	filter := &dto.ReceptionFilter{Cursor: cursor.Encode()}
+	if err := filter.DecodeCursor(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !filter.After.CreatedAt.Equal(cursor.CreatedAt) || filter.After.ID != cursor.ID {
+		t.Errorf("Expected %+v, got %+v", cursor, *filter.After)
+	}
+
+	for _, raw := range []string{"not base64!", "e30", "bnVsbA"} {
+		filter := &dto.ReceptionFilter{Cursor: raw}
+		if err := filter.DecodeCursor(); err == nil {
+			t.Errorf("Expected error for cursor %q", raw)
+		}
+	}
+}
+
+func TestNewPVZPage_NextCursor(t *testing.T) {
+	now := time.Now()
+	items := []dto.PVZInfo{
+		{PVZ: dto.PVZWithReceptions{ID: uuid.New(), RegistrationDate: now}},
+		{PVZ: dto.PVZWithReceptions{ID: uuid.New(), RegistrationDate: now.Add(-time.Minute)}},
+	}
+
+	page := dto.NewPVZPage(items, dto.ReceptionFilter{Limit: 2})
+	if page.NextCursor == "" {
+		t.Fatal("Expected next cursor for a full page")
+	}
+	next, err := dto.DecodePVZCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.ID != items[1].PVZ.ID {
+		t.Errorf("Expected cursor to point at the last item")
+	}
+
+	if page := dto.NewPVZPage(items, dto.ReceptionFilter{Limit: 10}); page.NextCursor != "" {
+		t.Errorf("Expected no next cursor for a partial page, got %q", page.NextCursor)
+	}
+}
+
+func TestPVZCursor_SortBinding(t *testing.T) {
+	now := time.Now().UTC()
+	items := []dto.PVZInfo{
+		{PVZ: dto.PVZWithReceptions{ID: uuid.New(), RegistrationDate: now.Add(-time.Hour), LastActivity: now}},
+	}
+
+	page := dto.NewPVZPage(items, dto.ReceptionFilter{Limit: 1, Sort: dto.PVZSortLastActivity})
+	next, err := dto.DecodePVZCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next.CreatedAt.Equal(now) {
+		t.Errorf("Expected cursor on last activity %v, got %v", now, next.CreatedAt)
+	}
+
+	filter := &dto.ReceptionFilter{Cursor: page.NextCursor, Sort: dto.PVZSortLastActivity}
+	if err := filter.DecodeCursor(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	filter = &dto.ReceptionFilter{Cursor: page.NextCursor}
+	if err := filter.DecodeCursor(); err == nil {
+		t.Error("Expected error when sort order differs from cursor")
+	}
+}
+
 func TestPVZWithReceptions_Structure(t *testing.T) {
 	pvz := dto.PVZWithReceptions{
 		ID:               uuid.New(),
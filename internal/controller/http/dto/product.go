@@ -9,11 +9,55 @@ import (
 type PostAddProductRequest struct {
 	PvzID       uuid.UUID          `json:"pvzId"`
 	ProductType entity.ProductType `json:"type"`
+	Category    string             `json:"category,omitempty"`
+	PickupCode  string             `json:"pickupCode,omitempty" binding:"omitempty,min=4,max=32"`
+	Barcode     string             `json:"barcode,omitempty"`
+}
+
+type AddProductItem struct {
+	ProductType entity.ProductType `json:"type"`
+	Category    string             `json:"category,omitempty"`
+	PickupCode  string             `json:"pickupCode,omitempty"`
+	Barcode     string             `json:"barcode,omitempty"`
+}
+
+type PostAddProductsBatchRequest struct {
+	PvzID    uuid.UUID        `json:"pvzId" binding:"required"`
+	Products []AddProductItem `json:"products" binding:"required,min=1,max=500"`
+}
+
+type DeleteProductRequest struct {
+	Reason string `form:"reason" binding:"required,max=500"`
+}
+
+type ProductLookupRequest struct {
+	Barcode string `form:"barcode" binding:"required"`
+}
+
+type IssueProductRequest struct {
+	PickupCode string `json:"pickupCode"`
 }
 
 type PostAddProductResponse struct {
-	ID          uuid.UUID          `json:"id"`
-	DateTime    time.Time          `json:"dateTime"`
-	Type        entity.ProductType `json:"type"`
-	ReceptionID uuid.UUID          `json:"receptionId"`
+	ID          uuid.UUID            `json:"id"`
+	DateTime    time.Time            `json:"dateTime"`
+	Type        entity.ProductType   `json:"type"`
+	ReceptionID uuid.UUID            `json:"receptionId"`
+	Category    string               `json:"category,omitempty"`
+	Barcode     string               `json:"barcode,omitempty"`
+	Status      entity.ProductStatus `json:"status,omitempty"`
+	PickupCode  string               `json:"pickupCode,omitempty"`
+	CreatedBy   *uuid.UUID           `json:"createdBy,omitempty"`
+}
+
+type ProductBatchItemResult struct {
+	Index   int                     `json:"index"`
+	Product *PostAddProductResponse `json:"product,omitempty"`
+	Error   string                  `json:"error,omitempty"`
+}
+
+type PostAddProductsBatchResponse struct {
+	Accepted int                      `json:"accepted"`
+	Rejected int                      `json:"rejected"`
+	Results  []ProductBatchItemResult `json:"results"`
 }
@@ -16,6 +16,14 @@ type PVZWithReceptions struct {
 	ID               uuid.UUID   `json:"id"`
 	RegistrationDate time.Time   `json:"registrationDate"`
 	City             entity.City `json:"city"`
+	LastActivity     time.Time   `json:"lastActivity"`
+}
+
+type PVZDetails struct {
+	ID               uuid.UUID         `json:"id"`
+	RegistrationDate time.Time         `json:"registrationDate"`
+	City             entity.City       `json:"city"`
+	ActiveReception  *entity.Reception `json:"activeReception"`
 }
 
 type ReceptionGroup struct {
@@ -24,17 +32,22 @@ type ReceptionGroup struct {
 }
 
 type ReceptionWithProducts struct {
-	ID       uuid.UUID               `json:"id"`
-	DateTime time.Time               `json:"dateTime"`
-	PVZID    uuid.UUID               `json:"pvzId"`
-	Status   entity.ReceptionsStatus `json:"status"`
+	ID        uuid.UUID               `json:"id"`
+	DateTime  time.Time               `json:"dateTime"`
+	PVZID     uuid.UUID               `json:"pvzId"`
+	Status    entity.ReceptionsStatus `json:"status"`
+	CreatedBy *uuid.UUID              `json:"createdBy,omitempty"`
 }
 
 type ProductDTO struct {
-	ID          uuid.UUID          `json:"id"`
-	DateTime    time.Time          `json:"dateTime"`
-	Type        entity.ProductType `json:"type"`
-	ReceptionID uuid.UUID          `json:"receptionId"`
+	ID          uuid.UUID            `json:"id"`
+	DateTime    time.Time            `json:"dateTime"`
+	Type        entity.ProductType   `json:"type"`
+	ReceptionID uuid.UUID            `json:"receptionId"`
+	Category    string               `json:"category,omitempty"`
+	Barcode     string               `json:"barcode,omitempty"`
+	Status      entity.ProductStatus `json:"status,omitempty"`
+	CreatedBy   *uuid.UUID           `json:"createdBy,omitempty"`
 }
 
 type CreatePVZRequest struct {
@@ -43,28 +56,84 @@ type CreatePVZRequest struct {
 	RegistrationDate *time.Time  `json:"registrationDate,omitempty"`
 }
 
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 30
+	MaxCursorLimit   = 100
+)
+
+const (
+	PVZSortRegistrationDate = "registrationDate"
+	PVZSortLastActivity     = "lastActivity"
+)
+
 type ReceptionFilter struct {
-	Page      int       `form:"page" json:"page" binding:"omitempty,min=1" default:"1"`
-	Limit     int       `form:"limit" json:"limit" binding:"omitempty,min=1,max=30" default:"10"`
-	StartDate time.Time `form:"startDate" json:"startDate" binding:"omitempty,datetime"`
-	EndDate   time.Time `form:"endDate" json:"endDate" binding:"omitempty,datetime"`
+	Page       int        `form:"page" json:"page" binding:"omitempty,min=1" default:"1"`
+	Limit      int        `form:"limit" json:"limit" binding:"omitempty,min=1,max=100" default:"10"`
+	StartDate  time.Time  `form:"startDate" json:"startDate" binding:"omitempty,datetime"`
+	EndDate    time.Time  `form:"endDate" json:"endDate" binding:"omitempty,datetime"`
+	Cursor     string     `form:"cursor" json:"cursor"`
+	CursorMode bool       `form:"-" json:"-"`
+	After      *PVZCursor `form:"-" json:"-"`
+
+	Cities             []entity.City      `form:"city" json:"cities"`
+	HasActiveReception *bool              `form:"hasActiveReception" json:"hasActiveReception"`
+	ProductType        entity.ProductType `form:"productType" json:"productType"`
+	Sort               string             `form:"sort" json:"sort" binding:"omitempty,oneof=registrationDate lastActivity"`
+}
+
+type PVZPage struct {
+	Items      []PVZInfo `json:"items"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 type Option func(*ReceptionFilter)
 
 func WithPaginationDefaults() Option {
 	return func(f *ReceptionFilter) {
+		if f.CursorMode {
+			f.Page = 1
+			if f.Limit < 1 {
+				f.Limit = DefaultPageLimit
+			}
+			if f.Limit > MaxCursorLimit {
+				f.Limit = MaxCursorLimit
+			}
+			return
+		}
 		if f.Page < 1 {
 			f.Page = 1
 		}
-		if f.Limit < 1 || f.Limit > 30 {
-			f.Limit = 10
+		if f.Limit < 1 || f.Limit > MaxPageLimit {
+			f.Limit = DefaultPageLimit
 		}
 	}
 }
 
+func WithCursorMode() Option {
+	return func(f *ReceptionFilter) {
+		f.CursorMode = true
+	}
+}
+
 func (f *ReceptionFilter) Apply(opts ...Option) {
 	for _, opt := range opts {
 		opt(f)
 	}
 }
+
+type CityRequest struct {
+	Name entity.City `json:"name" binding:"required"`
+}
+
+type ProductTypeRequest struct {
+	Name entity.ProductType `json:"name" binding:"required"`
+}
+
+type ProductCategoryRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CatalogueActivityRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
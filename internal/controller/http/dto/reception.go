@@ -1,9 +1,15 @@
 package dto
 
 import (
+	"PVZ-avito-tech/internal/entity"
 	"github.com/google/uuid"
 )
 
 type ReceptionsRequest struct {
-	PvzId uuid.UUID `json:"pvzId"`
+	PvzId    uuid.UUID             `json:"pvzId"`
+	Manifest []entity.ManifestItem `json:"manifest,omitempty" binding:"omitempty,max=1000"`
+}
+
+type CancelReceptionRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
 }
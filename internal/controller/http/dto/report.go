@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"time"
+)
+
+const (
+	ReportFormatJSON = "json"
+	ReportFormatCSV  = "csv"
+	ReportFormatXLSX = "xlsx"
+)
+
+// AcceptanceReportFilter selects the days StartDate..EndDate, both inclusive.
+type AcceptanceReportFilter struct {
+	StartDate time.Time     `form:"startDate" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	EndDate   time.Time     `form:"endDate" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	PVZID     string        `form:"pvzId"`
+	Cities    []entity.City `form:"city"`
+	Format    string        `form:"format" binding:"omitempty,oneof=json csv xlsx"`
+}
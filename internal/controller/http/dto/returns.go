@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateReturnRequest struct {
+	PvzID     uuid.UUID `json:"pvzId" binding:"required"`
+	ProductID uuid.UUID `json:"productId" binding:"required"`
+	Reason    string    `json:"reason" binding:"max=1000"`
+}
+
+type ReturnShipmentRequest struct {
+	PvzID uuid.UUID `json:"pvzId" binding:"required"`
+}
+
+type AddReturnToShipmentRequest struct {
+	ReturnID uuid.UUID `json:"returnId" binding:"required"`
+}
+
+type ReturnsListFilter struct {
+	Outstanding bool `form:"outstanding" json:"outstanding"`
+}
+
+type ReturnsReportFilter struct {
+	StartDate time.Time `form:"startDate" json:"startDate"`
+	EndDate   time.Time `form:"endDate" json:"endDate"`
+}
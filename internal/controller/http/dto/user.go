@@ -1,6 +1,9 @@
 package dto
 
-import "PVZ-avito-tech/internal/entity"
+import (
+	"PVZ-avito-tech/internal/entity"
+	"github.com/google/uuid"
+)
 
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -16,3 +19,15 @@ type RegisterRequest struct {
 type DummyLoginRequest struct {
 	Role entity.UserRole `json:"role" binding:"required"`
 }
+
+type AssignEmployeeRequest struct {
+	UserID uuid.UUID `json:"userId" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
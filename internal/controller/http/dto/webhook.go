@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"github.com/google/uuid"
+)
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string             `json:"url" binding:"required,max=2048"`
+	EventTypes []entity.EventType `json:"eventTypes" binding:"required,min=1,max=20"`
+	PvzID      *uuid.UUID         `json:"pvzId"`
+	City       *entity.City       `json:"city"`
+	Secret     string             `json:"secret" binding:"max=256"`
+}
+
+// WebhookSubscriptionCreated is the only response that reveals the signing secret.
+type WebhookSubscriptionCreated struct {
+	entity.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryFilter struct {
+	Status entity.WebhookDeliveryStatus `form:"status"`
+	Limit  int                          `form:"limit" binding:"omitempty,min=1,max=500"`
+}
@@ -5,4 +5,5 @@ const (
 	ErrInvalidParam       = "invalid param"
 	ErrInvalidRole        = "invalid role"
 	ErrTokenGeneration    = "failed to generate token"
+	ErrNotAcceptable      = "requested format is not supported"
 )
@@ -11,5 +11,30 @@ func EntityProductToProductResponse(ent *entity.Product) *dto.PostAddProductResp
 		DateTime:    ent.DateTime,
 		Type:        ent.Type,
 		ReceptionID: ent.ReceptionID,
+		Category:    ent.Category,
+		Barcode:     ent.Barcode,
+		Status:      ent.Status,
+		PickupCode:  ent.PickupCode,
+		CreatedBy:   ent.CreatedBy,
 	}
 }
+
+func EntityProductBatchToResponse(results []entity.ProductBatchResult) *dto.PostAddProductsBatchResponse {
+	resp := &dto.PostAddProductsBatchResponse{
+		Results: make([]dto.ProductBatchItemResult, 0, len(results)),
+	}
+
+	for i, result := range results {
+		item := dto.ProductBatchItemResult{Index: i}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+			resp.Rejected++
+		} else {
+			item.Product = EntityProductToProductResponse(result.Product)
+			resp.Accepted++
+		}
+		resp.Results = append(resp.Results, item)
+	}
+
+	return resp
+}
@@ -4,15 +4,19 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
 )
 
 const (
-	AuthorizationHeader = "Authorization"
-	UserRoleContextKey  = "userRole"
-	BearerSchema        = "Bearer "
+	AuthorizationHeader   = "Authorization"
+	UserRoleContextKey    = "userRole"
+	TokenClaimsContextKey = "tokenClaims"
+	PrincipalContextKey   = "principal"
+	BearerSchema          = "Bearer "
 )
 
 func AuthMiddleware(jwtService auth.TokenService, logger logger.Interface) gin.HandlerFunc {
@@ -29,10 +33,19 @@ func AuthMiddleware(jwtService auth.TokenService, logger logger.Interface) gin.H
 		}
 
 		token := strings.TrimPrefix(authHeader, BearerSchema)
-		claims, err := jwtService.Validate(token)
+		claims, err := jwtService.Validate(c.Request.Context(), token)
 		if err != nil {
-			logger.Error("JWT validation failed: %v", err)
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid token"})
+			switch {
+			case errors.Is(err, auth.ErrRevokedToken):
+				logger.Warn("JWT has been revoked")
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": auth.ErrRevokedToken.Error()})
+			case errors.Is(err, auth.ErrInvalidToken):
+				logger.Error("JWT validation failed: %v", err)
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid token"})
+			default:
+				logger.Error("JWT revocation check failed: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternal.Error()})
+			}
 			return
 		}
 
@@ -43,10 +56,23 @@ func AuthMiddleware(jwtService auth.TokenService, logger logger.Interface) gin.H
 		}
 
 		c.Set(UserRoleContextKey, claims.Role)
+		c.Set(TokenClaimsContextKey, claims)
+		c.Set(PrincipalContextKey, claims.Principal())
+		c.Request = c.Request.WithContext(requestctx.WithPrincipal(c.Request.Context(), claims.Principal()))
 		c.Next()
 	}
 }
 
+func CurrentPrincipal(c *gin.Context) (entity.Principal, bool) {
+	value, exists := c.Get(PrincipalContextKey)
+	if !exists {
+		return entity.Principal{}, false
+	}
+
+	principal, ok := value.(entity.Principal)
+	return principal, ok
+}
+
 func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		roleValue, exists := c.Get(UserRoleContextKey)
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotentBodyBytes bounds the body buffered to hash the request.
+const maxIdempotentBodyBytes = 1 << 20
+
+// secretResponseFields are dropped from a response before it is stored for
+// replay: pickup codes are otherwise kept only as an HMAC.
+var secretResponseFields = []string{"pickupCode"}
+
+// Idempotency replays the stored response of a POST retried with the same
+// Idempotency-Key and body. It must run after AuthMiddleware: keys are scoped
+// to the path and the principal, so one caller cannot read another caller's
+// responses. Server errors are not stored and the retry is processed again.
+// Secret fields are stripped from the stored response, so a replay lacks them.
+func Idempotency(uc usecase.Idempotency, logger logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "principal not found in context"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body is too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := idempotencyScope(c.Request.URL.Path, principal)
+		bodyHash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(bodyHash[:])
+
+		record, err := uc.Begin(c.Request.Context(), scope, key, requestHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, entity.ErrInvalidIdempotencyKey):
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, entity.ErrIdempotencyKeyReused):
+				logger.Warn("idempotency key reused: key=%s path=%s", key, c.Request.URL.Path)
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			case errors.Is(err, entity.ErrIdempotencyKeyInProgress):
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				logger.Error("idempotency check failed: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternal.Error()})
+			}
+			return
+		}
+
+		if record != nil {
+			metrics.IdempotentReplays.Inc()
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(record.StatusCode, record.ContentType, record.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// The client may be gone already, but the outcome must still be saved.
+		ctx := context.WithoutCancel(c.Request.Context())
+		stored, ok := redactSecrets(recorder.body.Bytes())
+		if status := recorder.Status(); status >= http.StatusInternalServerError || !ok {
+			err = uc.Release(ctx, scope, key)
+		} else {
+			err = uc.Complete(ctx, scope, key, status, recorder.Header().Get("Content-Type"), stored)
+		}
+		if err != nil {
+			logger.Error("failed to finish idempotent request: %v", err)
+		}
+	}
+}
+
+func idempotencyScope(path string, principal entity.Principal) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(principal.UserID[:])
+	h.Write([]byte(principal.Role))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// redactSecrets removes secretResponseFields from a JSON body at any depth. It
+// reports false when the body mentions a secret field but is not JSON.
+func redactSecrets(body []byte) ([]byte, bool) {
+	found := false
+	for _, field := range secretResponseFields {
+		if bytes.Contains(body, []byte(`"`+field+`"`)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return body, true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	dropSecretFields(value)
+
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return redacted, true
+}
+
+func dropSecretFields(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range secretResponseFields {
+			delete(v, field)
+		}
+		for _, item := range v {
+			dropSecretFields(item)
+		}
+	case []any:
+		for _, item := range v {
+			dropSecretFields(item)
+		}
+	}
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -5,12 +5,18 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,13 +25,13 @@ type MockTokenService struct {
 	mock.Mock
 }
 
-func (m *MockTokenService) Generate(role entity.UserRole) (string, error) {
-	args := m.Called(role)
+func (m *MockTokenService) Generate(principal entity.Principal) (string, error) {
+	args := m.Called(principal)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockTokenService) Validate(token string) (*auth.Claims, error) {
-	args := m.Called(token)
+func (m *MockTokenService) Validate(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
 	return args.Get(0).(*auth.Claims), args.Error(1)
 }
 
@@ -56,19 +62,31 @@ func TestAuthMiddleware(t *testing.T) {
 			name:       "invalid token",
 			authHeader: "Bearer bad_token",
 			mockSetup: func() {
-				tokenService.On("Validate", "bad_token").Return(
+				tokenService.On("Validate", mock.Anything, "bad_token").Return(
 					&auth.Claims{},
-					errors.New("invalid token"),
+					auth.ErrInvalidToken,
 				)
 			},
 			expectedStatus: http.StatusForbidden,
 			expectedError:  "invalid token",
 		},
+		{
+			name:       "revocation list unavailable",
+			authHeader: "Bearer unchecked_token",
+			mockSetup: func() {
+				tokenService.On("Validate", mock.Anything, "unchecked_token").Return(
+					&auth.Claims{},
+					errors.New("failed to check token revocation: db error"),
+				)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  entity.ErrInternal.Error(),
+		},
 		{
 			name:       "valid token with invalid role",
 			authHeader: "Bearer valid_token",
 			mockSetup: func() {
-				tokenService.On("Validate", "valid_token").Return(
+				tokenService.On("Validate", mock.Anything, "valid_token").Return(
 					&auth.Claims{Role: "invalid_role"},
 					nil,
 				)
@@ -76,6 +94,29 @@ func TestAuthMiddleware(t *testing.T) {
 			expectedStatus: http.StatusForbidden,
 			expectedError:  "invalid role in token",
 		},
+		{
+			name:       "revoked token",
+			authHeader: "Bearer revoked_token",
+			mockSetup: func() {
+				tokenService.On("Validate", mock.Anything, "revoked_token").Return(
+					&auth.Claims{},
+					auth.ErrRevokedToken,
+				)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  auth.ErrRevokedToken.Error(),
+		},
+		{
+			name:       "valid token",
+			authHeader: "Bearer good_token",
+			mockSetup: func() {
+				tokenService.On("Validate", mock.Anything, "good_token").Return(
+					&auth.Claims{Role: entity.UserRoleEmployee},
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +201,445 @@ func TestRequireRole(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentPrincipal(t *testing.T) {
+	loggerMock := logger.NewMock()
+	tokenService := new(MockTokenService)
+	userID := uuid.New()
+
+	tokenService.On("Validate", mock.Anything, "employee_token").Return(
+		&auth.Claims{
+			Role:  entity.UserRoleEmployee,
+			Email: "employee@example.com",
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject: userID.String(),
+			},
+		},
+		nil,
+	)
+
+	var (
+		principal    entity.Principal
+		found        bool
+		ctxPrincipal entity.Principal
+	)
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(tokenService, loggerMock))
+	r.GET("/test", func(c *gin.Context) {
+		principal, found = middleware.CurrentPrincipal(c)
+		ctxPrincipal, _ = requestctx.Principal(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer employee_token")
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, found)
+	assert.Equal(t, entity.Principal{
+		UserID: userID,
+		Email:  "employee@example.com",
+		Role:   entity.UserRoleEmployee,
+	}, principal)
+	assert.Equal(t, principal, ctxPrincipal)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	_, found = middleware.CurrentPrincipal(c)
+	assert.False(t, found)
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{name: "propagates caller id", header: "req-42"},
+		{name: "generates when missing", generated: true},
+		{name: "replaces oversized id", header: strings.Repeat("x", 200), generated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromCtx string
+
+			r := gin.New()
+			r.Use(middleware.RequestID())
+			r.GET("/test", func(c *gin.Context) {
+				fromCtx = requestctx.RequestID(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get(middleware.RequestIDHeader)
+			assert.Equal(t, id, fromCtx)
+			if tt.generated {
+				_, err := uuid.Parse(id)
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.header, id)
+			}
+		})
+	}
+}
+
+type MockAssignmentUC struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentUC) Assign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID, actor entity.Principal) (*entity.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID, userID, actor)
+	return args.Get(0).(*entity.PVZAssignment), args.Error(1)
+}
+
+func (m *MockAssignmentUC) Unassign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, pvzID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentUC) ListEmployees(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).([]entity.PVZAssignment), args.Error(1)
+}
+
+func (m *MockAssignmentUC) CheckAccess(ctx context.Context, principal entity.Principal, pvzID uuid.UUID) error {
+	args := m.Called(ctx, principal, pvzID)
+	return args.Error(0)
+}
+
+func TestRequirePVZAccess(t *testing.T) {
+	loggerMock := logger.NewMock()
+	principal := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleEmployee}
+	allowedPVZ := uuid.New()
+	deniedPVZ := uuid.New()
+
+	tests := []struct {
+		name           string
+		withPrincipal  bool
+		path           string
+		body           string
+		fromBody       bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "assigned pvz from path",
+			withPrincipal:  true,
+			path:           "/pvz/" + allowedPVZ.String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not assigned pvz from path",
+			withPrincipal:  true,
+			path:           "/pvz/" + deniedPVZ.String(),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   entity.ErrPVZAccessDenied.Error(),
+		},
+		{
+			name:           "invalid pvz id in path",
+			withPrincipal:  true,
+			path:           "/pvz/not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "assigned pvz from body keeps body readable",
+			withPrincipal:  true,
+			path:           "/pvz/body",
+			body:           `{"pvzId":"` + allowedPVZ.String() + `","type":"обувь"}`,
+			fromBody:       true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "обувь",
+		},
+		{
+			name:           "missing pvz id in body",
+			withPrincipal:  true,
+			path:           "/pvz/body",
+			body:           `{"type":"обувь"}`,
+			fromBody:       true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "oversized body",
+			withPrincipal:  true,
+			path:           "/pvz/body",
+			body:           `{"pvzId":"` + allowedPVZ.String() + `","type":"` + strings.Repeat("a", 1<<20) + `"}`,
+			fromBody:       true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "principal not in context",
+			path:           "/pvz/" + allowedPVZ.String(),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := new(MockAssignmentUC)
+			checker.On("CheckAccess", mock.Anything, principal, allowedPVZ).Return(nil).Maybe()
+			checker.On("CheckAccess", mock.Anything, principal, deniedPVZ).Return(entity.ErrPVZAccessDenied).Maybe()
+
+			extractor := middleware.PVZIDFromParam("pvzId")
+			if tt.fromBody {
+				extractor = middleware.PVZIDFromJSONBody("pvzId")
+			}
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.withPrincipal {
+					c.Set(middleware.PrincipalContextKey, principal)
+				}
+			})
+			handler := func(c *gin.Context) {
+				body, _ := io.ReadAll(c.Request.Body)
+				c.String(http.StatusOK, string(body))
+			}
+			if tt.fromBody {
+				r.POST(tt.path, middleware.RequirePVZAccess(checker, extractor, loggerMock), handler)
+			} else {
+				r.POST("/pvz/:pvzId", middleware.RequirePVZAccess(checker, extractor, loggerMock), handler)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+type MockIdempotencyUC struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyUC) Begin(ctx context.Context, scope, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	args := m.Called(ctx, scope, key, requestHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyUC) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	args := m.Called(ctx, scope, key, statusCode, contentType, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyUC) Release(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyUC) Purge(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func withPrincipal(principal entity.Principal) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.PrincipalContextKey, principal)
+		c.Next()
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	loggerMock := logger.NewMock()
+	employee := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleEmployee}
+	stored := &entity.IdempotencyRecord{
+		StatusCode:  http.StatusCreated,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":"stored"}`),
+	}
+
+	tests := []struct {
+		name            string
+		key             string
+		handlerStatus   int
+		mockSetup       func(*MockIdempotencyUC)
+		expectedStatus  int
+		expectedBody    string
+		expectedReplay  bool
+		expectedHandled bool
+	}{
+		{
+			name:            "no key",
+			handlerStatus:   http.StatusCreated,
+			mockSetup:       func(uc *MockIdempotencyUC) {},
+			expectedStatus:  http.StatusCreated,
+			expectedBody:    `{"id":"new"}`,
+			expectedHandled: true,
+		},
+		{
+			name:          "first request stores response",
+			key:           "key-1",
+			handlerStatus: http.StatusCreated,
+			mockSetup: func(uc *MockIdempotencyUC) {
+				uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(nil, nil)
+				uc.On("Complete", mock.Anything, mock.Anything, "key-1", http.StatusCreated,
+					"application/json; charset=utf-8", []byte(`{"id":"new"}`)).Return(nil)
+			},
+			expectedStatus:  http.StatusCreated,
+			expectedBody:    `{"id":"new"}`,
+			expectedHandled: true,
+		},
+		{
+			name: "retry replays stored response",
+			key:  "key-1",
+			mockSetup: func(uc *MockIdempotencyUC) {
+				uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(stored, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"stored"}`,
+			expectedReplay: true,
+		},
+		{
+			name: "key reused with different body",
+			key:  "key-1",
+			mockSetup: func(uc *MockIdempotencyUC) {
+				uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(nil, entity.ErrIdempotencyKeyReused)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"` + entity.ErrIdempotencyKeyReused.Error() + `"}`,
+		},
+		{
+			name: "original request in progress",
+			key:  "key-1",
+			mockSetup: func(uc *MockIdempotencyUC) {
+				uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(nil, entity.ErrIdempotencyKeyInProgress)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"` + entity.ErrIdempotencyKeyInProgress.Error() + `"}`,
+		},
+		{
+			name:          "server error releases key",
+			key:           "key-1",
+			handlerStatus: http.StatusInternalServerError,
+			mockSetup: func(uc *MockIdempotencyUC) {
+				uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(nil, nil)
+				uc.On("Release", mock.Anything, mock.Anything, "key-1").Return(nil)
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    `{"id":"new"}`,
+			expectedHandled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockIdempotencyUC)
+			tt.mockSetup(uc)
+
+			handled := false
+			r := gin.New()
+			r.Use(withPrincipal(employee), middleware.Idempotency(uc, loggerMock))
+			r.POST("/products", func(c *gin.Context) {
+				handled = true
+				body, _ := io.ReadAll(c.Request.Body)
+				assert.Equal(t, `{"type":"обувь"}`, string(body))
+				c.JSON(tt.handlerStatus, gin.H{"id": "new"})
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"type":"обувь"}`))
+			if tt.key != "" {
+				req.Header.Set(middleware.IdempotencyKeyHeader, tt.key)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedReplay, w.Header().Get(middleware.IdempotentReplayedHeader) == "true")
+			assert.Equal(t, tt.expectedHandled, handled)
+			uc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotency_ScopedByPrincipal(t *testing.T) {
+	uc := new(MockIdempotencyUC)
+	scopes := make(map[string]struct{})
+	uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).
+		Run(func(args mock.Arguments) { scopes[args.String(1)] = struct{}{} }).
+		Return(nil, nil)
+	uc.On("Complete", mock.Anything, mock.Anything, "key-1", http.StatusCreated, mock.Anything, mock.Anything).Return(nil)
+
+	userID := uuid.New()
+	principals := []entity.Principal{
+		{UserID: userID, Role: entity.UserRoleEmployee},
+		{UserID: userID, Role: entity.UserRoleEmployee},
+		{UserID: userID, Role: entity.UserRoleModerator},
+		{UserID: uuid.New(), Role: entity.UserRoleEmployee},
+	}
+	for _, principal := range principals {
+		r := gin.New()
+		r.Use(withPrincipal(principal), middleware.Idempotency(uc, logger.NewMock()))
+		r.POST("/products", func(c *gin.Context) {
+			c.JSON(http.StatusCreated, gin.H{"id": "new"})
+		})
+
+		req, _ := http.NewRequest(http.MethodPost, "/products", strings.NewReader(`{}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		req.Header.Set(middleware.AuthorizationHeader, "Bearer "+uuid.NewString())
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Len(t, scopes, 3)
+}
+
+func TestIdempotency_StripsPickupCodes(t *testing.T) {
+	response := `{"accepted":1,"results":[{"index":0,"product":{"id":"p1","pickupCode":"123456"}}],"pickupCode":"654321"}`
+	uc := new(MockIdempotencyUC)
+	uc.On("Begin", mock.Anything, mock.Anything, "key-1", mock.Anything).Return(nil, nil)
+	uc.On("Complete", mock.Anything, mock.Anything, "key-1", http.StatusOK, mock.Anything,
+		mock.MatchedBy(func(body []byte) bool {
+			return assert.JSONEq(t, `{"accepted":1,"results":[{"index":0,"product":{"id":"p1"}}]}`, string(body))
+		})).Return(nil)
+
+	r := gin.New()
+	r.Use(withPrincipal(entity.Principal{Role: entity.UserRoleEmployee}), middleware.Idempotency(uc, logger.NewMock()))
+	r.POST("/products/batch", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(response))
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/products/batch", strings.NewReader(`{}`))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, response, w.Body.String(), "the caller still gets the codes")
+	uc.AssertExpectations(t)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	uc := new(MockIdempotencyUC)
+	r := gin.New()
+	r.Use(withPrincipal(entity.Principal{Role: entity.UserRoleEmployee}), middleware.Idempotency(uc, logger.NewMock()))
+	r.POST("/products", func(c *gin.Context) {
+		t.Fatal("handler must not be called")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/products", strings.NewReader(strings.Repeat("a", 1<<20+1)))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	uc.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+)
+
+// maxPVZAccessBodyBytes bounds the body PVZIDFromJSONBody buffers before the
+// handler binds it.
+const maxPVZAccessBodyBytes = 1 << 20
+
+type PVZIDExtractor func(c *gin.Context) (uuid.UUID, error)
+
+func PVZIDFromParam(name string) PVZIDExtractor {
+	return func(c *gin.Context) (uuid.UUID, error) {
+		return uuid.Parse(c.Param(name))
+	}
+}
+
+func PVZIDFromJSONBody(field string) PVZIDExtractor {
+	return func(c *gin.Context) (uuid.UUID, error) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPVZAccessBodyBytes))
+		if err != nil {
+			return uuid.Nil, err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return uuid.Nil, err
+		}
+
+		raw, ok := fields[field]
+		if !ok {
+			return uuid.Nil, fmt.Errorf("field %s is missing", field)
+		}
+
+		var id uuid.UUID
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return uuid.Nil, err
+		}
+		return id, nil
+	}
+}
+
+func RequirePVZAccess(checker usecase.PVZAssignment, extract PVZIDExtractor, logger logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "principal not found in context"})
+			return
+		}
+
+		pvzID, err := extract(c)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body is too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid pvz id"})
+			return
+		}
+
+		if err := checker.CheckAccess(c.Request.Context(), principal, pvzID); err != nil {
+			if errors.Is(err, entity.ErrPVZAccessDenied) {
+				logger.Warn("pvz access denied: user=%s pvz=%s", principal.UserID, pvzID)
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
+			}
+			logger.Error("pvz access check failed: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": entity.ErrInternal.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
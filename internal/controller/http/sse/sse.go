@@ -0,0 +1,81 @@
+package sse
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Heartbeat keeps idle streams alive through proxies that drop silent connections.
+const Heartbeat = 15 * time.Second
+
+var ErrInvalidLastEventID = errors.New("invalid Last-Event-ID")
+
+// LastEventID reads the resume position sent by a reconnecting EventSource;
+// the query parameter serves clients that cannot set headers.
+func LastEventID(c *gin.Context) (int64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("lastEventId")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidLastEventID
+	}
+	return id, nil
+}
+
+// Stream writes events as they arrive until the client disconnects or the
+// channel is closed. The event id is the outbox sequence so clients can resume.
+func Stream(c *gin.Context, events <-chan entity.Event, heartbeat time.Duration) {
+	// Streams outlive the server write timeout, which is meant for regular requests.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := WriteEvent(c.Writer, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+func WriteEvent(w io.Writer, event entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+	return err
+}
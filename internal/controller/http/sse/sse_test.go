@@ -0,0 +1,84 @@
+package sse_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/sse"
+	"PVZ-avito-tech/internal/entity"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastEventID(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		query         string
+		expected      int64
+		expectedError bool
+	}{
+		{name: "fresh connection"},
+		{name: "header", header: "42", expected: 42},
+		{name: "query fallback", query: "?lastEventId=7", expected: 7},
+		{name: "header wins", header: "42", query: "?lastEventId=7", expected: 42},
+		{name: "not a number", header: "abc", expectedError: true},
+		{name: "negative", header: "-1", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/events"+tt.query, nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Last-Event-ID", tt.header)
+			}
+
+			id, err := sse.LastEventID(c)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, sse.ErrInvalidLastEventID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/events", nil)
+
+	event := entity.Event{
+		ID:       uuid.New(),
+		Type:     entity.EventReceptionClosed,
+		Version:  1,
+		Data:     json.RawMessage(`{"to":"close"}`),
+		Sequence: 12,
+	}
+	events := make(chan entity.Event, 1)
+	events <- event
+	close(events)
+
+	sse.Stream(c, events, time.Minute)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	frame := w.Body.String()
+	assert.True(t, strings.HasPrefix(frame, "id: 12\nevent: reception.closed\ndata: {"), frame)
+	assert.True(t, strings.HasSuffix(frame, "\n\n"))
+
+	var decoded entity.Event
+	data := strings.TrimSuffix(strings.SplitN(frame, "data: ", 2)[1], "\n\n")
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	assert.Equal(t, event.ID, decoded.ID)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) List(c *gin.Context) {
+	var filter dto.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	entries, err := h.auditUC.List(c.Request.Context(), filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidAuditFilter),
+			errors.Is(err, entity.ErrInvalidAuditPeriod):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger  logger.Interface
+	auditUC usecase.Audit
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	auditUC usecase.Audit,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:  logger,
+		auditUC: auditUC,
+	}
+
+	authGroup := apiV1Group.Group("/audit").
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.RequireRole(entity.UserRoleModerator))
+	{
+		authGroup.GET("", au.List)
+	}
+
+	return au
+}
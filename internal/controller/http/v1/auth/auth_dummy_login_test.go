@@ -33,7 +33,9 @@ func TestDummyLogin(t *testing.T) {
 		gin.New().Group("/"),
 		mockDummyUC,
 		nil,
+		nil,
 		loggerMock,
+		nil,
 	)
 
 	tests := []struct {
@@ -43,7 +43,11 @@ func (h *Routes) Login(c *gin.Context) {
 		return
 	}
 
-	token, errToken := h.dummyUC.GenerateDummyToken(loginResp.Role)
+	pair, errToken := h.tokenUC.Issue(c.Request.Context(), entity.Principal{
+		UserID: loginResp.Id,
+		Email:  loginResp.Email,
+		Role:   loginResp.Role,
+	})
 
 	if errToken != nil {
 		h.logger.Error("token generation failed", map[string]interface{}{
@@ -56,6 +60,7 @@ func (h *Routes) Login(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, tokenResponse{
-		Token: token,
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
 	})
 }
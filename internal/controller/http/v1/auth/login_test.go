@@ -4,12 +4,15 @@ import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	"PVZ-avito-tech/internal/controller/http/v1/auth"
 	"PVZ-avito-tech/internal/entity"
+	authPkg "PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/pkg/logger"
 	authUC "PVZ-avito-tech/internal/usecase/auth"
+	"PVZ-avito-tech/internal/usecase/token"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"github.com/google/uuid"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -33,7 +36,28 @@ func (m *MockAuthUC) Login(ctx context.Context, email string, rawPassword string
 	return args.Get(0).(authUC.LoginResponse), args.Error(1)
 }
 
+type MockTokenUC struct {
+	mock.Mock
+}
+
+func (m *MockTokenUC) Issue(ctx context.Context, principal entity.Principal) (token.Pair, error) {
+	args := m.Called(ctx, principal)
+	return args.Get(0).(token.Pair), args.Error(1)
+}
+
+func (m *MockTokenUC) Refresh(ctx context.Context, refreshToken string) (token.Pair, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.Get(0).(token.Pair), args.Error(1)
+}
+
+func (m *MockTokenUC) Logout(ctx context.Context, refreshToken string, claims *authPkg.Claims) error {
+	args := m.Called(ctx, refreshToken, claims)
+	return args.Error(0)
+}
+
 func TestLogin(t *testing.T) {
+	userID := uuid.New()
+
 	gin.SetMode(gin.TestMode)
 	loggerMock := logger.NewMock()
 
@@ -41,7 +65,7 @@ func TestLogin(t *testing.T) {
 		name           string
 		request        interface{}
 		mockAuthSetup  func(*MockAuthUC)
-		mockDummySetup func(*MockDummyUC)
+		mockTokenSetup func(*MockTokenUC)
 		expectedStatus int
 		expectedBody   string
 	}{
@@ -53,21 +77,25 @@ func TestLogin(t *testing.T) {
 			},
 			mockAuthSetup: func(mockAuth *MockAuthUC) {
 				mockAuth.On("Login", mock.Anything, "test@example.com", "password123").
-					Return(authUC.LoginResponse{Role: entity.UserRoleModerator}, nil)
+					Return(authUC.LoginResponse{Id: userID, Email: "test@example.com", Role: entity.UserRoleModerator}, nil)
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
-				mockDummy.On("GenerateDummyToken", entity.UserRoleModerator).
-					Return("test-token", nil)
+			mockTokenSetup: func(mockToken *MockTokenUC) {
+				mockToken.On("Issue", mock.Anything, entity.Principal{
+					UserID: userID,
+					Email:  "test@example.com",
+					Role:   entity.UserRoleModerator,
+				}).
+					Return(token.Pair{AccessToken: "test-token", RefreshToken: "refresh-token"}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"token":"test-token"}`,
+			expectedBody:   `{"token":"test-token","refreshToken":"refresh-token"}`,
 		},
 		{
 			name:    "invalid request body",
 			request: "invalid",
 			mockAuthSetup: func(mockAuth *MockAuthUC) {
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
+			mockTokenSetup: func(mockToken *MockTokenUC) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "invalid request body",
@@ -82,7 +110,7 @@ func TestLogin(t *testing.T) {
 				mockAuth.On("Login", mock.Anything, "nonexistent@example.com", "password123").
 					Return(authUC.LoginResponse{}, entity.ErrUserNotFound)
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
+			mockTokenSetup: func(mockToken *MockTokenUC) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   entity.ErrUserNotFound.Error(),
@@ -97,7 +125,7 @@ func TestLogin(t *testing.T) {
 				mockAuth.On("Login", mock.Anything, "test@example.com", "wrongpassword").
 					Return(authUC.LoginResponse{}, entity.ErrInvalidPassword)
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
+			mockTokenSetup: func(mockToken *MockTokenUC) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   entity.ErrInvalidPassword.Error(),
@@ -112,7 +140,7 @@ func TestLogin(t *testing.T) {
 				mockAuth.On("Login", mock.Anything, "test@example.com", "password123").
 					Return(authUC.LoginResponse{}, entity.ErrInternal)
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
+			mockTokenSetup: func(mockToken *MockTokenUC) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   entity.ErrInternal.Error(),
@@ -125,11 +153,15 @@ func TestLogin(t *testing.T) {
 			},
 			mockAuthSetup: func(mockAuth *MockAuthUC) {
 				mockAuth.On("Login", mock.Anything, "test@example.com", "password123").
-					Return(authUC.LoginResponse{Role: entity.UserRoleModerator}, nil)
+					Return(authUC.LoginResponse{Id: userID, Email: "test@example.com", Role: entity.UserRoleModerator}, nil)
 			},
-			mockDummySetup: func(mockDummy *MockDummyUC) {
-				mockDummy.On("GenerateDummyToken", entity.UserRoleModerator).
-					Return("", errors.New("token generation failed"))
+			mockTokenSetup: func(mockToken *MockTokenUC) {
+				mockToken.On("Issue", mock.Anything, entity.Principal{
+					UserID: userID,
+					Email:  "test@example.com",
+					Role:   entity.UserRoleModerator,
+				}).
+					Return(token.Pair{}, errors.New("token generation failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   entity.ErrInternal.Error(),
@@ -139,21 +171,23 @@ func TestLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAuth := new(MockAuthUC)
-			mockDummy := new(MockDummyUC)
+			mockToken := new(MockTokenUC)
 
 			if tt.mockAuthSetup != nil {
 				tt.mockAuthSetup(mockAuth)
 			}
-			if tt.mockDummySetup != nil {
-				tt.mockDummySetup(mockDummy)
+			if tt.mockTokenSetup != nil {
+				tt.mockTokenSetup(mockToken)
 			}
 
 			router := gin.New()
 			handler := auth.NewAuthRoutes(
 				router.Group("/"),
-				mockDummy,
+				nil,
 				mockAuth,
+				mockToken,
 				loggerMock,
+				nil,
 			)
 
 			w := httptest.NewRecorder()
@@ -169,7 +203,7 @@ func TestLogin(t *testing.T) {
 			assert.Contains(t, w.Body.String(), tt.expectedBody)
 
 			mockAuth.AssertExpectations(t)
-			mockDummy.AssertExpectations(t)
+			mockToken.AssertExpectations(t)
 		})
 	}
 }
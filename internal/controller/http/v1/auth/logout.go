@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	authPkg "PVZ-avito-tech/internal/pkg/auth"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+func (h *Routes) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	claims, _ := c.Get(middleware.TokenClaimsContextKey)
+	tokenClaims, _ := claims.(*authPkg.Claims)
+
+	if err := h.tokenUC.Logout(c.Request.Context(), req.RefreshToken, tokenClaims); err != nil {
+		h.logger.Error(err, "http - v1 - auth - handler - Logout")
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	pair, err := h.tokenUC.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidRefreshToken):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusUnauthorized, err.Error())
+		default:
+			h.logger.Error(err, "http - v1 - auth - handler - RefreshToken")
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+	})
+}
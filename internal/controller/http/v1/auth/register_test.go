@@ -161,7 +161,9 @@ func TestRegister(t *testing.T) {
 				router.Group("/"),
 				mockDummy,
 				mockAuth,
+				nil,
 				loggerMock,
+				nil,
 			)
 
 			w := httptest.NewRecorder()
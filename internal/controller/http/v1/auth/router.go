@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	authPkg "PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/pkg/logger"
 	"PVZ-avito-tech/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -9,22 +11,27 @@ import (
 type Routes struct {
 	dummyUC usecase.DummyLogin
 	userUC  usecase.Auth
+	tokenUC usecase.Token
 	logger  logger.Interface
 }
 
 type tokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
 func NewAuthRoutes(
 	apiV1Group *gin.RouterGroup,
 	dummyUC usecase.DummyLogin,
 	userUC usecase.Auth,
+	tokenUC usecase.Token,
 	logger logger.Interface,
+	jwtService authPkg.TokenService,
 ) *Routes {
 	au := &Routes{
 		dummyUC: dummyUC,
 		userUC:  userUC,
+		tokenUC: tokenUC,
 		logger:  logger,
 	}
 
@@ -33,6 +40,8 @@ func NewAuthRoutes(
 		authGroup.POST("/dummyLogin", au.DummyLogin)
 		authGroup.POST("/register", au.Register)
 		authGroup.POST("/login", au.Login)
+		authGroup.POST("/token/refresh", au.RefreshToken)
+		authGroup.POST("/logout", middleware.AuthMiddleware(jwtService, logger), au.Logout)
 	}
 
 	return au
@@ -0,0 +1,81 @@
+package catalogue
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) CreateCategory(c *gin.Context) {
+	typeId, err := uuid.Parse(c.Param("typeId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.ProductCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	category, err := h.catalogueUC.CreateCategory(c.Request.Context(), typeId, req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidProductCategory):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrProductTypeNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrProductCategoryAlreadyExists):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+func (h *Routes) SetCategoryActive(c *gin.Context) {
+	typeId, err := uuid.Parse(c.Param("typeId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	categoryId, err := uuid.Parse(c.Param("categoryId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.CatalogueActivityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	category, err := h.catalogueUC.SetCategoryActive(c.Request.Context(), typeId, categoryId, *req.Active)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrProductCategoryNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
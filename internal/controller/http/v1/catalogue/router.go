@@ -0,0 +1,40 @@
+package catalogue
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger      logger.Interface
+	catalogueUC usecase.Catalogue
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	catalogueUC usecase.Catalogue,
+	idempotencyUC usecase.Idempotency,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:      logger,
+		catalogueUC: catalogueUC,
+	}
+
+	authGroup := apiV1Group.Group("/product-types").
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger))
+	{
+		authGroup.GET("", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.ListTypes)
+		authGroup.POST("", middleware.RequireRole(entity.UserRoleModerator), au.CreateType)
+		authGroup.PATCH("/:typeId", middleware.RequireRole(entity.UserRoleModerator), au.SetTypeActive)
+		authGroup.POST("/:typeId/categories", middleware.RequireRole(entity.UserRoleModerator), au.CreateCategory)
+		authGroup.PATCH("/:typeId/categories/:categoryId", middleware.RequireRole(entity.UserRoleModerator), au.SetCategoryActive)
+	}
+
+	return au
+}
@@ -0,0 +1,88 @@
+package catalogue
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+func (h *Routes) ListTypes(c *gin.Context) {
+	includeInactive := false
+	if raw := c.Query("includeInactive"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+			return
+		}
+		includeInactive = value
+	}
+
+	types, err := h.catalogueUC.ListTypes(c.Request.Context(), includeInactive)
+	if err != nil {
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, types)
+}
+
+func (h *Routes) CreateType(c *gin.Context) {
+	var req dto.ProductTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	productType, err := h.catalogueUC.CreateType(c.Request.Context(), req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidProductType):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrProductTypeAlreadyExists):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, productType)
+}
+
+func (h *Routes) SetTypeActive(c *gin.Context) {
+	typeId, err := uuid.Parse(c.Param("typeId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.CatalogueActivityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	productType, err := h.catalogueUC.SetTypeActive(c.Request.Context(), typeId, *req.Active)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrProductTypeNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, productType)
+}
@@ -0,0 +1,37 @@
+package city
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) Delete(c *gin.Context) {
+	cityId, err := uuid.Parse(c.Param("cityId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	err = h.cityUC.Delete(c.Request.Context(), cityId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCityNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrCityInUse):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
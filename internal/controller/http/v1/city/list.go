@@ -0,0 +1,45 @@
+package city
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) List(c *gin.Context) {
+	cities, err := h.cityUC.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, cities)
+}
+
+func (h *Routes) Get(c *gin.Context) {
+	cityId, err := uuid.Parse(c.Param("cityId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	city, err := h.cityUC.Get(c.Request.Context(), cityId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCityNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, city)
+}
@@ -0,0 +1,44 @@
+package city
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger   logger.Interface
+	cityUC   usecase.City
+	eventsUC usecase.Events
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	cityUC usecase.City,
+	eventsUC usecase.Events,
+	idempotencyUC usecase.Idempotency,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:   logger,
+		cityUC:   cityUC,
+		eventsUC: eventsUC,
+	}
+
+	authGroup := apiV1Group.Group("/cities").
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger))
+	{
+		authGroup.GET("", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.List)
+		authGroup.GET("/:cityId", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.Get)
+		authGroup.GET("/:cityId/events", middleware.RequireRole(entity.UserRoleModerator), au.StreamEvents)
+		authGroup.POST("", middleware.RequireRole(entity.UserRoleModerator), au.Create)
+		authGroup.PUT("/:cityId", middleware.RequireRole(entity.UserRoleModerator), au.Rename)
+		authGroup.DELETE("/:cityId", middleware.RequireRole(entity.UserRoleModerator), au.Delete)
+	}
+
+	return au
+}
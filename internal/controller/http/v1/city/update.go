@@ -0,0 +1,46 @@
+package city
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) Rename(c *gin.Context) {
+	cityId, err := uuid.Parse(c.Param("cityId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.CityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	city, err := h.cityUC.Rename(c.Request.Context(), cityId, req.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCity):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrCityNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrCityAlreadyExists):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, city)
+}
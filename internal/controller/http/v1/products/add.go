@@ -4,6 +4,7 @@ import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	er "PVZ-avito-tech/internal/controller/http/errors"
 	"PVZ-avito-tech/internal/controller/http/mapper"
+	"PVZ-avito-tech/internal/controller/http/middleware"
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/metrics"
 	"errors"
@@ -13,18 +14,28 @@ import (
 
 func (h *Routes) AddProduct(c *gin.Context) {
 	var req dto.PostAddProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil || !req.ProductType.IsValidProductType() {
+	if err := c.ShouldBindJSON(&req); err != nil {
 		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
 		return
 	}
 
-	respEntity, err := h.productUC.AddProduct(c.Request.Context(), &req)
+	principal, _ := middleware.CurrentPrincipal(c)
+	respEntity, err := h.productUC.AddProduct(c.Request.Context(), &req, principal)
 
 	if err != nil {
 		h.logger.Warn(err.Error())
 		switch {
-		case errors.Is(err, entity.ErrNoActiveReception):
+		case errors.Is(err, entity.ErrNoActiveReception),
+			errors.Is(err, entity.ErrInvalidProductType),
+			errors.Is(err, entity.ErrProductTypeInactive),
+			errors.Is(err, entity.ErrInvalidProductCategory),
+			errors.Is(err, entity.ErrProductCategoryNotFound),
+			errors.Is(err, entity.ErrProductCategoryInactive),
+			errors.Is(err, entity.ErrInvalidBarcode),
+			errors.Is(err, entity.ErrInvalidPickupCode):
 			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrBarcodeConflict):
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
 		default:
 			dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
 		}
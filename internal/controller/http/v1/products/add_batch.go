@@ -0,0 +1,45 @@
+package products
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/mapper"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) AddProducts(c *gin.Context) {
+	var req dto.PostAddProductsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	results, err := h.productUC.AddProducts(c.Request.Context(), &req, principal)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNoActiveReception):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrBarcodeConflict):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+
+		return
+	}
+
+	resp := mapper.EntityProductBatchToResponse(results)
+
+	metrics.ProductsAdded.Add(float64(resp.Accepted))
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,105 @@
+package products
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) GetProduct(c *gin.Context) {
+	product, ok := h.loadProduct(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+func (h *Routes) StatusHistory(c *gin.Context) {
+	product, ok := h.loadProduct(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.productUC.StatusHistory(c.Request.Context(), product.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *Routes) FindByBarcode(c *gin.Context) {
+	var req dto.ProductLookupRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	location, err := h.productUC.FindByBarcode(c.Request.Context(), req.Barcode)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	if err := h.assignmentUC.CheckAccess(c.Request.Context(), principal, *location.PVZ.ID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+func (h *Routes) loadProduct(c *gin.Context) (*entity.Product, bool) {
+	productId, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return nil, false
+	}
+
+	product, err := h.productUC.GetProduct(c.Request.Context(), productId)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	if err := h.assignmentUC.CheckAccess(c.Request.Context(), principal, product.PVZID); err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	return product, true
+}
+
+func (h *Routes) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrPVZAccessDenied):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrInvalidPickupCode),
+		errors.Is(err, entity.ErrInvalidBarcode):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrInvalidProductStatusTransition),
+		errors.Is(err, entity.ErrPickupCodeNotSet):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrPickupCodeAttemptsExceeded):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusTooManyRequests, err.Error())
+	default:
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+	}
+}
@@ -0,0 +1,34 @@
+package products
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/mapper"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) IssueProduct(c *gin.Context) {
+	product, ok := h.loadProduct(c)
+	if !ok {
+		return
+	}
+
+	var req dto.IssueProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	issued, err := h.productUC.IssueProduct(c.Request.Context(), product.ID, req.PickupCode, principal)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	metrics.ProductsIssued.Inc()
+	c.JSON(http.StatusOK, mapper.EntityProductToProductResponse(issued))
+}
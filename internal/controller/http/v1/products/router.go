@@ -10,25 +10,42 @@ import (
 )
 
 type Routes struct {
-	logger    logger.Interface
-	productUC usecase.ProductUseCase
+	logger       logger.Interface
+	productUC    usecase.ProductUseCase
+	assignmentUC usecase.PVZAssignment
 }
 
 func NewAuthRoutes(
 	apiV1Group *gin.RouterGroup,
 	productUC usecase.ProductUseCase,
+	assignmentUC usecase.PVZAssignment,
 	logger logger.Interface,
+	idempotencyUC usecase.Idempotency,
 	jwtService auth.TokenService,
 ) *Routes {
 	au := &Routes{
-		logger:    logger,
-		productUC: productUC,
+		logger:       logger,
+		productUC:    productUC,
+		assignmentUC: assignmentUC,
 	}
 
 	authGroup := apiV1Group.Group("/products").
-		Use(middleware.AuthMiddleware(jwtService, logger))
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger))
 	{
-		authGroup.POST("", middleware.RequireRole(entity.UserRoleEmployee), au.AddProduct)
+		authGroup.POST("",
+			middleware.RequireRole(entity.UserRoleEmployee),
+			middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromJSONBody("pvzId"), logger),
+			au.AddProduct,
+		)
+		authGroup.POST("/batch",
+			middleware.RequireRole(entity.UserRoleEmployee),
+			middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromJSONBody("pvzId"), logger),
+			au.AddProducts,
+		)
+		authGroup.GET("", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.FindByBarcode)
+		authGroup.GET("/:productId", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.GetProduct)
+		authGroup.GET("/:productId/history", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.StatusHistory)
+		authGroup.POST("/:productId/issue", middleware.RequireRole(entity.UserRoleEmployee, entity.UserRoleModerator), au.IssueProduct)
 	}
 
 	return au
@@ -0,0 +1,88 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) AssignEmployee(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.AssignEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	assignment, err := h.assignmentUC.Assign(c.Request.Context(), pvzId, req.UserID, principal)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound), errors.Is(err, entity.ErrEmployeeNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+func (h *Routes) UnassignEmployee(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	userId, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	err = h.assignmentUC.Unassign(c.Request.Context(), pvzId, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssignmentNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Routes) ListEmployees(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	assignments, err := h.assignmentUC.ListEmployees(c.Request.Context(), pvzId)
+	if err != nil {
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
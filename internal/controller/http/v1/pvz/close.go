@@ -3,6 +3,7 @@ package pvz
 import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
 	"PVZ-avito-tech/internal/entity"
 	"errors"
 	"github.com/gin-gonic/gin"
@@ -18,7 +19,8 @@ func (h *Routes) CloseReception(c *gin.Context) {
 		return
 	}
 
-	response, err := h.receptionUC.CloseReception(c.Request.Context(), pvzId)
+	principal, _ := middleware.CurrentPrincipal(c)
+	response, err := h.receptionUC.CloseReception(c.Request.Context(), pvzId, principal)
 
 	if err != nil {
 		switch {
@@ -6,6 +6,7 @@ import (
 	"PVZ-avito-tech/internal/controller/http/mapper"
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/metrics"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -20,17 +21,16 @@ func (h *Routes) CreatePVZ(c *gin.Context) {
 
 	pvzEntity := mapper.DtoPVZToEntityPVZ(req)
 
-	if !pvzEntity.City.IsValidCity() {
-		h.logger.Warn(er.ErrInvalidRequestBody)
-		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
-		return
-	}
-
 	pvzResp, err := h.pvzUC.CreatePVZ(c.Request.Context(), pvzEntity)
 
 	if err != nil {
 		h.logger.Warn(err.Error())
-		dto.ErrorResponse(c, http.StatusBadRequest, entity.ErrCreatePVZ.Error())
+		switch {
+		case errors.Is(err, entity.ErrInvalidCity):
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			dto.ErrorResponse(c, http.StatusBadRequest, entity.ErrCreatePVZ.Error())
+		}
 		return
 	}
 
@@ -3,6 +3,7 @@ package pvz
 import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
 	"PVZ-avito-tech/internal/entity"
 	"errors"
 	"github.com/gin-gonic/gin"
@@ -28,6 +29,9 @@ func (h *Routes) DeleteLastProduct(c *gin.Context) {
 		case errors.Is(err, entity.ErrNoProducts):
 			h.logger.Warn(err.Error())
 			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrInvalidProductStatusTransition):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
 		default:
 			h.logger.Error(err.Error())
 			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
@@ -37,3 +41,46 @@ func (h *Routes) DeleteLastProduct(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (h *Routes) DeleteProduct(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+	productId, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var req dto.DeleteProductRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	deletion, err := h.productUC.DeleteProduct(c.Request.Context(), pvzId, productId, req.Reason, principal)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidDeletionReason):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrProductNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrReceptionNotInProgress),
+			errors.Is(err, entity.ErrInvalidProductStatusTransition):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, deletion)
+}
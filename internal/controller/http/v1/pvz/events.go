@@ -0,0 +1,41 @@
+package pvz
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/sse"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) StreamEvents(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	lastEventID, err := sse.LastEventID(c)
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := h.eventsUC.SubscribePVZ(c.Request.Context(), pvzId, lastEventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	sse.Stream(c, events, sse.Heartbeat)
+}
@@ -16,6 +16,17 @@ func (h *Routes) GetPVZList(c *gin.Context) {
 		return
 	}
 
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.getPVZPage(c, filter)
+		return
+	}
+
+	if filter.Limit > dto.MaxPageLimit {
+		h.logger.Warn(er.ErrInvalidRequestBody)
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
 	filter.Apply(
 		dto.WithPaginationDefaults(),
 	)
@@ -28,3 +39,24 @@ func (h *Routes) GetPVZList(c *gin.Context) {
 
 	c.JSON(http.StatusOK, *pvzList)
 }
+
+func (h *Routes) getPVZPage(c *gin.Context, filter dto.ReceptionFilter) {
+	if err := filter.DecodeCursor(); err != nil {
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Apply(
+		dto.WithCursorMode(),
+		dto.WithPaginationDefaults(),
+	)
+	page, err := h.pvzUC.GetPVZPage(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error(entity.ErrGetPVZList, err)
+		dto.ErrorResponse(c, http.StatusBadRequest, entity.ErrGetPVZList.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -10,10 +10,12 @@ import (
 )
 
 type Routes struct {
-	logger      logger.Interface
-	pvzUC       usecase.PVZUseCase
-	receptionUC usecase.ReceptionUseCase
-	productUC   usecase.ProductUseCase
+	logger       logger.Interface
+	pvzUC        usecase.PVZUseCase
+	receptionUC  usecase.ReceptionUseCase
+	productUC    usecase.ProductUseCase
+	assignmentUC usecase.PVZAssignment
+	eventsUC     usecase.Events
 }
 
 func NewAuthRoutes(
@@ -22,22 +24,35 @@ func NewAuthRoutes(
 	pvzUC usecase.PVZUseCase,
 	receptionUC usecase.ReceptionUseCase,
 	productUC usecase.ProductUseCase,
+	assignmentUC usecase.PVZAssignment,
+	eventsUC usecase.Events,
+	idempotencyUC usecase.Idempotency,
 	jwtService auth.TokenService,
 ) *Routes {
 	au := &Routes{
-		logger:      logger,
-		pvzUC:       pvzUC,
-		receptionUC: receptionUC,
-		productUC:   productUC,
+		logger:       logger,
+		pvzUC:        pvzUC,
+		receptionUC:  receptionUC,
+		productUC:    productUC,
+		assignmentUC: assignmentUC,
+		eventsUC:     eventsUC,
 	}
 
+	pvzAccess := middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromParam("pvzId"), logger)
+
 	authGroup := apiV1Group.Group("/pvz").
-		Use(middleware.AuthMiddleware(jwtService, logger))
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger))
 	{
 		authGroup.POST("", middleware.RequireRole(entity.UserRoleModerator), au.CreatePVZ)
 		authGroup.GET("", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.GetPVZList)
-		authGroup.POST("/:pvzId/close_last_reception", middleware.RequireRole(entity.UserRoleEmployee), au.CloseReception)
-		authGroup.POST("/:pvzId/delete_last_product", middleware.RequireRole(entity.UserRoleEmployee), au.DeleteLastProduct)
+		authGroup.GET("/:pvzId", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), pvzAccess, au.GetPVZ)
+		authGroup.GET("/:pvzId/events", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), pvzAccess, au.StreamEvents)
+		authGroup.POST("/:pvzId/close_last_reception", middleware.RequireRole(entity.UserRoleEmployee), pvzAccess, au.CloseReception)
+		authGroup.POST("/:pvzId/delete_last_product", middleware.RequireRole(entity.UserRoleEmployee), pvzAccess, au.DeleteLastProduct)
+		authGroup.DELETE("/:pvzId/products/:productId", middleware.RequireRole(entity.UserRoleEmployee), pvzAccess, au.DeleteProduct)
+		authGroup.GET("/:pvzId/employees", middleware.RequireRole(entity.UserRoleModerator), au.ListEmployees)
+		authGroup.POST("/:pvzId/employees", middleware.RequireRole(entity.UserRoleModerator), au.AssignEmployee)
+		authGroup.DELETE("/:pvzId/employees/:userId", middleware.RequireRole(entity.UserRoleModerator), au.UnassignEmployee)
 	}
 
 	return au
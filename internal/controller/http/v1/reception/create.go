@@ -3,6 +3,7 @@ package reception
 import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/metrics"
 	"errors"
@@ -14,15 +15,20 @@ func (h *Routes) CreateReception(c *gin.Context) {
 	var req dto.ReceptionsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
 	}
 
-	response, err := h.receptionUC.CreateReception(c.Request.Context(), req)
+	principal, _ := middleware.CurrentPrincipal(c)
+	response, err := h.receptionUC.CreateReception(c.Request.Context(), req, principal)
 
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrReceptionConflict):
 			h.logger.Warn(err.Error())
 			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrInvalidManifest):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
 		case errors.Is(err, entity.ErrPVZNotFound):
 			h.logger.Warn(err.Error())
 			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
@@ -0,0 +1,97 @@
+package reception
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) GetReception(c *gin.Context) {
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, reception)
+}
+
+func (h *Routes) ListProducts(c *gin.Context) {
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	products, err := h.receptionUC.ListProducts(c.Request.Context(), reception.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+func (h *Routes) loadReception(c *gin.Context) (*entity.Reception, bool) {
+	receptionId, err := uuid.Parse(c.Param("receptionId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return nil, false
+	}
+
+	reception, err := h.receptionUC.GetReception(c.Request.Context(), receptionId)
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	if err := h.assignmentUC.CheckAccess(c.Request.Context(), principal, reception.PVZID); err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+
+	return reception, true
+}
+
+func (h *Routes) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrReceptionNotFound),
+		errors.Is(err, entity.ErrDiscrepancyReportNotFound):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrInvalidCancellationReason):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrInvalidReceptionStatusTransition),
+		errors.Is(err, entity.ErrReceptionReopenWindowExpired),
+		errors.Is(err, entity.ErrReceptionProductsLeftStorage),
+		errors.Is(err, entity.ErrReceptionConflict):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrPVZAccessDenied):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusForbidden, err.Error())
+	default:
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+	}
+}
+
+func (h *Routes) DiscrepancyReport(c *gin.Context) {
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.receptionUC.DiscrepancyReport(c.Request.Context(), reception.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
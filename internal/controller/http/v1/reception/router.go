@@ -10,25 +10,39 @@ import (
 )
 
 type Routes struct {
-	logger      logger.Interface
-	receptionUC usecase.ReceptionUseCase
+	logger       logger.Interface
+	receptionUC  usecase.ReceptionUseCase
+	assignmentUC usecase.PVZAssignment
 }
 
 func NewAuthRoutes(
 	apiV1Group *gin.RouterGroup,
 	logger logger.Interface,
 	reception usecase.ReceptionUseCase,
+	assignmentUC usecase.PVZAssignment,
+	idempotencyUC usecase.Idempotency,
 	jwtService auth.TokenService,
 ) *Routes {
 	au := &Routes{
-		logger:      logger,
-		receptionUC: reception,
+		logger:       logger,
+		receptionUC:  reception,
+		assignmentUC: assignmentUC,
 	}
 
 	authGroup := apiV1Group.Group("/receptions").
-		Use(middleware.AuthMiddleware(jwtService, logger))
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger))
 	{
-		authGroup.POST("", middleware.RequireRole(entity.UserRoleEmployee), au.CreateReception)
+		authGroup.POST("",
+			middleware.RequireRole(entity.UserRoleEmployee),
+			middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromJSONBody("pvzId"), logger),
+			au.CreateReception,
+		)
+		authGroup.GET("/:receptionId", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.GetReception)
+		authGroup.GET("/:receptionId/products", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.ListProducts)
+		authGroup.GET("/:receptionId/discrepancies", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.DiscrepancyReport)
+		authGroup.GET("/:receptionId/history", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.StatusHistory)
+		authGroup.POST("/:receptionId/reopen", middleware.RequireRole(entity.UserRoleModerator), au.ReopenReception)
+		authGroup.POST("/:receptionId/cancel", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), au.CancelReception)
 	}
 
 	return au
@@ -0,0 +1,62 @@
+package reception
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) ReopenReception(c *gin.Context) {
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	reopened, err := h.receptionUC.ReopenReception(c.Request.Context(), reception.ID, principal)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reopened)
+}
+
+func (h *Routes) CancelReception(c *gin.Context) {
+	var req dto.CancelReceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	cancelled, err := h.receptionUC.CancelReception(c.Request.Context(), reception.ID, req.Reason, principal)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cancelled)
+}
+
+func (h *Routes) StatusHistory(c *gin.Context) {
+	reception, ok := h.loadReception(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.receptionUC.StatusHistory(c.Request.Context(), reception.ID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/xlsx"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const mimeCSV = "text/csv"
+
+var acceptanceReportHeader = []any{"pvzId", "city", "day", "productType", "accepted"}
+
+// Acceptance serves the daily acceptance report as JSON, CSV or XLSX. The
+// format query parameter takes precedence over the Accept header.
+func (h *Routes) Acceptance(c *gin.Context) {
+	var filter dto.AcceptanceReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	format := filter.Format
+	if format == "" {
+		switch c.NegotiateFormat(binding.MIMEJSON, mimeCSV, xlsx.ContentType) {
+		case binding.MIMEJSON:
+			format = dto.ReportFormatJSON
+		case mimeCSV:
+			format = dto.ReportFormatCSV
+		case xlsx.ContentType:
+			format = dto.ReportFormatXLSX
+		default:
+			dto.ErrorResponse(c, http.StatusNotAcceptable, er.ErrNotAcceptable)
+			return
+		}
+	}
+
+	rows, err := h.reportsUC.Acceptance(c.Request.Context(), filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidAcceptanceReportPeriod),
+			errors.Is(err, entity.ErrInvalidAcceptanceReportFilter):
+			h.logger.Warn(err.Error())
+			dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error(err.Error())
+			dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		}
+		return
+	}
+
+	if format == dto.ReportFormatJSON {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	table := make([][]any, 0, len(rows)+1)
+	table = append(table, acceptanceReportHeader)
+	for _, row := range rows {
+		table = append(table, []any{row.PVZID.String(), string(row.City), row.Day, string(row.ProductType), row.Accepted})
+	}
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+	)
+	switch format {
+	case dto.ReportFormatCSV:
+		contentType = mimeCSV + "; charset=utf-8"
+		err = writeCSV(&buf, table)
+	case dto.ReportFormatXLSX:
+		contentType = xlsx.ContentType
+		err = xlsx.Write(&buf, "acceptance", table)
+	}
+	if err != nil {
+		h.logger.Error(fmt.Errorf("failed to render acceptance report: %w", err).Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("acceptance_%s_%s.%s",
+		filter.StartDate.Format(entity.ReportDayLayout),
+		filter.EndDate.Format(entity.ReportDayLayout),
+		format,
+	)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+func writeCSV(w io.Writer, table [][]any) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, 0, len(acceptanceReportHeader))
+	for _, row := range table {
+		record = record[:0]
+		for _, value := range row {
+			record = append(record, escapeCSVFormula(fmt.Sprint(value)))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeCSVFormula keeps spreadsheets from evaluating a cell as a formula.
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
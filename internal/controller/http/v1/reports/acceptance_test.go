@@ -0,0 +1,164 @@
+package reports_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/controller/http/v1/reports"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/xlsx"
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReportsUC struct {
+	mock.Mock
+}
+
+func (m *MockReportsUC) Acceptance(ctx context.Context, filter dto.AcceptanceReportFilter) ([]entity.AcceptanceReportRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AcceptanceReportRow), args.Error(1)
+}
+
+func TestAcceptance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pvzID := uuid.MustParse("6db90747-eaff-4552-b727-bdb8a7f63921")
+	rows := []entity.AcceptanceReportRow{{
+		PVZID:       pvzID,
+		City:        entity.CityMoscow,
+		Day:         "2025-03-02",
+		ProductType: entity.ElectronicsProductType,
+		Accepted:    5,
+	}}
+
+	tests := []struct {
+		name                string
+		query               string
+		accept              string
+		mockSetup           func(*MockReportsUC)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "json by default",
+			query: "startDate=2025-03-01&endDate=2025-03-31",
+			mockSetup: func(uc *MockReportsUC) {
+				uc.On("Acceptance", mock.Anything, mock.Anything).Return(rows, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `[{"pvzId":"` + pvzID.String() + `","city":"Москва","day":"2025-03-02","productType":"электроника","accepted":5}]`,
+		},
+		{
+			name:   "csv by accept header",
+			query:  "startDate=2025-03-01&endDate=2025-03-31",
+			accept: "text/csv",
+			mockSetup: func(uc *MockReportsUC) {
+				uc.On("Acceptance", mock.Anything, mock.Anything).Return(rows, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "pvzId,city,day,productType,accepted\n" + pvzID.String() + ",Москва,2025-03-02,электроника,5\n",
+		},
+		{
+			name:   "csv escapes formula cells",
+			query:  "startDate=2025-03-01&endDate=2025-03-31",
+			accept: "text/csv",
+			mockSetup: func(uc *MockReportsUC) {
+				uc.On("Acceptance", mock.Anything, mock.Anything).Return([]entity.AcceptanceReportRow{{
+					PVZID:       pvzID,
+					City:        "=HYPERLINK(\"http://evil\")",
+					Day:         "2025-03-02",
+					ProductType: "@SUM(A1)",
+					Accepted:    5,
+				}}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "pvzId,city,day,productType,accepted\n" + pvzID.String() + ",\"'=HYPERLINK(\"\"http://evil\"\")\",2025-03-02,'@SUM(A1),5\n",
+		},
+		{
+			name:   "format parameter wins over accept header",
+			query:  "startDate=2025-03-01&endDate=2025-03-31&format=xlsx",
+			accept: "text/csv",
+			mockSetup: func(uc *MockReportsUC) {
+				uc.On("Acceptance", mock.Anything, mock.Anything).Return(rows, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: xlsx.ContentType,
+		},
+		{
+			name:           "unsupported accept header",
+			query:          "startDate=2025-03-01&endDate=2025-03-31",
+			accept:         "application/pdf",
+			expectedStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:           "unknown format",
+			query:          "startDate=2025-03-01&endDate=2025-03-31&format=pdf",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing period",
+			query:          "startDate=2025-03-01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid period",
+			query: "startDate=2025-03-31&endDate=2025-03-01",
+			mockSetup: func(uc *MockReportsUC) {
+				uc.On("Acceptance", mock.Anything, mock.Anything).Return(nil, entity.ErrInvalidAcceptanceReportPeriod)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid acceptance report period"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockReportsUC)
+			if tt.mockSetup != nil {
+				tt.mockSetup(uc)
+			}
+
+			router := gin.New()
+			handler := reports.NewAuthRoutes(router.Group("/"), logger.NewMock(), uc, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/reports/acceptance?"+tt.query, nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+
+			handler.Acceptance(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			if tt.expectedContentType == xlsx.ContentType {
+				_, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+				require.NoError(t, err)
+				assert.Equal(t, `attachment; filename="acceptance_2025-03-01_2025-03-31.xlsx"`, w.Header().Get("Content-Disposition"))
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package reports
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger    logger.Interface
+	reportsUC usecase.Reports
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	reportsUC usecase.Reports,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:    logger,
+		reportsUC: reportsUC,
+	}
+
+	authGroup := apiV1Group.Group("/reports").
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.RequireRole(entity.UserRoleModerator))
+	{
+		authGroup.GET("/acceptance", au.Acceptance)
+	}
+
+	return au
+}
@@ -0,0 +1,52 @@
+package returns
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Routes) CreateReturn(c *gin.Context) {
+	var req dto.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	ret, err := h.returnsUC.CreateReturn(c.Request.Context(), req, principal)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	metrics.ReturnsCreated.Inc()
+	c.JSON(http.StatusCreated, ret)
+}
+
+func (h *Routes) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound),
+		errors.Is(err, entity.ErrReturnNotFound):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrInvalidProductStatusTransition),
+		errors.Is(err, entity.ErrReturnAlreadyShipped),
+		errors.Is(err, entity.ErrReturnShipmentConflict):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrNoActiveReturnShipment),
+		errors.Is(err, entity.ErrPVZNotFound),
+		errors.Is(err, entity.ErrInvalidReturnReportPeriod):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+	}
+}
@@ -0,0 +1,47 @@
+package returns
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) ListReturns(c *gin.Context) {
+	pvzId, err := uuid.Parse(c.Param("pvzId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var filter dto.ReturnsListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	returns, err := h.returnsUC.ListReturns(c.Request.Context(), pvzId, filter.Outstanding)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, returns)
+}
+
+func (h *Routes) Report(c *gin.Context) {
+	var filter dto.ReturnsReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	stats, err := h.returnsUC.Report(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
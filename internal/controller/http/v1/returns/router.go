@@ -0,0 +1,54 @@
+package returns
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger    logger.Interface
+	returnsUC usecase.Returns
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	returnsUC usecase.Returns,
+	assignmentUC usecase.PVZAssignment,
+	idempotencyUC usecase.Idempotency,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:    logger,
+		returnsUC: returnsUC,
+	}
+
+	authMiddleware := middleware.AuthMiddleware(jwtService, logger)
+	idempotency := middleware.Idempotency(idempotencyUC, logger)
+	bodyAccess := middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromJSONBody("pvzId"), logger)
+	paramAccess := middleware.RequirePVZAccess(assignmentUC, middleware.PVZIDFromParam("pvzId"), logger)
+
+	returnsGroup := apiV1Group.Group("/returns").Use(authMiddleware, idempotency)
+	{
+		returnsGroup.POST("", middleware.RequireRole(entity.UserRoleEmployee), bodyAccess, au.CreateReturn)
+		returnsGroup.GET("/report", middleware.RequireRole(entity.UserRoleModerator), au.Report)
+	}
+
+	shipmentGroup := apiV1Group.Group("/return-shipments").Use(authMiddleware, idempotency)
+	{
+		shipmentGroup.POST("", middleware.RequireRole(entity.UserRoleEmployee), bodyAccess, au.OpenShipment)
+	}
+
+	pvzGroup := apiV1Group.Group("/pvz").Use(authMiddleware, idempotency)
+	{
+		pvzGroup.GET("/:pvzId/returns", middleware.RequireRole(entity.UserRoleModerator, entity.UserRoleEmployee), paramAccess, au.ListReturns)
+		pvzGroup.POST("/:pvzId/return_shipment/returns", middleware.RequireRole(entity.UserRoleEmployee), paramAccess, au.AddToShipment)
+		pvzGroup.POST("/:pvzId/close_return_shipment", middleware.RequireRole(entity.UserRoleEmployee), paramAccess, au.CloseShipment)
+	}
+
+	return au
+}
@@ -3,10 +3,16 @@ package v1
 import (
 	"PVZ-avito-tech/config"
 	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/controller/http/v1/audit"
 	"PVZ-avito-tech/internal/controller/http/v1/auth"
+	"PVZ-avito-tech/internal/controller/http/v1/catalogue"
+	"PVZ-avito-tech/internal/controller/http/v1/city"
 	"PVZ-avito-tech/internal/controller/http/v1/products"
 	"PVZ-avito-tech/internal/controller/http/v1/pvz"
 	"PVZ-avito-tech/internal/controller/http/v1/reception"
+	"PVZ-avito-tech/internal/controller/http/v1/reports"
+	"PVZ-avito-tech/internal/controller/http/v1/returns"
+	"PVZ-avito-tech/internal/controller/http/v1/webhooks"
 	authPkg "PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/pkg/logger"
 	"PVZ-avito-tech/internal/usecase"
@@ -18,14 +24,25 @@ func NewRouter(
 	l logger.Interface,
 	authUC usecase.Auth,
 	dummyAuthUC usecase.DummyLogin,
+	tokenUC usecase.Token,
 	receptionUC usecase.ReceptionUseCase,
 	pvzUC usecase.PVZUseCase,
 	productUC usecase.ProductUseCase,
+	assignmentUC usecase.PVZAssignment,
+	cityUC usecase.City,
+	catalogueUC usecase.Catalogue,
+	returnsUC usecase.Returns,
+	webhooksUC usecase.Webhooks,
+	eventsUC usecase.Events,
+	auditUC usecase.Audit,
+	reportsUC usecase.Reports,
+	idempotencyUC usecase.Idempotency,
 	jwtService authPkg.TokenService,
 ) *gin.Engine {
 	router := gin.New()
 
 	router.Use(
+		middleware.RequestID(),
 		middleware.Logger(l),
 		middleware.PrometheusMiddleware(),
 	)
@@ -36,7 +53,9 @@ func NewRouter(
 			apiV1,
 			dummyAuthUC,
 			authUC,
+			tokenUC,
 			l,
+			jwtService,
 		)
 
 		pvz.NewAuthRoutes(
@@ -45,6 +64,9 @@ func NewRouter(
 			pvzUC,
 			receptionUC,
 			productUC,
+			assignmentUC,
+			eventsUC,
+			idempotencyUC,
 			jwtService,
 		)
 
@@ -52,13 +74,65 @@ func NewRouter(
 			apiV1,
 			l,
 			receptionUC,
+			assignmentUC,
+			idempotencyUC,
 			jwtService,
 		)
 
 		products.NewAuthRoutes(
 			apiV1,
 			productUC,
+			assignmentUC,
+			l,
+			idempotencyUC,
+			jwtService,
+		)
+
+		city.NewAuthRoutes(
+			apiV1,
+			l,
+			cityUC,
+			eventsUC,
+			idempotencyUC,
+			jwtService,
+		)
+
+		catalogue.NewAuthRoutes(
+			apiV1,
+			l,
+			catalogueUC,
+			idempotencyUC,
+			jwtService,
+		)
+
+		returns.NewAuthRoutes(
+			apiV1,
+			l,
+			returnsUC,
+			assignmentUC,
+			idempotencyUC,
+			jwtService,
+		)
+
+		webhooks.NewAuthRoutes(
+			apiV1,
+			l,
+			webhooksUC,
+			idempotencyUC,
+			jwtService,
+		)
+
+		audit.NewAuthRoutes(
+			apiV1,
+			l,
+			auditUC,
+			jwtService,
+		)
+
+		reports.NewAuthRoutes(
+			apiV1,
 			l,
+			reportsUC,
 			jwtService,
 		)
 	}
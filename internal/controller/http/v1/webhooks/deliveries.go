@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) Deliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("subscriptionId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	var filter dto.WebhookDeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	deliveries, err := h.webhooksUC.Deliveries(c.Request.Context(), id, filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,39 @@
+package webhooks
+
+import (
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type Routes struct {
+	logger     logger.Interface
+	webhooksUC usecase.Webhooks
+}
+
+func NewAuthRoutes(
+	apiV1Group *gin.RouterGroup,
+	logger logger.Interface,
+	webhooksUC usecase.Webhooks,
+	idempotencyUC usecase.Idempotency,
+	jwtService auth.TokenService,
+) *Routes {
+	au := &Routes{
+		logger:     logger,
+		webhooksUC: webhooksUC,
+	}
+
+	authGroup := apiV1Group.Group("/webhooks").
+		Use(middleware.AuthMiddleware(jwtService, logger), middleware.Idempotency(idempotencyUC, logger), middleware.RequireRole(entity.UserRoleModerator))
+	{
+		authGroup.POST("", au.Create)
+		authGroup.GET("", au.List)
+		authGroup.DELETE("/:subscriptionId", au.Delete)
+		authGroup.GET("/:subscriptionId/deliveries", au.Deliveries)
+	}
+
+	return au
+}
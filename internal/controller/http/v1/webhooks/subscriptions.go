@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	er "PVZ-avito-tech/internal/controller/http/errors"
+	"PVZ-avito-tech/internal/controller/http/middleware"
+	"PVZ-avito-tech/internal/entity"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Routes) Create(c *gin.Context) {
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidRequestBody)
+		return
+	}
+
+	principal, _ := middleware.CurrentPrincipal(c)
+	sub, err := h.webhooksUC.CreateSubscription(c.Request.Context(), req, principal)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *Routes) List(c *gin.Context) {
+	subs, err := h.webhooksUC.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+func (h *Routes) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("subscriptionId"))
+	if err != nil {
+		dto.ErrorResponse(c, http.StatusBadRequest, er.ErrInvalidParam)
+		return
+	}
+
+	if err := h.webhooksUC.DeleteSubscription(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Routes) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrWebhookSubscriptionNotFound):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrInvalidWebhookURL),
+		errors.Is(err, entity.ErrWebhookURLNotPublic),
+		errors.Is(err, entity.ErrInvalidWebhookEventTypes),
+		errors.Is(err, entity.ErrInvalidWebhookSecret),
+		errors.Is(err, entity.ErrInvalidWebhookDeliveryStatus),
+		errors.Is(err, entity.ErrInvalidCity),
+		errors.Is(err, entity.ErrPVZNotFound):
+		h.logger.Warn(err.Error())
+		dto.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(err.Error())
+		dto.ErrorResponse(c, http.StatusInternalServerError, entity.ErrInternal.Error())
+	}
+}
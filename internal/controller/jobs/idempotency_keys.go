@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase"
+	"context"
+)
+
+type IdempotencyKeysCleanup struct {
+	idempotencyUC usecase.Idempotency
+}
+
+func NewIdempotencyKeysCleanup(idempotencyUC usecase.Idempotency) *IdempotencyKeysCleanup {
+	return &IdempotencyKeysCleanup{idempotencyUC: idempotencyUC}
+}
+
+func (j *IdempotencyKeysCleanup) Run(ctx context.Context) error {
+	purged, err := j.idempotencyUC.Purge(ctx)
+	if err != nil {
+		return err
+	}
+	metrics.IdempotencyKeysPurged.Add(float64(purged))
+	return nil
+}
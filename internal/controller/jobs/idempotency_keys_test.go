@@ -0,0 +1,80 @@
+package jobs_test
+
+import (
+	"PVZ-avito-tech/internal/controller/jobs"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type MockIdempotencyUC struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyUC) Begin(ctx context.Context, scope, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	args := m.Called(ctx, scope, key, requestHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyUC) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	args := m.Called(ctx, scope, key, statusCode, contentType, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyUC) Release(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyUC) Purge(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestIdempotencyKeysCleanup_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		purged         int64
+		purgeErr       error
+		expectedPurged float64
+	}{
+		{
+			name:           "expired keys are counted",
+			purged:         4,
+			expectedPurged: 4,
+		},
+		{
+			name:     "purge failure",
+			purgeErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockIdempotencyUC)
+			uc.On("Purge", ctx).Return(tt.purged, tt.purgeErr)
+
+			purgedBefore := testutil.ToFloat64(metrics.IdempotencyKeysPurged)
+
+			err := jobs.NewIdempotencyKeysCleanup(uc).Run(ctx)
+
+			if tt.purgeErr != nil {
+				assert.ErrorIs(t, err, tt.purgeErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPurged, testutil.ToFloat64(metrics.IdempotencyKeysPurged)-purgedBefore)
+			uc.AssertExpectations(t)
+		})
+	}
+}
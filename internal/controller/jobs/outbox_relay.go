@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase"
+	"context"
+)
+
+type OutboxRelay struct {
+	outboxUC usecase.Outbox
+}
+
+func NewOutboxRelay(outboxUC usecase.Outbox) *OutboxRelay {
+	return &OutboxRelay{outboxUC: outboxUC}
+}
+
+func (j *OutboxRelay) Run(ctx context.Context) error {
+	published, err := j.outboxUC.Relay(ctx)
+	metrics.EventsPublished.Add(float64(published))
+	if err != nil {
+		metrics.EventsPublishFailures.Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs_test
+
+import (
+	"PVZ-avito-tech/internal/controller/jobs"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type MockOutboxUC struct {
+	mock.Mock
+}
+
+func (m *MockOutboxUC) Relay(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestOutboxRelay_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		published         int
+		relayErr          error
+		expectedPublished float64
+		expectedFailures  float64
+	}{
+		{
+			name:              "published events are counted",
+			published:         3,
+			expectedPublished: 3,
+		},
+		{
+			name:              "partial relay failure",
+			published:         1,
+			relayErr:          errors.New("receiver down"),
+			expectedPublished: 1,
+			expectedFailures:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockOutboxUC)
+			uc.On("Relay", ctx).Return(tt.published, tt.relayErr)
+
+			publishedBefore := testutil.ToFloat64(metrics.EventsPublished)
+			failuresBefore := testutil.ToFloat64(metrics.EventsPublishFailures)
+
+			err := jobs.NewOutboxRelay(uc).Run(ctx)
+
+			if tt.relayErr != nil {
+				assert.ErrorIs(t, err, tt.relayErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPublished, testutil.ToFloat64(metrics.EventsPublished)-publishedBefore)
+			assert.Equal(t, tt.expectedFailures, testutil.ToFloat64(metrics.EventsPublishFailures)-failuresBefore)
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase"
+	"context"
+	"time"
+)
+
+type StaleReceptions struct {
+	receptionUC usecase.ReceptionUseCase
+	idleFor     time.Duration
+	logger      logger.Interface
+	now         func() time.Time
+}
+
+func NewStaleReceptions(
+	receptionUC usecase.ReceptionUseCase,
+	idleFor time.Duration,
+	l logger.Interface,
+) *StaleReceptions {
+	return &StaleReceptions{
+		receptionUC: receptionUC,
+		idleFor:     idleFor,
+		logger:      l,
+		now:         time.Now,
+	}
+}
+
+func (j *StaleReceptions) Run(ctx context.Context) error {
+	if j.idleFor > 0 {
+		closed, err := j.receptionUC.CloseStaleReceptions(ctx, j.idleFor)
+		if err != nil {
+			return err
+		}
+
+		for _, reception := range closed {
+			j.logger.Warn("stale reception %s of pvz %s closed automatically", reception.ID, reception.PVZID)
+		}
+		metrics.ReceptionsAutoClosed.Add(float64(len(closed)))
+	}
+
+	stats, err := j.receptionUC.OpenStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	metrics.ReceptionsOpen.Set(float64(stats.Count))
+	if stats.OldestOpenedAt != nil {
+		metrics.ReceptionsOpenOldestAge.Set(j.now().Sub(*stats.OldestOpenedAt).Seconds())
+	} else {
+		metrics.ReceptionsOpenOldestAge.Set(0)
+	}
+
+	return nil
+}
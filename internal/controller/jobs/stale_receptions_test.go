@@ -0,0 +1,171 @@
+package jobs_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/controller/jobs"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type MockReceptionUC struct {
+	mock.Mock
+}
+
+func (m *MockReceptionUC) CreateReception(ctx context.Context, request dto.ReceptionsRequest, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, request, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) CloseReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) GetReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) ListProducts(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+func (m *MockReceptionUC) ReopenReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) CancelReception(ctx context.Context, id uuid.UUID, reason string, actor entity.Principal) (*entity.Reception, error) {
+	args := m.Called(ctx, id, reason, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReceptionStatusChange), args.Error(1)
+}
+
+func (m *MockReceptionUC) CloseStaleReceptions(ctx context.Context, idleFor time.Duration) ([]entity.Reception, error) {
+	args := m.Called(ctx, idleFor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionUC) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OpenReceptionStats), args.Error(1)
+}
+
+func (m *MockReceptionUC) DiscrepancyReport(ctx context.Context, id uuid.UUID) (*entity.DiscrepancyReport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscrepancyReport), args.Error(1)
+}
+
+func TestStaleReceptions_Run(t *testing.T) {
+	ctx := context.Background()
+	oldest := time.Now().Add(-3 * time.Hour)
+
+	tests := []struct {
+		name           string
+		idleFor        time.Duration
+		mockSetup      func(*MockReceptionUC)
+		expectedClosed float64
+		expectedOpen   float64
+		expectAge      bool
+		expectedError  bool
+	}{
+		{
+			name:    "closes stale receptions and reports open ones",
+			idleFor: 2 * time.Hour,
+			mockSetup: func(uc *MockReceptionUC) {
+				uc.On("CloseStaleReceptions", ctx, 2*time.Hour).Return([]entity.Reception{
+					{ID: uuid.New(), PVZID: uuid.New(), Status: entity.CloseStatus},
+					{ID: uuid.New(), PVZID: uuid.New(), Status: entity.CloseStatus},
+				}, nil)
+				uc.On("OpenStats", ctx).Return(&entity.OpenReceptionStats{Count: 4, OldestOpenedAt: &oldest}, nil)
+			},
+			expectedClosed: 2,
+			expectedOpen:   4,
+			expectAge:      true,
+		},
+		{
+			name:    "auto-close disabled only refreshes gauges",
+			idleFor: 0,
+			mockSetup: func(uc *MockReceptionUC) {
+				uc.On("OpenStats", ctx).Return(&entity.OpenReceptionStats{}, nil)
+			},
+		},
+		{
+			name:    "sweep error",
+			idleFor: time.Hour,
+			mockSetup: func(uc *MockReceptionUC) {
+				uc.On("CloseStaleReceptions", ctx, time.Hour).Return(nil, errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockReceptionUC)
+			tt.mockSetup(uc)
+			closedBefore := testutil.ToFloat64(metrics.ReceptionsAutoClosed)
+
+			err := jobs.NewStaleReceptions(uc, tt.idleFor, logger.NewMock()).Run(ctx)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedClosed, testutil.ToFloat64(metrics.ReceptionsAutoClosed)-closedBefore)
+				assert.Equal(t, tt.expectedOpen, testutil.ToFloat64(metrics.ReceptionsOpen))
+				if tt.expectAge {
+					assert.InDelta(t, (3 * time.Hour).Seconds(), testutil.ToFloat64(metrics.ReceptionsOpenOldestAge), 60)
+				} else {
+					assert.Zero(t, testutil.ToFloat64(metrics.ReceptionsOpenOldestAge))
+				}
+			}
+
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase"
+	"context"
+)
+
+type WebhookDeliveries struct {
+	webhooksUC usecase.Webhooks
+}
+
+func NewWebhookDeliveries(webhooksUC usecase.Webhooks) *WebhookDeliveries {
+	return &WebhookDeliveries{webhooksUC: webhooksUC}
+}
+
+func (j *WebhookDeliveries) Run(ctx context.Context) error {
+	stats, err := j.webhooksUC.Deliver(ctx)
+
+	metrics.WebhookDeliveries.WithLabelValues("delivered").Add(float64(stats.Delivered))
+	metrics.WebhookDeliveries.WithLabelValues("retried").Add(float64(stats.Retried))
+	metrics.WebhookDeliveries.WithLabelValues("failed").Add(float64(stats.Failed))
+
+	return err
+}
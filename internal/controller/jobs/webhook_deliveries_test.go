@@ -0,0 +1,111 @@
+package jobs_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/controller/jobs"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/metrics"
+	"PVZ-avito-tech/internal/usecase/webhook"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type MockWebhooksUC struct {
+	mock.Mock
+}
+
+func (m *MockWebhooksUC) CreateSubscription(
+	ctx context.Context,
+	request dto.CreateWebhookSubscriptionRequest,
+	actor entity.Principal,
+) (*dto.WebhookSubscriptionCreated, error) {
+	args := m.Called(ctx, request, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.WebhookSubscriptionCreated), args.Error(1)
+}
+
+func (m *MockWebhooksUC) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhooksUC) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhooksUC) Deliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	filter dto.WebhookDeliveryFilter,
+) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhooksUC) Publish(ctx context.Context, events []entity.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockWebhooksUC) Deliver(ctx context.Context) (webhook.DeliveryStats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(webhook.DeliveryStats), args.Error(1)
+}
+
+func TestWebhookDeliveries_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		stats    webhook.DeliveryStats
+		err      error
+		expected map[string]float64
+	}{
+		{
+			name:     "counts every result",
+			stats:    webhook.DeliveryStats{Delivered: 3, Retried: 2, Failed: 1},
+			expected: map[string]float64{"delivered": 3, "retried": 2, "failed": 1},
+		},
+		{
+			name:     "partial batch before repo error",
+			stats:    webhook.DeliveryStats{Delivered: 1},
+			err:      errors.New("db error"),
+			expected: map[string]float64{"delivered": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := new(MockWebhooksUC)
+			uc.On("Deliver", ctx).Return(tt.stats, tt.err)
+
+			before := make(map[string]float64)
+			for _, result := range []string{"delivered", "retried", "failed"} {
+				before[result] = testutil.ToFloat64(metrics.WebhookDeliveries.WithLabelValues(result))
+			}
+
+			err := jobs.NewWebhookDeliveries(uc).Run(ctx)
+
+			assert.Equal(t, tt.err, err)
+			for _, result := range []string{"delivered", "retried", "failed"} {
+				got := testutil.ToFloat64(metrics.WebhookDeliveries.WithLabelValues(result)) - before[result]
+				assert.Equal(t, tt.expected[result], got, result)
+			}
+			uc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditPVZCreated           AuditAction = "pvz.create"
+	AuditReceptionCreated     AuditAction = "reception.create"
+	AuditReceptionClosed      AuditAction = "reception.close"
+	AuditReceptionReopened    AuditAction = "reception.reopen"
+	AuditReceptionCancelled   AuditAction = "reception.cancel"
+	AuditProductAdded         AuditAction = "product.add"
+	AuditProductDeleted       AuditAction = "product.delete"
+	AuditProductStatusChanged AuditAction = "product.status_change"
+)
+
+func ReceptionStatusAuditAction(status ReceptionsStatus) AuditAction {
+	switch status {
+	case CloseStatus:
+		return AuditReceptionClosed
+	case CancelledStatus:
+		return AuditReceptionCancelled
+	default:
+		return AuditReceptionReopened
+	}
+}
+
+type AuditEntityType string
+
+const (
+	AuditEntityPVZ       AuditEntityType = "pvz"
+	AuditEntityReception AuditEntityType = "reception"
+	AuditEntityProduct   AuditEntityType = "product"
+)
+
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityPVZ, AuditEntityReception, AuditEntityProduct:
+		return true
+	}
+	return false
+}
+
+// AuditEntry is an immutable record of a mutation. An empty actor means the
+// change was made by the system, e.g. the stale reception sweep.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	ActorID    *uuid.UUID      `json:"actorId,omitempty"`
+	ActorRole  UserRole        `json:"actorRole,omitempty"`
+	Action     AuditAction     `json:"action"`
+	EntityType AuditEntityType `json:"entityType"`
+	EntityID   uuid.UUID       `json:"entityId"`
+	PVZID      uuid.UUID       `json:"pvzId"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"requestId,omitempty"`
+}
+
+type AuditFilter struct {
+	EntityType AuditEntityType
+	EntityID   *uuid.UUID
+	ActorID    *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
@@ -2,7 +2,14 @@ package entity_test
 
 import (
 	"PVZ-avito-tech/internal/entity"
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCity_IsValidCity(t *testing.T) {
@@ -27,8 +34,18 @@ func TestCity_IsValidCity(t *testing.T) {
 			want: true,
 		},
 		{
-			name: "invalid city",
-			city: "Invalid City",
+			name: "city from dictionary",
+			city: "Новосибирск",
+			want: true,
+		},
+		{
+			name: "city with surrounding spaces",
+			city: " Казань ",
+			want: false,
+		},
+		{
+			name: "too long city",
+			city: entity.City(strings.Repeat("я", 256)),
 			want: false,
 		},
 		{
@@ -69,8 +86,13 @@ func TestCity_ValidateCity(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "invalid city",
-			city:    "Invalid City",
+			name:    "city from dictionary",
+			city:    "Новосибирск",
+			wantErr: false,
+		},
+		{
+			name:    "city with surrounding spaces",
+			city:    " Казань ",
 			wantErr: true,
 		},
 		{
@@ -117,8 +139,13 @@ func TestProductType_IsValidProductType(t *testing.T) {
 			want:        true,
 		},
 		{
-			name:        "invalid product type",
-			productType: "Invalid Product Type",
+			name:        "product type from catalogue",
+			productType: "мебель",
+			want:        true,
+		},
+		{
+			name:        "product type with surrounding spaces",
+			productType: " обувь",
 			want:        false,
 		},
 		{
@@ -159,8 +186,13 @@ func TestProductType_ValidateProductType(t *testing.T) {
 			wantErr:     false,
 		},
 		{
-			name:        "invalid product type",
-			productType: "Invalid Product Type",
+			name:        "product type from catalogue",
+			productType: "мебель",
+			wantErr:     false,
+		},
+		{
+			name:        "too long product type",
+			productType: entity.ProductType(strings.Repeat("я", 256)),
 			wantErr:     true,
 		},
 		{
@@ -201,6 +233,11 @@ func TestReceptionsStatus_IsValidReceptionsStatus(t *testing.T) {
 			status: entity.CloseStatus,
 			want:   true,
 		},
+		{
+			name:   "valid status - cancelled",
+			status: entity.CancelledStatus,
+			want:   true,
+		},
 		{
 			name:   "invalid status",
 			status: "Invalid Status",
@@ -344,3 +381,235 @@ func TestUserRole_ValidateRole(t *testing.T) {
 		})
 	}
 }
+
+func TestProductStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from entity.ProductStatus
+		to   entity.ProductStatus
+		want bool
+	}{
+		{name: "received to stored", from: entity.ReceivedProductStatus, to: entity.StoredProductStatus, want: true},
+		{name: "stored to issued", from: entity.StoredProductStatus, to: entity.IssuedProductStatus, want: true},
+		{name: "stored to returned", from: entity.StoredProductStatus, to: entity.ReturnedProductStatus, want: true},
+		{name: "stored back to received", from: entity.StoredProductStatus, to: entity.ReceivedProductStatus, want: true},
+		{name: "issued to returned", from: entity.IssuedProductStatus, to: entity.ReturnedProductStatus, want: true},
+		{name: "received to issued", from: entity.ReceivedProductStatus, to: entity.IssuedProductStatus, want: false},
+		{name: "issued twice", from: entity.IssuedProductStatus, to: entity.IssuedProductStatus, want: false},
+		{name: "returned is terminal", from: entity.ReturnedProductStatus, to: entity.StoredProductStatus, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("ProductStatus.CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReceptionsStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from entity.ReceptionsStatus
+		to   entity.ReceptionsStatus
+		want bool
+	}{
+		{name: "in progress to close", from: entity.InProgressStatus, to: entity.CloseStatus, want: true},
+		{name: "in progress to cancelled", from: entity.InProgressStatus, to: entity.CancelledStatus, want: true},
+		{name: "close to in progress", from: entity.CloseStatus, to: entity.InProgressStatus, want: true},
+		{name: "close to cancelled", from: entity.CloseStatus, to: entity.CancelledStatus, want: false},
+		{name: "close twice", from: entity.CloseStatus, to: entity.CloseStatus, want: false},
+		{name: "cancelled is terminal", from: entity.CancelledStatus, to: entity.InProgressStatus, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("ReceptionsStatus.CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReception_CanReopen(t *testing.T) {
+	now := time.Now()
+	closedRecently := now.Add(-time.Hour)
+	closedLongAgo := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		reception entity.Reception
+		want      bool
+	}{
+		{
+			name:      "closed within window",
+			reception: entity.Reception{Status: entity.CloseStatus, ClosedAt: &closedRecently},
+			want:      true,
+		},
+		{
+			name:      "window expired",
+			reception: entity.Reception{Status: entity.CloseStatus, ClosedAt: &closedLongAgo},
+			want:      false,
+		},
+		{
+			name:      "not closed",
+			reception: entity.Reception{Status: entity.InProgressStatus},
+			want:      false,
+		},
+		{
+			name:      "cancelled",
+			reception: entity.Reception{Status: entity.CancelledStatus, ClosedAt: &closedRecently},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reception.CanReopen(now, 24*time.Hour); got != tt.want {
+				t.Errorf("Reception.CanReopen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []entity.ManifestItem
+		wantErr bool
+	}{
+		{name: "empty manifest", items: nil},
+		{
+			name: "barcoded and counted items",
+			items: []entity.ManifestItem{
+				{Barcode: "4601234567890", Type: entity.ShoesProductType, Quantity: 1},
+				{Type: entity.ClothesProductType, Quantity: 10},
+			},
+		},
+		{name: "zero quantity", items: []entity.ManifestItem{{Type: entity.ClothesProductType}}, wantErr: true},
+		{name: "empty type", items: []entity.ManifestItem{{Quantity: 1}}, wantErr: true},
+		{
+			name:    "barcoded item with quantity",
+			items:   []entity.ManifestItem{{Barcode: "4601234567890", Type: entity.ShoesProductType, Quantity: 2}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate barcode",
+			items: []entity.ManifestItem{
+				{Barcode: "4601234567890", Type: entity.ShoesProductType, Quantity: 1},
+				{Barcode: "4601234567890", Type: entity.ClothesProductType, Quantity: 1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entity.ValidateManifest(tt.items)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidManifest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildDiscrepancyReport(t *testing.T) {
+	receptionID := uuid.New()
+	mismatchedID := uuid.New()
+
+	manifest := []entity.ManifestItem{
+		{Barcode: "A-1", Type: entity.ShoesProductType, Quantity: 1},
+		{Barcode: "A-2", Type: entity.ShoesProductType, Quantity: 1},
+		{Barcode: "A-3", Type: entity.ElectronicsProductType, Quantity: 1},
+		{Type: entity.ClothesProductType, Quantity: 3},
+	}
+	products := []entity.Product{
+		{ID: uuid.New(), Barcode: "A-1", Type: entity.ShoesProductType},
+		{ID: mismatchedID, Barcode: "A-3", Type: entity.ClothesProductType},
+		{ID: uuid.New(), Type: entity.ClothesProductType},
+		{ID: uuid.New(), Barcode: "B-9", Type: entity.ClothesProductType},
+		{ID: uuid.New(), Barcode: "B-7", Type: entity.ElectronicsProductType},
+		{ID: uuid.New(), Type: entity.ElectronicsProductType},
+		{ID: uuid.New(), Type: entity.ElectronicsProductType},
+	}
+
+	report := entity.BuildDiscrepancyReport(receptionID, manifest, products)
+
+	assert.Equal(t, receptionID, report.ReceptionID)
+	assert.Equal(t, 6, report.ExpectedCount)
+	assert.Equal(t, 7, report.ReceivedCount)
+	assert.True(t, report.HasDiscrepancies())
+	assert.ElementsMatch(t, []entity.ManifestItem{
+		{Barcode: "A-2", Type: entity.ShoesProductType, Quantity: 1},
+		{Type: entity.ClothesProductType, Quantity: 1},
+	}, report.Missing)
+	assert.ElementsMatch(t, []entity.ManifestItem{
+		{Barcode: "B-7", Type: entity.ElectronicsProductType, Quantity: 1},
+		{Type: entity.ElectronicsProductType, Quantity: 2},
+	}, report.Extra)
+	assert.Equal(t, []entity.TypeMismatch{{
+		ProductID:    mismatchedID,
+		Barcode:      "A-3",
+		ExpectedType: entity.ElectronicsProductType,
+		ActualType:   entity.ClothesProductType,
+	}}, report.MismatchedType)
+}
+
+func TestBuildDiscrepancyReport_ExactMatch(t *testing.T) {
+	report := entity.BuildDiscrepancyReport(uuid.New(),
+		[]entity.ManifestItem{{Type: entity.ShoesProductType, Quantity: 2}},
+		[]entity.Product{{Type: entity.ShoesProductType}, {Type: entity.ShoesProductType}},
+	)
+
+	assert.False(t, report.HasDiscrepancies())
+	assert.Empty(t, report.Missing)
+	assert.Empty(t, report.Extra)
+	assert.Empty(t, report.MismatchedType)
+}
+
+func TestNewEvent(t *testing.T) {
+	pvzID := uuid.New()
+
+	event, err := entity.NewEvent(entity.EventPVZCreated, pvzID, pvzID, entity.PVZCreatedData{ID: pvzID, City: "Москва"})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.Equal(t, 1, event.Version)
+	assert.Equal(t, pvzID, event.PVZID)
+	assert.JSONEq(t, `{"id":"`+pvzID.String()+`","city":"Москва","registrationDate":"0001-01-01T00:00:00Z"}`, string(event.Data))
+
+	_, err = entity.NewEvent("pvz.renamed", pvzID, pvzID, nil)
+	assert.Error(t, err)
+}
+
+func TestEventSchema_DocumentsAllTypes(t *testing.T) {
+	raw, err := os.ReadFile("../../api/events/v1/events.schema.json")
+	assert.NoError(t, err)
+
+	var schema struct {
+		Properties struct {
+			Type struct {
+				Enum []entity.EventType `json:"enum"`
+			} `json:"type"`
+		} `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &schema))
+
+	types := []entity.EventType{
+		entity.EventPVZCreated,
+		entity.EventReceptionCreated,
+		entity.EventReceptionClosed,
+		entity.EventReceptionReopened,
+		entity.EventReceptionCancelled,
+		entity.EventProductAdded,
+		entity.EventProductDeleted,
+		entity.EventProductStatusChanged,
+	}
+	assert.ElementsMatch(t, types, schema.Properties.Type.Enum)
+	for _, eventType := range types {
+		assert.Equal(t, 1, eventType.SchemaVersion(), eventType)
+	}
+}
@@ -11,12 +11,73 @@ var (
 	ErrInternal          = errors.New("internal error")
 	ErrUserAlreadyExists = errors.New("user already exists")
 
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
 	ErrCreatePVZ  = errors.New("failed to create PVZ")
 	ErrGetPVZList = errors.New("failed to get PVZ list")
 
+	ErrInvalidCity       = errors.New("invalid city")
+	ErrCityNotFound      = errors.New("city not found")
+	ErrCityAlreadyExists = errors.New("city already exists")
+	ErrCityInUse         = errors.New("city is used by pvz")
+
 	ErrPVZNotFound       = errors.New("pvz not found")
 	ErrReceptionConflict = errors.New("existing open reception")
+	ErrReceptionNotFound = errors.New("reception not found")
+
+	ErrInvalidReceptionStatusTransition = errors.New("reception status transition is not allowed")
+	ErrReceptionReopenWindowExpired     = errors.New("reception reopen window has expired")
+	ErrReceptionProductsLeftStorage     = errors.New("reception has products that were issued or returned")
+	ErrInvalidCancellationReason        = errors.New("invalid cancellation reason")
+	ErrInvalidManifest                  = errors.New("invalid reception manifest")
+	ErrDiscrepancyReportNotFound        = errors.New("discrepancy report not found")
+
+	ErrInvalidProductType           = errors.New("invalid product type")
+	ErrProductTypeNotFound          = errors.New("product type not found")
+	ErrProductTypeAlreadyExists     = errors.New("product type already exists")
+	ErrProductTypeInactive          = errors.New("product type is inactive")
+	ErrInvalidProductCategory       = errors.New("invalid product category")
+	ErrProductCategoryNotFound      = errors.New("product category not found")
+	ErrProductCategoryAlreadyExists = errors.New("product category already exists")
+	ErrProductCategoryInactive      = errors.New("product category is inactive")
 
 	ErrNoActiveReception = errors.New("no active reception")
 	ErrNoProducts        = errors.New("no products")
+
+	ErrProductNotFound                = errors.New("product not found")
+	ErrInvalidPickupCode              = errors.New("invalid pickup code")
+	ErrPickupCodeNotSet               = errors.New("product has no pickup code, it can only be issued by a moderator")
+	ErrPickupCodeAttemptsExceeded     = errors.New("too many invalid pickup codes, the product can only be issued by a moderator")
+	ErrInvalidProductStatusTransition = errors.New("product status transition is not allowed")
+	ErrInvalidBarcode                 = errors.New("invalid barcode")
+	ErrBarcodeConflict                = errors.New("product with this barcode is already accepted")
+	ErrReceptionNotInProgress         = errors.New("reception is not in progress")
+	ErrInvalidDeletionReason          = errors.New("invalid deletion reason")
+
+	ErrReturnNotFound            = errors.New("return not found")
+	ErrReturnAlreadyShipped      = errors.New("return already added to shipment")
+	ErrNoActiveReturnShipment    = errors.New("no active return shipment")
+	ErrReturnShipmentConflict    = errors.New("existing open return shipment")
+	ErrInvalidReturnReportPeriod = errors.New("invalid return report period")
+
+	ErrInvalidAcceptanceReportPeriod = errors.New("invalid acceptance report period")
+	ErrInvalidAcceptanceReportFilter = errors.New("invalid acceptance report filter")
+
+	ErrEmployeeNotFound   = errors.New("employee not found")
+	ErrAssignmentNotFound = errors.New("employee is not assigned to pvz")
+	ErrPVZAccessDenied    = errors.New("access to pvz denied")
+
+	ErrWebhookSubscriptionNotFound  = errors.New("webhook subscription not found")
+	ErrInvalidWebhookURL            = errors.New("invalid webhook url")
+	ErrWebhookURLNotPublic          = errors.New("webhook url must resolve to public addresses only")
+	ErrInvalidWebhookEventTypes     = errors.New("invalid webhook event types")
+	ErrInvalidWebhookSecret         = errors.New("webhook secret must be between 16 and 256 characters")
+	ErrInvalidWebhookDeliveryStatus = errors.New("invalid webhook delivery status")
+
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+	ErrInvalidAuditPeriod = errors.New("invalid audit period")
+
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
 )
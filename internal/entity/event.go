@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPVZCreated           EventType = "pvz.created"
+	EventReceptionCreated     EventType = "reception.created"
+	EventReceptionClosed      EventType = "reception.closed"
+	EventReceptionReopened    EventType = "reception.reopened"
+	EventReceptionCancelled   EventType = "reception.cancelled"
+	EventProductAdded         EventType = "product.added"
+	EventProductDeleted       EventType = "product.deleted"
+	EventProductStatusChanged EventType = "product.status_changed"
+)
+
+// eventSchemaVersions holds the current payload version of every event type.
+// Bump the version together with api/events whenever a payload changes incompatibly.
+var eventSchemaVersions = map[EventType]int{
+	EventPVZCreated:           1,
+	EventReceptionCreated:     1,
+	EventReceptionClosed:      1,
+	EventReceptionReopened:    1,
+	EventReceptionCancelled:   1,
+	EventProductAdded:         1,
+	EventProductDeleted:       1,
+	EventProductStatusChanged: 1,
+}
+
+func (t EventType) SchemaVersion() int {
+	return eventSchemaVersions[t]
+}
+
+func (t EventType) IsValid() bool {
+	_, ok := eventSchemaVersions[t]
+	return ok
+}
+
+type Event struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        EventType       `json:"type"`
+	Version     int             `json:"version"`
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	PVZID       uuid.UUID       `json:"pvzId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Data        json.RawMessage `json:"data"`
+
+	Sequence int64 `json:"-"`
+	City     City  `json:"-"`
+}
+
+// EventFilter narrows a live event stream to one PVZ or to all PVZ of a city.
+type EventFilter struct {
+	PVZID uuid.UUID
+	City  City
+}
+
+func (f EventFilter) Matches(e Event) bool {
+	if f.PVZID != uuid.Nil && e.PVZID != f.PVZID {
+		return false
+	}
+	if f.City != "" && e.City != f.City {
+		return false
+	}
+	return true
+}
+
+func NewEvent(eventType EventType, aggregateID, pvzID uuid.UUID, data any) (Event, error) {
+	if !eventType.IsValid() {
+		return Event{}, fmt.Errorf("unknown event type %q", eventType)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+
+	return Event{
+		ID:          uuid.New(),
+		Type:        eventType,
+		Version:     eventType.SchemaVersion(),
+		AggregateID: aggregateID,
+		PVZID:       pvzID,
+		OccurredAt:  time.Now().UTC(),
+		Data:        payload,
+	}, nil
+}
+
+func ReceptionStatusEvent(status ReceptionsStatus) EventType {
+	switch status {
+	case CloseStatus:
+		return EventReceptionClosed
+	case CancelledStatus:
+		return EventReceptionCancelled
+	default:
+		return EventReceptionReopened
+	}
+}
+
+type PVZCreatedData struct {
+	ID               uuid.UUID `json:"id"`
+	City             City      `json:"city"`
+	RegistrationDate time.Time `json:"registrationDate"`
+}
+
+type ReceptionCreatedData struct {
+	ID            uuid.UUID        `json:"id"`
+	PVZID         uuid.UUID        `json:"pvzId"`
+	Status        ReceptionsStatus `json:"status"`
+	DateTime      time.Time        `json:"dateTime"`
+	CreatedBy     *uuid.UUID       `json:"createdBy,omitempty"`
+	ManifestItems int              `json:"manifestItems"`
+}
+
+type ReceptionStatusChangedData struct {
+	ID        uuid.UUID        `json:"id"`
+	PVZID     uuid.UUID        `json:"pvzId"`
+	From      ReceptionsStatus `json:"from"`
+	To        ReceptionsStatus `json:"to"`
+	Reason    string           `json:"reason,omitempty"`
+	ChangedBy *uuid.UUID       `json:"changedBy,omitempty"`
+}
+
+type ProductAddedData struct {
+	ID          uuid.UUID   `json:"id"`
+	ReceptionID uuid.UUID   `json:"receptionId"`
+	PVZID       uuid.UUID   `json:"pvzId"`
+	Type        ProductType `json:"type"`
+	Category    string      `json:"category,omitempty"`
+	Barcode     string      `json:"barcode,omitempty"`
+	DateTime    time.Time   `json:"dateTime"`
+	CreatedBy   *uuid.UUID  `json:"createdBy,omitempty"`
+}
+
+type ProductDeletedData struct {
+	ID          uuid.UUID  `json:"id"`
+	ReceptionID uuid.UUID  `json:"receptionId"`
+	PVZID       uuid.UUID  `json:"pvzId"`
+	Reason      string     `json:"reason,omitempty"`
+	DeletedBy   *uuid.UUID `json:"deletedBy,omitempty"`
+}
+
+type ProductStatusChangedData struct {
+	ID          uuid.UUID     `json:"id"`
+	ReceptionID uuid.UUID     `json:"receptionId"`
+	PVZID       uuid.UUID     `json:"pvzId"`
+	From        ProductStatus `json:"from"`
+	To          ProductStatus `json:"to"`
+	ChangedBy   *uuid.UUID    `json:"changedBy,omitempty"`
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+const maxManifestQuantity = 10000
+
+type ManifestItem struct {
+	Barcode  string      `json:"barcode,omitempty"`
+	Type     ProductType `json:"type"`
+	Quantity int         `json:"quantity"`
+}
+
+type TypeMismatch struct {
+	ProductID    uuid.UUID   `json:"productId"`
+	Barcode      string      `json:"barcode"`
+	ExpectedType ProductType `json:"expectedType"`
+	ActualType   ProductType `json:"actualType"`
+}
+
+type DiscrepancyReport struct {
+	ReceptionID    uuid.UUID      `json:"receptionId"`
+	ExpectedCount  int            `json:"expectedCount"`
+	ReceivedCount  int            `json:"receivedCount"`
+	Missing        []ManifestItem `json:"missing"`
+	Extra          []ManifestItem `json:"extra"`
+	MismatchedType []TypeMismatch `json:"mismatchedType"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+func (r *DiscrepancyReport) HasDiscrepancies() bool {
+	return len(r.Missing) > 0 || len(r.Extra) > 0 || len(r.MismatchedType) > 0
+}
+
+func ValidateManifest(items []ManifestItem) error {
+	barcodes := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		if !item.Type.IsValidProductType() {
+			return ErrInvalidManifest
+		}
+		if item.Quantity < 1 || item.Quantity > maxManifestQuantity {
+			return ErrInvalidManifest
+		}
+		if item.Barcode == "" {
+			continue
+		}
+		if !IsValidBarcode(item.Barcode) || item.Quantity != 1 {
+			return ErrInvalidManifest
+		}
+		if _, dup := barcodes[item.Barcode]; dup {
+			return ErrInvalidManifest
+		}
+		barcodes[item.Barcode] = struct{}{}
+	}
+	return nil
+}
+
+// BuildDiscrepancyReport matches received products against the manifest: barcoded
+// items are matched by barcode first, everything else is counted per product type.
+func BuildDiscrepancyReport(receptionID uuid.UUID, manifest []ManifestItem, products []Product) *DiscrepancyReport {
+	report := &DiscrepancyReport{
+		ReceptionID:    receptionID,
+		ReceivedCount:  len(products),
+		Missing:        make([]ManifestItem, 0),
+		Extra:          make([]ManifestItem, 0),
+		MismatchedType: make([]TypeMismatch, 0),
+	}
+
+	expectedByBarcode := make(map[string]ProductType)
+	expectedByType := make(map[ProductType]int)
+	for _, item := range manifest {
+		report.ExpectedCount += item.Quantity
+		if item.Barcode != "" {
+			expectedByBarcode[item.Barcode] = item.Type
+		} else {
+			expectedByType[item.Type] += item.Quantity
+		}
+	}
+
+	extraByType := make(map[ProductType]int)
+	for _, p := range products {
+		if expectedType, ok := expectedByBarcode[p.Barcode]; ok && p.Barcode != "" {
+			delete(expectedByBarcode, p.Barcode)
+			if expectedType != p.Type {
+				report.MismatchedType = append(report.MismatchedType, TypeMismatch{
+					ProductID:    p.ID,
+					Barcode:      p.Barcode,
+					ExpectedType: expectedType,
+					ActualType:   p.Type,
+				})
+			}
+			continue
+		}
+
+		if expectedByType[p.Type] > 0 {
+			expectedByType[p.Type]--
+			continue
+		}
+
+		if p.Barcode != "" {
+			report.Extra = append(report.Extra, ManifestItem{Barcode: p.Barcode, Type: p.Type, Quantity: 1})
+		} else {
+			extraByType[p.Type]++
+		}
+	}
+
+	for barcode, productType := range expectedByBarcode {
+		report.Missing = append(report.Missing, ManifestItem{Barcode: barcode, Type: productType, Quantity: 1})
+	}
+	for productType, quantity := range expectedByType {
+		if quantity > 0 {
+			report.Missing = append(report.Missing, ManifestItem{Type: productType, Quantity: quantity})
+		}
+	}
+	for productType, quantity := range extraByType {
+		report.Extra = append(report.Extra, ManifestItem{Type: productType, Quantity: quantity})
+	}
+
+	sortManifestItems(report.Missing)
+	sortManifestItems(report.Extra)
+
+	return report
+}
+
+func sortManifestItems(items []ManifestItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Barcode < items[j].Barcode
+	})
+}
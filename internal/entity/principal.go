@@ -0,0 +1,17 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Principal struct {
+	UserID uuid.UUID
+	Email  string
+	Role   UserRole
+}
+
+func (p Principal) Actor() *uuid.UUID {
+	if p.UserID == uuid.Nil {
+		return nil
+	}
+	id := p.UserID
+	return &id
+}
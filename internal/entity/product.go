@@ -3,11 +3,63 @@ package entity
 import (
 	"github.com/google/uuid"
 	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxBarcodeLength    = 64
+	minPickupCodeLength = 4
+	maxPickupCodeLength = 32
 )
 
 type Product struct {
-	ID          uuid.UUID   `json:"id"`
-	DateTime    time.Time   `json:"dateTime"`
-	Type        ProductType `json:"type"`
-	ReceptionID uuid.UUID   `json:"receptionId"`
+	ID          uuid.UUID     `json:"id"`
+	DateTime    time.Time     `json:"dateTime"`
+	Type        ProductType   `json:"type"`
+	ReceptionID uuid.UUID     `json:"receptionId"`
+	Category    string        `json:"category,omitempty"`
+	Barcode     string        `json:"barcode,omitempty"`
+	Status      ProductStatus `json:"status,omitempty"`
+	CreatedBy   *uuid.UUID    `json:"createdBy,omitempty"`
+
+	PVZID              uuid.UUID `json:"-"`
+	PickupCode         string    `json:"-"`
+	PickupCodeHash     string    `json:"-"`
+	PickupCodeAttempts int       `json:"-"`
+}
+
+type ProductLocation struct {
+	Product   Product   `json:"product"`
+	Reception Reception `json:"reception"`
+	PVZ       PVZ       `json:"pvz"`
+}
+
+type ProductDeletion struct {
+	ProductID   uuid.UUID  `json:"productId"`
+	ReceptionID uuid.UUID  `json:"receptionId"`
+	Reason      string     `json:"reason"`
+	DeletedAt   time.Time  `json:"deletedAt"`
+	DeletedBy   *uuid.UUID `json:"deletedBy,omitempty"`
+}
+
+type ProductBatchResult struct {
+	Product *Product
+	Err     error
+}
+
+func IsValidPickupCode(code string) bool {
+	n := utf8.RuneCountInString(code)
+	return n >= minPickupCodeLength && n <= maxPickupCodeLength
+}
+
+func IsValidBarcode(barcode string) bool {
+	if barcode == "" || len(barcode) > maxBarcodeLength {
+		return false
+	}
+	for _, r := range barcode {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ProductStatus string
+
+const (
+	ReceivedProductStatus ProductStatus = "received"
+	StoredProductStatus   ProductStatus = "stored"
+	IssuedProductStatus   ProductStatus = "issued"
+	ReturnedProductStatus ProductStatus = "returned"
+)
+
+// Stored products go back to received when their reception is reopened.
+var productStatusTransitions = map[ProductStatus][]ProductStatus{
+	ReceivedProductStatus: {StoredProductStatus},
+	StoredProductStatus:   {IssuedProductStatus, ReturnedProductStatus, ReceivedProductStatus},
+	IssuedProductStatus:   {ReturnedProductStatus},
+}
+
+func (s ProductStatus) IsValidProductStatus() bool {
+	switch s {
+	case ReceivedProductStatus, StoredProductStatus, IssuedProductStatus, ReturnedProductStatus:
+		return true
+	}
+	return false
+}
+
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	for _, allowed := range productStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ProductStatusChange struct {
+	ID        uuid.UUID      `json:"id"`
+	ProductID uuid.UUID      `json:"productId"`
+	From      *ProductStatus `json:"from,omitempty"`
+	To        ProductStatus  `json:"to"`
+	ChangedAt time.Time      `json:"changedAt"`
+	ChangedBy *uuid.UUID     `json:"changedBy,omitempty"`
+}
@@ -1,6 +1,13 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
 
 type ProductType string
 
@@ -10,15 +17,26 @@ const (
 	ElectronicsProductType ProductType = "электроника"
 )
 
-var validProductTypeMap = map[ProductType]struct{}{
-	ShoesProductType:       {},
-	ClothesProductType:     {},
-	ElectronicsProductType: {},
+const maxCatalogueNameLength = 255
+
+type ProductTypeInfo struct {
+	ID         uuid.UUID         `json:"id"`
+	Name       ProductType       `json:"name"`
+	Active     bool              `json:"active"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	Categories []ProductCategory `json:"categories"`
+}
+
+type ProductCategory struct {
+	ID        uuid.UUID `json:"id"`
+	TypeID    uuid.UUID `json:"typeId"`
+	Name      string    `json:"name"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 func (r ProductType) IsValidProductType() bool {
-	_, exists := validProductTypeMap[r]
-	return exists
+	return IsValidCatalogueName(string(r))
 }
 
 func (r ProductType) ValidateProductType() error {
@@ -27,3 +45,9 @@ func (r ProductType) ValidateProductType() error {
 	}
 	return nil
 }
+
+func IsValidCatalogueName(name string) bool {
+	return name != "" &&
+		strings.TrimSpace(name) == name &&
+		utf8.RuneCountInString(name) <= maxCatalogueNameLength
+}
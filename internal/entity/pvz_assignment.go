@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PVZAssignment struct {
+	UserID     uuid.UUID  `json:"userId"`
+	PVZID      uuid.UUID  `json:"pvzId"`
+	AssignedBy *uuid.UUID `json:"assignedBy,omitempty"`
+	AssignedAt time.Time  `json:"assignedAt"`
+}
@@ -1,6 +1,13 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
 
 type City string
 
@@ -10,15 +17,19 @@ const (
 	CityKazan  City = "Казань"
 )
 
-var validCityMap = map[City]struct{}{
-	CityMoscow: {},
-	CitySpb:    {},
-	CityKazan:  {},
+const maxCityNameLength = 255
+
+type CityInfo struct {
+	ID        uuid.UUID `json:"id"`
+	Name      City      `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 func (r City) IsValidCity() bool {
-	_, exists := validCityMap[r]
-	return exists
+	name := string(r)
+	return name != "" &&
+		strings.TrimSpace(name) == name &&
+		utf8.RuneCountInString(name) <= maxCityNameLength
 }
 
 func (r City) ValidateCity() error {
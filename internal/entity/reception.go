@@ -6,8 +6,26 @@ import (
 )
 
 type Reception struct {
-	ID       uuid.UUID        `json:"id"`
-	DateTime time.Time        `json:"dateTime"`
-	PVZID    uuid.UUID        `json:"pvzId"`
-	Status   ReceptionsStatus `json:"status"`
+	ID                 uuid.UUID        `json:"id"`
+	DateTime           time.Time        `json:"dateTime"`
+	PVZID              uuid.UUID        `json:"pvzId"`
+	Status             ReceptionsStatus `json:"status"`
+	ClosedAt           *time.Time       `json:"closedAt,omitempty"`
+	CancellationReason string           `json:"cancellationReason,omitempty"`
+	CreatedBy          *uuid.UUID       `json:"createdBy,omitempty"`
+
+	Manifest          []ManifestItem     `json:"manifest,omitempty"`
+	DiscrepancyReport *DiscrepancyReport `json:"discrepancyReport,omitempty"`
+}
+
+type OpenReceptionStats struct {
+	Count          int
+	OldestOpenedAt *time.Time
+}
+
+func (r *Reception) CanReopen(now time.Time, window time.Duration) bool {
+	if !r.Status.CanTransitionTo(InProgressStatus) || r.ClosedAt == nil {
+		return false
+	}
+	return !now.After(r.ClosedAt.Add(window))
 }
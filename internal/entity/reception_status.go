@@ -1,17 +1,28 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
 
 type ReceptionsStatus string
 
 const (
 	InProgressStatus ReceptionsStatus = "in_progress"
 	CloseStatus      ReceptionsStatus = "close"
+	CancelledStatus  ReceptionsStatus = "cancelled"
 )
 
 var validReceptionsStatusMap = map[ReceptionsStatus]struct{}{
 	InProgressStatus: {},
 	CloseStatus:      {},
+	CancelledStatus:  {},
+}
+
+var receptionStatusTransitions = map[ReceptionsStatus][]ReceptionsStatus{
+	InProgressStatus: {CloseStatus, CancelledStatus},
+	CloseStatus:      {InProgressStatus},
 }
 
 func (r ReceptionsStatus) IsValidReceptionsStatus() bool {
@@ -25,3 +36,22 @@ func (r ReceptionsStatus) ValidateReceptionsStatus() error {
 	}
 	return nil
 }
+
+func (r ReceptionsStatus) CanTransitionTo(next ReceptionsStatus) bool {
+	for _, allowed := range receptionStatusTransitions[r] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ReceptionStatusChange struct {
+	ID          uuid.UUID         `json:"id"`
+	ReceptionID uuid.UUID         `json:"receptionId"`
+	From        *ReceptionsStatus `json:"from,omitempty"`
+	To          ReceptionsStatus  `json:"to"`
+	Reason      string            `json:"reason,omitempty"`
+	ChangedAt   time.Time         `json:"changedAt"`
+	ChangedBy   *uuid.UUID        `json:"changedBy,omitempty"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReportDayLayout formats the days of daily reports; days are in UTC.
+const ReportDayLayout = time.DateOnly
+
+// MaxAcceptanceReportDays bounds the period of a single acceptance report.
+const MaxAcceptanceReportDays = 366
+
+type AcceptanceReportFilter struct {
+	From   time.Time
+	To     time.Time
+	PVZID  *uuid.UUID
+	Cities []City
+}
+
+// AcceptanceReportRow counts the products of one type a PVZ accepted on a day;
+// products of receptions that are not closed are not accepted yet.
+type AcceptanceReportRow struct {
+	PVZID       uuid.UUID   `json:"pvzId"`
+	City        City        `json:"city"`
+	Day         string      `json:"day"`
+	ProductType ProductType `json:"productType"`
+	Accepted    int         `json:"accepted"`
+}
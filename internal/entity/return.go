@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Return struct {
+	ID         uuid.UUID  `json:"id"`
+	ProductID  uuid.UUID  `json:"productId"`
+	PVZID      uuid.UUID  `json:"pvzId"`
+	Reason     string     `json:"reason,omitempty"`
+	ShipmentID *uuid.UUID `json:"shipmentId,omitempty"`
+	DateTime   time.Time  `json:"dateTime"`
+	CreatedBy  *uuid.UUID `json:"createdBy,omitempty"`
+}
+
+type ReturnShipment struct {
+	ID        uuid.UUID        `json:"id"`
+	PVZID     uuid.UUID        `json:"pvzId"`
+	Status    ReceptionsStatus `json:"status"`
+	DateTime  time.Time        `json:"dateTime"`
+	ClosedAt  *time.Time       `json:"closedAt,omitempty"`
+	CreatedBy *uuid.UUID       `json:"createdBy,omitempty"`
+}
+
+type ReturnStats struct {
+	PVZID       uuid.UUID `json:"pvzId"`
+	City        City      `json:"city"`
+	Total       int       `json:"total"`
+	Outstanding int       `json:"outstanding"`
+	Shipped     int       `json:"shipped"`
+}
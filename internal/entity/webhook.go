@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID   `json:"id"`
+	URL        string      `json:"url"`
+	EventTypes []EventType `json:"eventTypes"`
+	PVZID      *uuid.UUID  `json:"pvzId,omitempty"`
+	City       *City       `json:"city,omitempty"`
+	Active     bool        `json:"active"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	CreatedBy  *uuid.UUID  `json:"createdBy,omitempty"`
+
+	Secret string `json:"-"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscriptionId"`
+	EventID        uuid.UUID             `json:"eventId"`
+	EventType      EventType             `json:"eventType"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"nextAttemptAt,omitempty"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	LastError      string                `json:"lastError,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+
+	Event  Event  `json:"-"`
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookAttempt is the outcome of a single delivery attempt. A nil
+// NextAttemptAt on a failed attempt means the delivery is given up.
+type WebhookAttempt struct {
+	DeliveryID    uuid.UUID
+	StatusCode    int
+	Error         string
+	Delivered     bool
+	NextAttemptAt *time.Time
+}
@@ -0,0 +1,17 @@
+package publisher
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+)
+
+type (
+	Publisher interface {
+		Publish(ctx context.Context, events []entity.Event) error
+	}
+
+	// Sender delivers a single event to a URL, signing it when secret is set.
+	Sender interface {
+		Send(ctx context.Context, url, secret string, event entity.Event) (int, error)
+	}
+)
@@ -0,0 +1,53 @@
+package file
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Publisher writes events as JSON lines, one event per line.
+type Publisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+func NewPublisher(w io.Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+func Open(path string) (*Publisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("file publisher - open %s: %w", path, err)
+	}
+	return &Publisher{w: f, closer: f}, nil
+}
+
+func (p *Publisher) Publish(ctx context.Context, events []entity.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	enc := json.NewEncoder(p.w)
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("file publisher - write event %s: %w", event.ID, err)
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
@@ -0,0 +1,54 @@
+package file_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/publisher/file"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	pvzID := uuid.New()
+	first, err := entity.NewEvent(entity.EventPVZCreated, pvzID, pvzID, entity.PVZCreatedData{ID: pvzID, City: "Москва"})
+	require.NoError(t, err)
+	second, err := entity.NewEvent(entity.EventReceptionCreated, uuid.New(), pvzID, entity.ReceptionCreatedData{PVZID: pvzID})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	p := file.NewPublisher(&buf)
+
+	require.NoError(t, p.Publish(context.Background(), []entity.Event{first, second}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var decoded entity.Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, first.ID, decoded.ID)
+	assert.Equal(t, entity.EventPVZCreated, decoded.Type)
+	assert.Equal(t, 1, decoded.Version)
+	assert.JSONEq(t, string(first.Data), string(decoded.Data))
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, second.ID, decoded.ID)
+}
+
+func TestPublisher_PublishCancelled(t *testing.T) {
+	event, err := entity.NewEvent(entity.EventPVZCreated, uuid.New(), uuid.New(), entity.PVZCreatedData{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	err = file.NewPublisher(&buf).Publish(ctx, []entity.Event{event})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, buf.String())
+}
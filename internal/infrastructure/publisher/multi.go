@@ -0,0 +1,22 @@
+package publisher
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+)
+
+type multi []Publisher
+
+// Multi publishes events to every publisher in order and stops at the first error.
+func Multi(publishers ...Publisher) Publisher {
+	return multi(publishers)
+}
+
+func (m multi) Publish(ctx context.Context, events []entity.Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
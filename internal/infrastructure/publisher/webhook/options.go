@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"PVZ-avito-tech/internal/pkg/netguard"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Option func(*Sender)
+
+func Timeout(timeout time.Duration) Option {
+	return func(s *Sender) {
+		if timeout > 0 {
+			s.client.Timeout = timeout
+		}
+	}
+}
+
+func Client(client *http.Client) Option {
+	return func(s *Sender) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// PublicAddressesOnly refuses connections to loopback, link-local and private
+// addresses; it is meant for URLs supplied by API users.
+func PublicAddressesOnly() Option {
+	return func(s *Sender) {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   netguard.Control,
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+		s.client.Transport = transport
+	}
+}
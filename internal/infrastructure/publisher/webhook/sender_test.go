@@ -0,0 +1,99 @@
+package webhook_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/publisher/webhook"
+	"PVZ-avito-tech/internal/pkg/netguard"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSender_Send(t *testing.T) {
+	pvzID := uuid.New()
+	event, err := entity.NewEvent(entity.EventReceptionClosed, uuid.New(), pvzID, entity.ReceptionStatusChangedData{PVZID: pvzID})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		secret        string
+		status        int
+		expectedError error
+	}{
+		{
+			name:   "signed delivery",
+			secret: "0123456789abcdef",
+			status: http.StatusOK,
+		},
+		{
+			name:   "unsigned delivery",
+			status: http.StatusAccepted,
+		},
+		{
+			name:          "receiver rejects",
+			secret:        "0123456789abcdef",
+			status:        http.StatusInternalServerError,
+			expectedError: webhook.ErrUnexpectedStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				signature := r.Header.Get(webhook.SignatureHeader)
+				if tt.secret == "" {
+					assert.Empty(t, signature)
+				} else {
+					timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+					assert.NoError(t, err)
+					assert.Equal(t, webhook.Sign(tt.secret, timestamp, body), signature)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			code, err := webhook.NewSender().Send(context.Background(), server.URL, tt.secret, event)
+
+			assert.Equal(t, tt.status, code)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSender_PublicAddressesOnly(t *testing.T) {
+	event, err := entity.NewEvent(entity.EventPVZCreated, uuid.New(), uuid.New(), entity.PVZCreatedData{})
+	require.NoError(t, err)
+
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	code, err := webhook.NewSender(webhook.PublicAddressesOnly()).Send(context.Background(), server.URL, "", event)
+
+	assert.ErrorIs(t, err, netguard.ErrNonPublicAddress)
+	assert.Zero(t, code)
+	assert.False(t, called)
+}
+
+func TestSign(t *testing.T) {
+	a := webhook.Sign("secret-one-16chr", 1700000000, []byte(`{"id":1}`))
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, a)
+	assert.NotEqual(t, a, webhook.Sign("secret-two-16chr", 1700000000, []byte(`{"id":1}`)))
+	assert.NotEqual(t, a, webhook.Sign("secret-one-16chr", 1700000001, []byte(`{"id":1}`)))
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+)
+
+// Publisher posts every event to the configured URL as a separate request so
+// that receivers can deduplicate by the X-Event-Id header.
+type Publisher struct {
+	url    string
+	sender *Sender
+}
+
+func NewPublisher(url string, opts ...Option) *Publisher {
+	return &Publisher{
+		url:    url,
+		sender: NewSender(opts...),
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, events []entity.Event) error {
+	for _, event := range events {
+		if _, err := p.sender.Send(ctx, p.url, "", event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package webhook_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/publisher/webhook"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	pvzID := uuid.New()
+	event, err := entity.NewEvent(entity.EventPVZCreated, pvzID, pvzID, entity.PVZCreatedData{ID: pvzID, City: "Казань"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		status        int
+		expectedError error
+	}{
+		{
+			name:   "delivered",
+			status: http.StatusNoContent,
+		},
+		{
+			name:          "receiver error",
+			status:        http.StatusBadGateway,
+			expectedError: webhook.ErrUnexpectedStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []entity.Event
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, event.ID.String(), r.Header.Get("X-Event-Id"))
+				assert.Equal(t, "pvz.created", r.Header.Get("X-Event-Type"))
+				assert.Equal(t, "1", r.Header.Get("X-Event-Version"))
+
+				var e entity.Event
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+				received = append(received, e)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := webhook.NewPublisher(server.URL).Publish(context.Background(), []entity.Event{event, event})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Len(t, received, 1)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, received, 2)
+				assert.Equal(t, event.ID, received[0].ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package cached
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CityRepo struct {
+	repo.CityRepo
+
+	ttl      time.Duration
+	mu       sync.RWMutex
+	names    map[entity.City]struct{}
+	loadedAt time.Time
+}
+
+func NewCityRepo(next repo.CityRepo, ttl time.Duration) *CityRepo {
+	return &CityRepo{
+		CityRepo: next,
+		ttl:      ttl,
+	}
+}
+
+func (r *CityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	r.mu.RLock()
+	if r.fresh() {
+		_, exists := r.names[name]
+		r.mu.RUnlock()
+		return exists, nil
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.fresh() {
+		cities, err := r.CityRepo.List(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		r.names = make(map[entity.City]struct{}, len(cities))
+		for _, city := range cities {
+			r.names[city.Name] = struct{}{}
+		}
+		r.loadedAt = time.Now()
+	}
+
+	_, exists := r.names[name]
+	return exists, nil
+}
+
+func (r *CityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	defer r.invalidate()
+	return r.CityRepo.Create(ctx, city)
+}
+
+func (r *CityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	defer r.invalidate()
+	return r.CityRepo.Rename(ctx, id, name)
+}
+
+func (r *CityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.invalidate()
+	return r.CityRepo.Delete(ctx, id)
+}
+
+func (r *CityRepo) fresh() bool {
+	return r.names != nil && time.Since(r.loadedAt) < r.ttl
+}
+
+func (r *CityRepo) invalidate() {
+	r.mu.Lock()
+	r.names = nil
+	r.mu.Unlock()
+}
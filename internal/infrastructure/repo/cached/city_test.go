@@ -0,0 +1,130 @@
+package cached_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo/cached"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCityRepo struct {
+	mock.Mock
+}
+
+func (m *MockCityRepo) Create(ctx context.Context, c *entity.CityInfo) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestCityRepo_ExistsUsesCache(t *testing.T) {
+	ctx := context.Background()
+	next := new(MockCityRepo)
+	next.On("List", ctx).Return([]entity.CityInfo{
+		{Name: entity.CityMoscow},
+		{Name: entity.CityKazan},
+	}, nil).Once()
+
+	r := cached.NewCityRepo(next, time.Minute)
+
+	exists, err := r.Exists(ctx, entity.CityMoscow)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = r.Exists(ctx, entity.CitySpb)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	next.AssertExpectations(t)
+	next.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+}
+
+func TestCityRepo_CreateInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	next := new(MockCityRepo)
+	next.On("List", ctx).Return([]entity.CityInfo{{Name: entity.CityMoscow}}, nil).Once()
+	next.On("Create", ctx, mock.Anything).Return(nil)
+	next.On("List", ctx).Return([]entity.CityInfo{{Name: entity.CityMoscow}, {Name: "Тверь"}}, nil).Once()
+
+	r := cached.NewCityRepo(next, time.Minute)
+
+	exists, err := r.Exists(ctx, "Тверь")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, r.Create(ctx, &entity.CityInfo{Name: "Тверь"}))
+
+	exists, err = r.Exists(ctx, "Тверь")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	next.AssertExpectations(t)
+}
+
+func TestCityRepo_ExpiredCacheReloads(t *testing.T) {
+	ctx := context.Background()
+	next := new(MockCityRepo)
+	next.On("List", ctx).Return([]entity.CityInfo{{Name: entity.CityMoscow}}, nil).Twice()
+
+	r := cached.NewCityRepo(next, time.Nanosecond)
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		exists, err := r.Exists(ctx, entity.CityMoscow)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	}
+
+	next.AssertExpectations(t)
+}
+
+func TestCityRepo_LoadError(t *testing.T) {
+	ctx := context.Background()
+	next := new(MockCityRepo)
+	next.On("List", ctx).Return(nil, errors.New("db error"))
+
+	r := cached.NewCityRepo(next, time.Minute)
+
+	exists, err := r.Exists(ctx, entity.CityMoscow)
+	assert.Error(t, err)
+	assert.False(t, exists)
+}
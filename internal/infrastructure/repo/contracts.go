@@ -5,6 +5,7 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type (
@@ -13,18 +14,121 @@ type (
 		GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	}
 
+	RefreshTokenRepo interface {
+		Create(ctx context.Context, token *entity.RefreshToken) error
+		Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+		Revoke(ctx context.Context, tokenHash string) error
+	}
+
+	RevokedTokenRepo interface {
+		Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+		IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	}
+
+	// TxManager runs several repository calls atomically: repositories join the
+	// transaction carried by the ctx passed to fn.
+	TxManager interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
 	PVZRepo interface {
 		Create(ctx context.Context, pvz *entity.PVZ) error
+		GetByID(ctx context.Context, id uuid.UUID) (*entity.PVZ, error)
 		GetPVZWithReceptions(ctx context.Context, filter dto.ReceptionFilter) (*[]dto.PVZInfo, error)
 	}
 
+	CityRepo interface {
+		Create(ctx context.Context, city *entity.CityInfo) error
+		GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error)
+		List(ctx context.Context) ([]entity.CityInfo, error)
+		Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+		Exists(ctx context.Context, name entity.City) (bool, error)
+	}
+
+	CatalogueRepo interface {
+		CreateType(ctx context.Context, productType *entity.ProductTypeInfo) error
+		ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error)
+		SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error)
+		CreateCategory(ctx context.Context, category *entity.ProductCategory) error
+		SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error)
+	}
+
 	ReceptionRepo interface {
-		CreateReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error)
-		CloseActiveReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error)
+		CreateReception(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID, manifest []entity.ManifestItem) (*entity.Reception, error)
+		CloseActiveReception(ctx context.Context, pvzID uuid.UUID, closedBy *uuid.UUID) (*entity.Reception, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*entity.Reception, error)
+		GetActiveByPVZ(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error)
+		ReopenReception(ctx context.Context, id uuid.UUID, window time.Duration, reopenedBy *uuid.UUID) (*entity.Reception, error)
+		CancelReception(ctx context.Context, id uuid.UUID, reason string, cancelledBy *uuid.UUID) (*entity.Reception, error)
+		ListStatusHistory(ctx context.Context, receptionID uuid.UUID) ([]entity.ReceptionStatusChange, error)
+		CloseStaleReceptions(ctx context.Context, idleFor time.Duration, limit int, reason string) ([]entity.Reception, error)
+		OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error)
+		GetDiscrepancyReport(ctx context.Context, receptionID uuid.UUID) (*entity.DiscrepancyReport, error)
+	}
+
+	PVZAssignmentRepo interface {
+		Assign(ctx context.Context, assignment *entity.PVZAssignment) error
+		Unassign(ctx context.Context, userID, pvzID uuid.UUID) error
+		IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error)
+		ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error)
 	}
 
 	ProductRepo interface {
-		AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error)
+		AddProduct(ctx context.Context, pvzID uuid.UUID, product *entity.Product) (*entity.Product, error)
+		AddProducts(ctx context.Context, pvzID uuid.UUID, products []*entity.Product) ([]entity.ProductBatchResult, error)
 		DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error
+		DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, deletedBy *uuid.UUID) (*entity.ProductDeletion, error)
+		ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error)
+		GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+		IssueProduct(ctx context.Context, id uuid.UUID, pickupCodeHash string, maxAttempts int, issuedBy *uuid.UUID) (*entity.Product, error)
+		IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error)
+		ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error)
+		FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error)
+	}
+
+	OutboxRepo interface {
+		Relay(ctx context.Context, limit int, publish func(ctx context.Context, events []entity.Event) error) (int, error)
+	}
+
+	EventStreamRepo interface {
+		Listen(ctx context.Context, handle func(entity.Event)) error
+		EventsSince(ctx context.Context, filter entity.EventFilter, afterSeq int64, limit int) ([]entity.Event, error)
+		ReplayStart(ctx context.Context, afterSeq int64, window time.Duration) (int64, error)
+	}
+
+	IdempotencyRepo interface {
+		Acquire(ctx context.Context, scope, key, requestHash string, ttl, lockTimeout time.Duration) (*entity.IdempotencyRecord, error)
+		Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error
+		Release(ctx context.Context, scope, key string) error
+		DeleteExpired(ctx context.Context) (int64, error)
+	}
+
+	AuditRepo interface {
+		List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error)
+	}
+
+	WebhookRepo interface {
+		CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error
+		GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+		ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+		DeactivateSubscription(ctx context.Context, id uuid.UUID) error
+		EnqueueDeliveries(ctx context.Context, events []entity.Event) (int, error)
+		ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+		RecordAttempt(ctx context.Context, attempt entity.WebhookAttempt) error
+		ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status entity.WebhookDeliveryStatus, limit int) ([]entity.WebhookDelivery, error)
+	}
+
+	ReturnRepo interface {
+		CreateReturn(ctx context.Context, ret *entity.Return) (*entity.Return, error)
+		ListByPVZ(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error)
+		OpenShipment(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID) (*entity.ReturnShipment, error)
+		AddToActiveShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error)
+		CloseActiveShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error)
+		Stats(ctx context.Context, from, to time.Time) ([]entity.ReturnStats, error)
+	}
+
+	ReportRepo interface {
+		AcceptanceReport(ctx context.Context, filter entity.AcceptanceReportFilter) ([]entity.AcceptanceReportRow, error)
 	}
 )
@@ -0,0 +1,110 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type AuditRepo struct {
+	*Storage
+}
+
+func NewAuditRepo(s *Storage) *AuditRepo {
+	return &AuditRepo{s}
+}
+
+func (r *AuditRepo) List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	entries := make([]entity.AuditEntry, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for i := len(t.audit) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+			e := t.audit[i]
+			switch {
+			case filter.EntityType != "" && e.EntityType != filter.EntityType,
+				filter.EntityID != nil && e.EntityID != *filter.EntityID,
+				filter.ActorID != nil && (e.ActorID == nil || *e.ActorID != *filter.ActorID),
+				filter.From != nil && e.OccurredAt.Before(*filter.From),
+				filter.To != nil && e.OccurredAt.After(*filter.To),
+				filter.BeforeID > 0 && e.ID >= filter.BeforeID:
+				continue
+			}
+			entries = append(entries, e)
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// auditRecord describes one mutation; the actor and request ID are taken from
+// the context when the record is written.
+type auditRecord struct {
+	action     entity.AuditAction
+	entityType entity.AuditEntityType
+	entityID   uuid.UUID
+	pvzID      uuid.UUID
+	before     any
+	after      any
+}
+
+// appendAudit snapshots every record before appending any, so a marshalling
+// error leaves the log untouched.
+func (s *Storage) appendAudit(ctx context.Context, t *tables, records ...auditRecord) error {
+	var (
+		actorID   *uuid.UUID
+		actorRole entity.UserRole
+	)
+	if principal, ok := requestctx.Principal(ctx); ok {
+		actorID = principal.Actor()
+		actorRole = principal.Role
+	}
+
+	entries := make([]entity.AuditEntry, 0, len(records))
+	for _, rec := range records {
+		before, err := auditSnapshot(rec.before)
+		if err != nil {
+			return err
+		}
+		after, err := auditSnapshot(rec.after)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entity.AuditEntry{
+			ActorID:    actorID,
+			ActorRole:  actorRole,
+			Action:     rec.action,
+			EntityType: rec.entityType,
+			EntityID:   rec.entityID,
+			PVZID:      rec.pvzID,
+			Before:     before,
+			After:      after,
+			RequestID:  requestctx.RequestID(ctx),
+		})
+	}
+
+	now := s.now()
+	for _, e := range entries {
+		s.auditSeq++
+		e.ID = s.auditSeq
+		e.OccurredAt = now
+		t.audit = append(t.audit, e)
+	}
+	return nil
+}
+
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
@@ -0,0 +1,157 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type CatalogueRepo struct {
+	*Storage
+}
+
+func NewCatalogueRepo(s *Storage) *CatalogueRepo {
+	return &CatalogueRepo{s}
+}
+
+func (r *CatalogueRepo) CreateType(ctx context.Context, productType *entity.ProductTypeInfo) error {
+	return r.write(ctx, func(t *tables) error {
+		if _, ok := t.productTypeByName(productType.Name); ok {
+			return entity.ErrProductTypeAlreadyExists
+		}
+
+		productType.ID = uuid.New()
+		productType.Active = true
+		productType.CreatedAt = r.now()
+		productType.Categories = []entity.ProductCategory{}
+		t.productTypes[productType.ID] = *productType
+		return nil
+	})
+}
+
+func (r *CatalogueRepo) ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error) {
+	types := make([]entity.ProductTypeInfo, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, productType := range t.productTypes {
+			if !includeInactive && !productType.Active {
+				continue
+			}
+
+			productType.Categories = []entity.ProductCategory{}
+			for _, category := range t.categories {
+				if category.TypeID == productType.ID && (includeInactive || category.Active) {
+					productType.Categories = append(productType.Categories, category)
+				}
+			}
+			slices.SortFunc(productType.Categories, func(a, b entity.ProductCategory) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+			types = append(types, productType)
+		}
+		return nil
+	})
+	slices.SortFunc(types, func(a, b entity.ProductTypeInfo) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+	return types, err
+}
+
+func (r *CatalogueRepo) SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error) {
+	var productType entity.ProductTypeInfo
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if productType, ok = t.productTypes[id]; !ok {
+			return entity.ErrProductTypeNotFound
+		}
+
+		productType.Active = active
+		t.productTypes[id] = productType
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	productType.Categories = []entity.ProductCategory{}
+	return &productType, nil
+}
+
+func (r *CatalogueRepo) CreateCategory(ctx context.Context, category *entity.ProductCategory) error {
+	return r.write(ctx, func(t *tables) error {
+		if _, ok := t.productTypes[category.TypeID]; !ok {
+			return entity.ErrProductTypeNotFound
+		}
+		if _, ok := t.categoryByName(category.TypeID, category.Name); ok {
+			return entity.ErrProductCategoryAlreadyExists
+		}
+
+		category.ID = uuid.New()
+		category.Active = true
+		category.CreatedAt = r.now()
+		t.categories[category.ID] = *category
+		return nil
+	})
+}
+
+func (r *CatalogueRepo) SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error) {
+	var category entity.ProductCategory
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if category, ok = t.categories[id]; !ok || category.TypeID != typeID {
+			return entity.ErrProductCategoryNotFound
+		}
+
+		category.Active = active
+		t.categories[id] = category
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (t *tables) productTypeByName(name entity.ProductType) (entity.ProductTypeInfo, bool) {
+	for _, productType := range t.productTypes {
+		if productType.Name == name {
+			return productType, true
+		}
+	}
+	return entity.ProductTypeInfo{}, false
+}
+
+func (t *tables) categoryByName(typeID uuid.UUID, name string) (entity.ProductCategory, bool) {
+	for _, category := range t.categories {
+		if category.TypeID == typeID && category.Name == name {
+			return category, true
+		}
+	}
+	return entity.ProductCategory{}, false
+}
+
+// checkCatalogueEntry reports whether products of this type and category may
+// be accepted.
+func (t *tables) checkCatalogueEntry(productType entity.ProductType, category string) error {
+	info, ok := t.productTypeByName(productType)
+	if !ok {
+		return entity.ErrInvalidProductType
+	}
+	if !info.Active {
+		return entity.ErrProductTypeInactive
+	}
+	if category == "" {
+		return nil
+	}
+
+	c, ok := t.categoryByName(info.ID, category)
+	if !ok {
+		return entity.ErrProductCategoryNotFound
+	}
+	if !c.Active {
+		return entity.ErrProductCategoryInactive
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type CityRepo struct {
+	*Storage
+}
+
+func NewCityRepo(s *Storage) *CityRepo {
+	return &CityRepo{s}
+}
+
+func (r *CityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	return r.write(ctx, func(t *tables) error {
+		if t.cityExists(city.Name) {
+			return entity.ErrCityAlreadyExists
+		}
+
+		city.ID = uuid.New()
+		city.CreatedAt = r.now()
+		t.cities[city.ID] = *city
+		return nil
+	})
+}
+
+func (r *CityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	var city entity.CityInfo
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		if city, ok = t.cities[id]; !ok {
+			return entity.ErrCityNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &city, nil
+}
+
+func (r *CityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	var cities []entity.CityInfo
+	err := r.read(ctx, func(t *tables) error {
+		cities = make([]entity.CityInfo, 0, len(t.cities))
+		for _, city := range t.cities {
+			cities = append(cities, city)
+		}
+		return nil
+	})
+	slices.SortFunc(cities, func(a, b entity.CityInfo) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+	return cities, err
+}
+
+// Rename cascades to the PVZ of the city, like the foreign key on pvz.city.
+func (r *CityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	var city entity.CityInfo
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if city, ok = t.cities[id]; !ok {
+			return entity.ErrCityNotFound
+		}
+		if city.Name == name {
+			return nil
+		}
+		if t.cityExists(name) {
+			return entity.ErrCityAlreadyExists
+		}
+
+		for pvzID, pvz := range t.pvz {
+			if pvz.city == city.Name {
+				pvz.city = name
+				t.pvz[pvzID] = pvz
+			}
+		}
+		city.Name = name
+		t.cities[id] = city
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &city, nil
+}
+
+func (r *CityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.write(ctx, func(t *tables) error {
+		city, ok := t.cities[id]
+		if !ok {
+			return entity.ErrCityNotFound
+		}
+		for _, pvz := range t.pvz {
+			if pvz.city == city.Name {
+				return entity.ErrCityInUse
+			}
+		}
+
+		delete(t.cities, id)
+		return nil
+	})
+}
+
+func (r *CityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	var exists bool
+	err := r.read(ctx, func(t *tables) error {
+		exists = t.cityExists(name)
+		return nil
+	})
+	return exists, err
+}
+
+func (t *tables) cityExists(name entity.City) bool {
+	for _, city := range t.cities {
+		if city.Name == name {
+			return true
+		}
+	}
+	return false
+}
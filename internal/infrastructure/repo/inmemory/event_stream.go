@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"time"
+)
+
+type listener struct {
+	events chan entity.Event
+	done   chan struct{}
+}
+
+type EventStreamRepo struct {
+	*Storage
+}
+
+func NewEventStreamRepo(s *Storage) *EventStreamRepo {
+	return &EventStreamRepo{s}
+}
+
+// Listen passes each committed event to handle until ctx is done.
+func (r *EventStreamRepo) Listen(ctx context.Context, handle func(entity.Event)) error {
+	l := &listener{
+		events: make(chan entity.Event),
+		done:   make(chan struct{}),
+	}
+
+	r.listenersMu.Lock()
+	r.listeners[l] = struct{}{}
+	r.listenersMu.Unlock()
+
+	defer func() {
+		close(l.done)
+		r.listenersMu.Lock()
+		delete(r.listeners, l)
+		r.listenersMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-l.events:
+			handle(event)
+		}
+	}
+}
+
+func (r *EventStreamRepo) EventsSince(
+	ctx context.Context,
+	filter entity.EventFilter,
+	afterSeq int64,
+	limit int,
+) ([]entity.Event, error) {
+	events := make([]entity.Event, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, row := range t.outbox {
+			if len(events) == limit {
+				break
+			}
+			if row.event.Sequence <= afterSeq {
+				continue
+			}
+			if event := t.streamEvent(row.event); filter.Matches(event) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	return events, err
+}
+
+// ReplayStart returns afterSeq: sequence numbers are assigned under the storage
+// lock, so no event with a lower one can commit later.
+func (r *EventStreamRepo) ReplayStart(_ context.Context, afterSeq int64, _ time.Duration) (int64, error) {
+	return afterSeq, nil
+}
+
+func (t *tables) streamEvent(e entity.Event) entity.Event {
+	if pvz, ok := t.pvz[e.PVZID]; ok {
+		e.City = pvz.city
+	}
+	return e
+}
+
+// broadcast blocks until every listener has taken the events, like a LISTEN
+// connection buffering notifications. Callers hold notifyMu.
+func (s *Storage) broadcast(events []entity.Event) {
+	s.listenersMu.Lock()
+	listeners := make([]*listener, 0, len(s.listeners))
+	for l := range s.listeners {
+		listeners = append(listeners, l)
+	}
+	s.listenersMu.Unlock()
+
+	for _, l := range listeners {
+		for _, event := range events {
+			select {
+			case l.events <- event:
+			case <-l.done:
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"time"
+)
+
+type idempotencyKey struct {
+	scope string
+	key   string
+}
+
+type IdempotencyRepo struct {
+	*Storage
+}
+
+func NewIdempotencyRepo(s *Storage) *IdempotencyRepo {
+	return &IdempotencyRepo{s}
+}
+
+// Acquire claims the key for the caller and returns nil, or returns the record
+// that already holds it. Expired keys and requests abandoned for longer than
+// lockTimeout are taken over.
+func (r *IdempotencyRepo) Acquire(
+	ctx context.Context,
+	scope, key, requestHash string,
+	ttl, lockTimeout time.Duration,
+) (*entity.IdempotencyRecord, error) {
+	var existing *entity.IdempotencyRecord
+	err := r.write(ctx, func(t *tables) error {
+		now := r.now()
+		k := idempotencyKey{scope, key}
+
+		if record, ok := t.idempotencyKey[k]; ok {
+			abandoned := !record.Completed() && !record.CreatedAt.After(now.Add(-lockTimeout))
+			if record.ExpiresAt.After(now) && !abandoned {
+				record.Body = slices.Clone(record.Body)
+				existing = &record
+				return nil
+			}
+		}
+
+		t.idempotencyKey[k] = entity.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+		return nil
+	})
+	return existing, err
+}
+
+func (r *IdempotencyRepo) Complete(
+	ctx context.Context,
+	scope, key string,
+	statusCode int,
+	contentType string,
+	body []byte,
+) error {
+	return r.write(ctx, func(t *tables) error {
+		k := idempotencyKey{scope, key}
+		record, ok := t.idempotencyKey[k]
+		if !ok {
+			return nil
+		}
+
+		record.StatusCode = statusCode
+		record.ContentType = contentType
+		record.Body = slices.Clone(body)
+		t.idempotencyKey[k] = record
+		return nil
+	})
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	return r.write(ctx, func(t *tables) error {
+		k := idempotencyKey{scope, key}
+		if record, ok := t.idempotencyKey[k]; ok && !record.Completed() {
+			delete(t.idempotencyKey, k)
+		}
+		return nil
+	})
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	var deleted int64
+	err := r.write(ctx, func(t *tables) error {
+		now := r.now()
+		for k, record := range t.idempotencyKey {
+			if !record.ExpiresAt.After(now) {
+				delete(t.idempotencyKey, k)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
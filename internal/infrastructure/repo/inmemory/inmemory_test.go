@@ -0,0 +1,32 @@
+package inmemory_test
+
+import (
+	"PVZ-avito-tech/internal/infrastructure/repo/inmemory"
+	"PVZ-avito-tech/internal/infrastructure/repo/repotest"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		s := inmemory.NewStorage()
+		return repotest.Repos{
+			Users:         inmemory.NewUserRepo(s),
+			RefreshTokens: inmemory.NewRefreshTokenRepo(s),
+			RevokedTokens: inmemory.NewRevokedTokenRepo(s),
+			PVZ:           inmemory.NewPVZRepo(s),
+			Assignments:   inmemory.NewPVZAssignmentRepo(s),
+			Receptions:    inmemory.NewReceptionRepo(s),
+			Products:      inmemory.NewProductRepo(s),
+			Cities:        inmemory.NewCityRepo(s),
+			Catalogue:     inmemory.NewCatalogueRepo(s),
+			Returns:       inmemory.NewReturnRepo(s),
+			Outbox:        inmemory.NewOutboxRepo(s),
+			EventStream:   inmemory.NewEventStreamRepo(s),
+			Webhooks:      inmemory.NewWebhookRepo(s),
+			Audit:         inmemory.NewAuditRepo(s),
+			Reports:       inmemory.NewReportRepo(s),
+			Idempotency:   inmemory.NewIdempotencyRepo(s),
+			Tx:            inmemory.NewTxManager(s),
+		}
+	})
+}
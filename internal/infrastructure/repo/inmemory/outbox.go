@@ -0,0 +1,125 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type outboxRow struct {
+	event     entity.Event
+	published bool
+	attempts  int
+	lastError string
+}
+
+type OutboxRepo struct {
+	*Storage
+}
+
+func NewOutboxRepo(s *Storage) *OutboxRepo {
+	return &OutboxRepo{s}
+}
+
+// Relay publishes pending events in commit order. publish runs without the
+// storage lock, so it may use the other repositories.
+func (r *OutboxRepo) Relay(
+	ctx context.Context,
+	limit int,
+	publish func(ctx context.Context, events []entity.Event) error,
+) (int, error) {
+	if !r.relayMu.TryLock() {
+		return 0, nil
+	}
+	defer r.relayMu.Unlock()
+
+	var events []entity.Event
+	_ = r.read(ctx, func(t *tables) error {
+		for _, row := range t.outbox {
+			if len(events) == limit {
+				break
+			}
+			if !row.published {
+				events = append(events, row.event)
+			}
+		}
+		return nil
+	})
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if publishErr := publish(ctx, events); publishErr != nil {
+		_ = r.write(ctx, func(t *tables) error {
+			if i, ok := t.outboxIndex(events[0].Sequence); ok {
+				t.outbox[i].attempts++
+				t.outbox[i].lastError = publishErr.Error()
+			}
+			return nil
+		})
+		return 0, fmt.Errorf("failed to publish events: %w", publishErr)
+	}
+
+	_ = r.write(ctx, func(t *tables) error {
+		for _, e := range events {
+			if i, ok := t.outboxIndex(e.Sequence); ok {
+				row := &t.outbox[i]
+				row.published = true
+				row.attempts++
+				row.lastError = ""
+			}
+		}
+		return nil
+	})
+
+	return len(events), nil
+}
+
+// outboxIndex finds an event by its sequence number; the outbox is ordered by it.
+func (t *tables) outboxIndex(seq int64) (int, bool) {
+	for i, row := range t.outbox {
+		if row.event.Sequence == seq {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) appendEvent(
+	t *tables,
+	eventType entity.EventType,
+	aggregateID uuid.UUID,
+	pvzID uuid.UUID,
+	data any,
+) error {
+	event, err := entity.NewEvent(eventType, aggregateID, pvzID, data)
+	if err != nil {
+		return err
+	}
+	s.appendEvents(t, []entity.Event{event})
+	return nil
+}
+
+func (s *Storage) appendEvents(t *tables, events []entity.Event) {
+	for _, e := range events {
+		s.eventSeq++
+		e.Sequence = s.eventSeq
+		t.outbox = append(t.outbox, outboxRow{event: e})
+	}
+}
+
+// eventsFrom returns the events appended after mark, with the city of their PVZ
+// as the event stream reports it. Callers hold the lock.
+func (s *Storage) eventsFrom(mark int) []entity.Event {
+	if mark >= len(s.t.outbox) {
+		return nil
+	}
+
+	events := make([]entity.Event, 0, len(s.t.outbox)-mark)
+	for _, row := range s.t.outbox[mark:] {
+		events = append(events, s.t.streamEvent(row.event))
+	}
+	return events
+}
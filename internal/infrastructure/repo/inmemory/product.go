@@ -0,0 +1,537 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"cmp"
+	"context"
+	"crypto/subtle"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type productRow struct {
+	product        entity.Product
+	seq            int64
+	deletedAt      *time.Time
+	deletedBy      *uuid.UUID
+	deletionReason string
+}
+
+type ProductRepo struct {
+	*Storage
+}
+
+func NewProductRepo(s *Storage) *ProductRepo {
+	return &ProductRepo{s}
+}
+
+func (r *ProductRepo) AddProduct(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	newProduct *entity.Product,
+) (*entity.Product, error) {
+	var product entity.Product
+	err := r.write(ctx, func(t *tables) error {
+		if err := t.checkCatalogueEntry(newProduct.Type, newProduct.Category); err != nil {
+			return err
+		}
+		reception, ok := t.activeReception(pvzID)
+		if !ok {
+			return entity.ErrNoActiveReception
+		}
+		if newProduct.Barcode != "" && t.barcodeAccepted(newProduct.Barcode) {
+			return entity.ErrBarcodeConflict
+		}
+
+		product = entity.Product{
+			ID:          uuid.New(),
+			DateTime:    r.now(),
+			Type:        newProduct.Type,
+			ReceptionID: reception.ID,
+			Category:    newProduct.Category,
+			Barcode:     newProduct.Barcode,
+			Status:      entity.ReceivedProductStatus,
+			CreatedBy:   newProduct.CreatedBy,
+			PVZID:       pvzID,
+		}
+
+		err := r.appendEvent(t, entity.EventProductAdded, product.ID, pvzID, productAddedData(&product))
+		if err != nil {
+			return err
+		}
+		err = r.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditProductAdded,
+			entityType: entity.AuditEntityProduct,
+			entityID:   product.ID,
+			pvzID:      pvzID,
+			after:      product,
+		})
+		if err != nil {
+			return err
+		}
+
+		r.insertProduct(t, product, newProduct.PickupCodeHash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	product.PickupCode = newProduct.PickupCode
+	return &product, nil
+}
+
+func (r *ProductRepo) AddProducts(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	products []*entity.Product,
+) ([]entity.ProductBatchResult, error) {
+	results := make([]entity.ProductBatchResult, len(products))
+	err := r.write(ctx, func(t *tables) error {
+		reception, ok := t.activeReception(pvzID)
+		if !ok {
+			return entity.ErrNoActiveReception
+		}
+
+		accepted := make(map[string]struct{}, len(products))
+		events := make([]entity.Event, 0, len(products))
+		audit := make([]auditRecord, 0, len(products))
+		now := r.now()
+		for i, newProduct := range products {
+			if err := t.checkCatalogueEntry(newProduct.Type, newProduct.Category); err != nil {
+				results[i].Err = err
+				continue
+			}
+			if newProduct.Barcode != "" {
+				_, taken := accepted[newProduct.Barcode]
+				if taken || t.barcodeAccepted(newProduct.Barcode) {
+					results[i].Err = entity.ErrBarcodeConflict
+					continue
+				}
+				accepted[newProduct.Barcode] = struct{}{}
+			}
+
+			product := &entity.Product{
+				ID:          uuid.New(),
+				DateTime:    now,
+				Type:        newProduct.Type,
+				ReceptionID: reception.ID,
+				Category:    newProduct.Category,
+				Barcode:     newProduct.Barcode,
+				Status:      entity.ReceivedProductStatus,
+				CreatedBy:   newProduct.CreatedBy,
+				PVZID:       pvzID,
+			}
+			event, err := entity.NewEvent(entity.EventProductAdded, product.ID, pvzID, productAddedData(product))
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+			audit = append(audit, auditRecord{
+				action:     entity.AuditProductAdded,
+				entityType: entity.AuditEntityProduct,
+				entityID:   product.ID,
+				pvzID:      pvzID,
+				after:      product,
+			})
+			results[i].Product = product
+		}
+
+		r.appendEvents(t, events)
+		if err := r.appendAudit(ctx, t, audit...); err != nil {
+			return err
+		}
+
+		for i, result := range results {
+			if result.Product == nil {
+				continue
+			}
+			r.insertProduct(t, *result.Product, products[i].PickupCodeHash)
+			result.Product.PickupCode = products[i].PickupCode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// DeleteProductLIFO removes the last product of the active reception together
+// with its status history.
+func (r *ProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
+	return r.write(ctx, func(t *tables) error {
+		reception, ok := t.activeReception(pvzID)
+		if !ok {
+			return entity.ErrNoActiveReception
+		}
+
+		products := t.productsOf(reception.ID)
+		if len(products) == 0 {
+			return entity.ErrNoProducts
+		}
+		last := products[len(products)-1]
+		if last.Status != entity.ReceivedProductStatus {
+			return entity.ErrInvalidProductStatusTransition
+		}
+
+		err := r.appendEvent(t, entity.EventProductDeleted, last.ID, pvzID, entity.ProductDeletedData{
+			ID:          last.ID,
+			ReceptionID: reception.ID,
+			PVZID:       pvzID,
+		})
+		if err != nil {
+			return err
+		}
+		err = r.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditProductDeleted,
+			entityType: entity.AuditEntityProduct,
+			entityID:   last.ID,
+			pvzID:      pvzID,
+			before:     last,
+		})
+		if err != nil {
+			return err
+		}
+
+		delete(t.products, last.ID)
+		t.productLog = slices.DeleteFunc(t.productLog, func(change entity.ProductStatusChange) bool {
+			return change.ProductID == last.ID
+		})
+		return nil
+	})
+}
+
+func (r *ProductRepo) DeleteProduct(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	productID uuid.UUID,
+	reason string,
+	deletedBy *uuid.UUID,
+) (*entity.ProductDeletion, error) {
+	deletion := entity.ProductDeletion{ProductID: productID, Reason: reason}
+	err := r.write(ctx, func(t *tables) error {
+		row, ok := t.products[productID]
+		if !ok || row.deletedAt != nil || row.product.PVZID != pvzID {
+			return entity.ErrProductNotFound
+		}
+		if t.receptions[row.product.ReceptionID].Status != entity.InProgressStatus {
+			return entity.ErrReceptionNotInProgress
+		}
+		if row.product.Status != entity.ReceivedProductStatus {
+			return entity.ErrInvalidProductStatusTransition
+		}
+
+		deletion.ReceptionID = row.product.ReceptionID
+		deletion.DeletedAt = r.now()
+		deletion.DeletedBy = deletedBy
+
+		err := r.appendEvent(t, entity.EventProductDeleted, productID, pvzID, entity.ProductDeletedData{
+			ID:          productID,
+			ReceptionID: deletion.ReceptionID,
+			PVZID:       pvzID,
+			Reason:      reason,
+			DeletedBy:   deletedBy,
+		})
+		if err != nil {
+			return err
+		}
+		err = r.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditProductDeleted,
+			entityType: entity.AuditEntityProduct,
+			entityID:   productID,
+			pvzID:      pvzID,
+			before:     row.product,
+			after:      deletion,
+		})
+		if err != nil {
+			return err
+		}
+
+		t.softDeleteProduct(row, deletion.DeletedAt, deletedBy, reason)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (r *ProductRepo) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	var products []entity.Product
+	err := r.read(ctx, func(t *tables) error {
+		products = t.productsOf(receptionID)
+		return nil
+	})
+	for i := range products {
+		products[i].PVZID = uuid.Nil
+		products[i].PickupCodeHash = ""
+	}
+	return products, err
+}
+
+func (r *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	var product entity.Product
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		product, ok = t.liveProduct(id)
+		if !ok {
+			return entity.ErrProductNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *ProductRepo) IssueProduct(
+	ctx context.Context,
+	id uuid.UUID,
+	pickupCodeHash string,
+	maxAttempts int,
+	issuedBy *uuid.UUID,
+) (*entity.Product, error) {
+	var product entity.Product
+	var mismatch bool
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		product, ok = t.liveProduct(id)
+		if !ok {
+			return entity.ErrProductNotFound
+		}
+
+		switch {
+		case product.PickupCodeHash == "":
+			return entity.ErrPickupCodeNotSet
+		case product.PickupCodeAttempts >= maxAttempts:
+			return entity.ErrPickupCodeAttemptsExceeded
+		case subtle.ConstantTimeCompare([]byte(product.PickupCodeHash), []byte(pickupCodeHash)) != 1:
+			// The attempt is counted even though the call fails, as the
+			// Postgres repository commits it before returning the error.
+			row := t.products[id]
+			row.product.PickupCodeAttempts++
+			t.products[id] = row
+			mismatch = true
+			return nil
+		}
+
+		return r.changeProductStatus(ctx, t, &product, entity.IssuedProductStatus, issuedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if mismatch {
+		return nil, entity.ErrInvalidPickupCode
+	}
+	return &product, nil
+}
+
+func (r *ProductRepo) IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error) {
+	var product entity.Product
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		product, ok = t.liveProduct(id)
+		if !ok {
+			return entity.ErrProductNotFound
+		}
+
+		return r.changeProductStatus(ctx, t, &product, entity.IssuedProductStatus, issuedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *ProductRepo) ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	history := make([]entity.ProductStatusChange, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, change := range t.productLog {
+			if change.ProductID == productID {
+				history = append(history, change)
+			}
+		}
+		return nil
+	})
+	return history, err
+}
+
+// FindByBarcode prefers the product currently held at a PVZ over issued or
+// returned ones, then the most recent.
+func (r *ProductRepo) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	var location entity.ProductLocation
+	err := r.read(ctx, func(t *tables) error {
+		var (
+			found *entity.Product
+			held  bool
+		)
+		for _, row := range t.products {
+			p := row.product
+			if row.deletedAt != nil || p.Barcode != barcode {
+				continue
+			}
+			isHeld := isHeldStatus(p.Status)
+			if found == nil || isHeld && !held || isHeld == held && p.DateTime.After(found.DateTime) {
+				found, held = &p, isHeld
+			}
+		}
+		if found == nil {
+			return entity.ErrProductNotFound
+		}
+
+		reception := t.receptions[found.ReceptionID]
+		location.Product = *found
+		location.Product.PickupCodeHash = ""
+		location.Reception = entity.Reception{
+			ID:        reception.ID,
+			DateTime:  reception.DateTime,
+			PVZID:     reception.PVZID,
+			Status:    reception.Status,
+			CreatedBy: reception.CreatedBy,
+		}
+		location.PVZ = *t.pvz[reception.PVZID].entity()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func isHeldStatus(status entity.ProductStatus) bool {
+	return status == entity.ReceivedProductStatus || status == entity.StoredProductStatus
+}
+
+// barcodeAccepted reports whether a product with the barcode is currently held
+// at a PVZ, which keeps barcodes unique among held products.
+func (t *tables) barcodeAccepted(barcode string) bool {
+	for _, row := range t.products {
+		if row.deletedAt == nil && row.product.Barcode == barcode && isHeldStatus(row.product.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) insertProduct(t *tables, product entity.Product, pickupCodeHash string) {
+	product.PickupCode = ""
+	product.PickupCodeHash = pickupCodeHash
+	s.productSeq++
+	t.products[product.ID] = productRow{product: product, seq: s.productSeq}
+	s.recordProductStatus(t, product.ID, nil, product.Status, product.CreatedBy)
+}
+
+func (t *tables) softDeleteProduct(row productRow, at time.Time, by *uuid.UUID, reason string) {
+	row.deletedAt = &at
+	row.deletedBy = by
+	row.deletionReason = reason
+	t.products[row.product.ID] = row
+}
+
+// liveProduct returns a product that has not been deleted.
+func (t *tables) liveProduct(id uuid.UUID) (entity.Product, bool) {
+	row, ok := t.products[id]
+	if !ok || row.deletedAt != nil {
+		return entity.Product{}, false
+	}
+	return row.product, true
+}
+
+// productsOf returns the live products of a reception in acceptance order.
+func (t *tables) productsOf(receptionID uuid.UUID) []entity.Product {
+	rows := make([]productRow, 0)
+	for _, row := range t.products {
+		if row.deletedAt == nil && row.product.ReceptionID == receptionID {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortFunc(rows, func(a, b productRow) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	products := make([]entity.Product, len(rows))
+	for i, row := range rows {
+		products[i] = row.product
+	}
+	return products
+}
+
+func (s *Storage) changeProductStatus(
+	ctx context.Context,
+	t *tables,
+	product *entity.Product,
+	next entity.ProductStatus,
+	changedBy *uuid.UUID,
+) error {
+	if !product.Status.CanTransitionTo(next) {
+		return entity.ErrInvalidProductStatusTransition
+	}
+	before := *product
+	prev := product.Status
+
+	err := s.appendEvent(t, entity.EventProductStatusChanged, product.ID, product.PVZID, entity.ProductStatusChangedData{
+		ID:          product.ID,
+		ReceptionID: product.ReceptionID,
+		PVZID:       product.PVZID,
+		From:        prev,
+		To:          next,
+		ChangedBy:   changedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	product.Status = next
+	err = s.appendAudit(ctx, t, auditRecord{
+		action:     entity.AuditProductStatusChanged,
+		entityType: entity.AuditEntityProduct,
+		entityID:   product.ID,
+		pvzID:      product.PVZID,
+		before:     before,
+		after:      *product,
+	})
+	if err != nil {
+		product.Status = prev
+		return err
+	}
+
+	row := t.products[product.ID]
+	row.product.Status = next
+	t.products[product.ID] = row
+	s.recordProductStatus(t, product.ID, &prev, next, changedBy)
+	return nil
+}
+
+func (s *Storage) recordProductStatus(
+	t *tables,
+	productID uuid.UUID,
+	from *entity.ProductStatus,
+	to entity.ProductStatus,
+	changedBy *uuid.UUID,
+) {
+	t.productLog = append(t.productLog, entity.ProductStatusChange{
+		ID:        uuid.New(),
+		ProductID: productID,
+		From:      from,
+		To:        to,
+		ChangedAt: s.now(),
+		ChangedBy: changedBy,
+	})
+}
+
+func productAddedData(product *entity.Product) entity.ProductAddedData {
+	return entity.ProductAddedData{
+		ID:          product.ID,
+		ReceptionID: product.ReceptionID,
+		PVZID:       product.PVZID,
+		Type:        product.Type,
+		Category:    product.Category,
+		Barcode:     product.Barcode,
+		DateTime:    product.DateTime,
+		CreatedBy:   product.CreatedBy,
+	}
+}
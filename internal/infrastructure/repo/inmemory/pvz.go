@@ -0,0 +1,267 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type pvzRow struct {
+	id        uuid.UUID
+	city      entity.City
+	createdAt time.Time
+}
+
+func (p pvzRow) entity() *entity.PVZ {
+	id, created := p.id, p.createdAt
+	return &entity.PVZ{ID: &id, City: p.city, RegistrationDate: &created}
+}
+
+type PVZRepo struct {
+	*Storage
+}
+
+func NewPVZRepo(s *Storage) *PVZRepo {
+	return &PVZRepo{s}
+}
+
+func (r *PVZRepo) Create(ctx context.Context, pvz *entity.PVZ) error {
+	return r.write(ctx, func(t *tables) error {
+		row := pvzRow{id: uuid.New(), city: pvz.City}
+		if pvz.ID != nil && *pvz.ID != uuid.Nil {
+			row.id = *pvz.ID
+		}
+		if pvz.RegistrationDate != nil && !pvz.RegistrationDate.IsZero() {
+			row.createdAt = *pvz.RegistrationDate
+		} else {
+			row.createdAt = r.now()
+		}
+
+		if !t.cityExists(pvz.City) {
+			return entity.ErrInvalidCity
+		}
+		if _, taken := t.pvz[row.id]; taken {
+			return entity.ErrCreatePVZ
+		}
+
+		created := row.entity()
+		err := r.appendEvent(t, entity.EventPVZCreated, row.id, row.id, entity.PVZCreatedData{
+			ID:               row.id,
+			City:             row.city,
+			RegistrationDate: row.createdAt,
+		})
+		if err != nil {
+			return err
+		}
+		err = r.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditPVZCreated,
+			entityType: entity.AuditEntityPVZ,
+			entityID:   row.id,
+			pvzID:      row.id,
+			after:      created,
+		})
+		if err != nil {
+			return err
+		}
+
+		t.pvz[row.id] = row
+		pvz.ID = created.ID
+		pvz.RegistrationDate = created.RegistrationDate
+		return nil
+	})
+}
+
+func (r *PVZRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.PVZ, error) {
+	var pvz *entity.PVZ
+	err := r.read(ctx, func(t *tables) error {
+		row, ok := t.pvz[id]
+		if !ok {
+			return entity.ErrPVZNotFound
+		}
+		pvz = row.entity()
+		return nil
+	})
+	return pvz, err
+}
+
+func (r *PVZRepo) GetPVZWithReceptions(
+	ctx context.Context,
+	filter dto.ReceptionFilter,
+) (*[]dto.PVZInfo, error) {
+	result := make([]dto.PVZInfo, 0, filter.Limit)
+	err := r.read(ctx, func(t *tables) error {
+		type candidate struct {
+			pvz          pvzRow
+			lastActivity time.Time
+			sortKey      time.Time
+		}
+
+		candidates := make([]candidate, 0)
+		for _, pvz := range t.pvz {
+			if !t.pvzMatches(pvz, filter) {
+				continue
+			}
+
+			c := candidate{pvz: pvz, lastActivity: t.lastActivity(pvz), sortKey: pvz.createdAt}
+			if filter.Sort == dto.PVZSortLastActivity {
+				c.sortKey = c.lastActivity
+			}
+			if filter.CursorMode && filter.After != nil {
+				if c.sortKey.After(filter.After.CreatedAt) ||
+					c.sortKey.Equal(filter.After.CreatedAt) && compareUUID(pvz.id, filter.After.ID) >= 0 {
+					continue
+				}
+			}
+			candidates = append(candidates, c)
+		}
+
+		slices.SortFunc(candidates, func(a, b candidate) int {
+			if c := b.sortKey.Compare(a.sortKey); c != 0 {
+				return c
+			}
+			return compareUUID(b.pvz.id, a.pvz.id)
+		})
+
+		if !filter.CursorMode {
+			offset := min((filter.Page-1)*filter.Limit, len(candidates))
+			candidates = candidates[offset:]
+		}
+		candidates = candidates[:min(filter.Limit, len(candidates))]
+
+		for _, c := range candidates {
+			result = append(result, dto.PVZInfo{
+				PVZ: dto.PVZWithReceptions{
+					ID:               c.pvz.id,
+					City:             c.pvz.city,
+					RegistrationDate: c.pvz.createdAt,
+					LastActivity:     c.lastActivity,
+				},
+				Receptions: t.receptionGroups(c.pvz.id, filter),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (t *tables) pvzMatches(pvz pvzRow, filter dto.ReceptionFilter) bool {
+	if len(filter.Cities) > 0 && !slices.Contains(filter.Cities, pvz.city) {
+		return false
+	}
+
+	var (
+		hasActive      bool
+		hasProductType bool
+		hasInPeriod    bool
+	)
+	for _, reception := range t.receptions {
+		if reception.PVZID != pvz.id {
+			continue
+		}
+		if reception.Status == entity.InProgressStatus {
+			hasActive = true
+		}
+		if receptionInPeriod(reception, filter) {
+			hasInPeriod = true
+		}
+		if filter.ProductType != "" && !hasProductType {
+			for _, p := range t.productsOf(reception.ID) {
+				if p.Type == filter.ProductType {
+					hasProductType = true
+					break
+				}
+			}
+		}
+	}
+
+	if filter.HasActiveReception != nil && *filter.HasActiveReception != hasActive {
+		return false
+	}
+	if filter.ProductType != "" && !hasProductType {
+		return false
+	}
+	if (!filter.StartDate.IsZero() || !filter.EndDate.IsZero()) && !hasInPeriod {
+		return false
+	}
+	return true
+}
+
+func receptionInPeriod(reception entity.Reception, filter dto.ReceptionFilter) bool {
+	if !filter.StartDate.IsZero() && reception.DateTime.Before(filter.StartDate) {
+		return false
+	}
+	if !filter.EndDate.IsZero() && reception.DateTime.After(filter.EndDate) {
+		return false
+	}
+	return true
+}
+
+func (t *tables) lastActivity(pvz pvzRow) time.Time {
+	last := pvz.createdAt
+	for _, reception := range t.receptions {
+		if reception.PVZID != pvz.id {
+			continue
+		}
+		if reception.DateTime.After(last) {
+			last = reception.DateTime
+		}
+		for _, p := range t.productsOf(reception.ID) {
+			if p.DateTime.After(last) {
+				last = p.DateTime
+			}
+		}
+	}
+	return last
+}
+
+// receptionGroups lists the receptions of the PVZ in the filter period with
+// their products, newest first.
+func (t *tables) receptionGroups(pvzID uuid.UUID, filter dto.ReceptionFilter) []*dto.ReceptionGroup {
+	receptions := make([]entity.Reception, 0)
+	for _, reception := range t.receptions {
+		if reception.PVZID == pvzID && receptionInPeriod(reception, filter) {
+			receptions = append(receptions, reception)
+		}
+	}
+	slices.SortFunc(receptions, func(a, b entity.Reception) int {
+		return b.DateTime.Compare(a.DateTime)
+	})
+
+	groups := make([]*dto.ReceptionGroup, 0, len(receptions))
+	for _, reception := range receptions {
+		group := &dto.ReceptionGroup{
+			Reception: dto.ReceptionWithProducts{
+				ID:        reception.ID,
+				DateTime:  reception.DateTime,
+				PVZID:     reception.PVZID,
+				Status:    reception.Status,
+				CreatedBy: reception.CreatedBy,
+			},
+			Products: []dto.ProductDTO{},
+		}
+
+		products := t.productsOf(reception.ID)
+		for i := len(products) - 1; i >= 0; i-- {
+			p := products[i]
+			group.Products = append(group.Products, dto.ProductDTO{
+				ID:          p.ID,
+				DateTime:    p.DateTime,
+				Type:        p.Type,
+				ReceptionID: p.ReceptionID,
+				Category:    p.Category,
+				Status:      p.Status,
+				Barcode:     p.Barcode,
+				CreatedBy:   p.CreatedBy,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
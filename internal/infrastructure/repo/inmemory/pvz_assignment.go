@@ -0,0 +1,82 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type assignmentKey struct {
+	userID uuid.UUID
+	pvzID  uuid.UUID
+}
+
+type PVZAssignmentRepo struct {
+	*Storage
+}
+
+func NewPVZAssignmentRepo(s *Storage) *PVZAssignmentRepo {
+	return &PVZAssignmentRepo{s}
+}
+
+// Assign keeps the original assignment when the employee is already assigned.
+func (r *PVZAssignmentRepo) Assign(ctx context.Context, a *entity.PVZAssignment) error {
+	return r.write(ctx, func(t *tables) error {
+		user, ok := t.users[a.UserID]
+		if !ok || user.Role != entity.UserRoleEmployee {
+			return entity.ErrEmployeeNotFound
+		}
+		if _, ok := t.pvz[a.PVZID]; !ok {
+			return entity.ErrPVZNotFound
+		}
+
+		key := assignmentKey{a.UserID, a.PVZID}
+		if existing, ok := t.assignments[key]; ok {
+			*a = existing
+			return nil
+		}
+
+		a.AssignedAt = r.now()
+		t.assignments[key] = *a
+		return nil
+	})
+}
+
+func (r *PVZAssignmentRepo) Unassign(ctx context.Context, userID, pvzID uuid.UUID) error {
+	return r.write(ctx, func(t *tables) error {
+		key := assignmentKey{userID, pvzID}
+		if _, ok := t.assignments[key]; !ok {
+			return entity.ErrAssignmentNotFound
+		}
+
+		delete(t.assignments, key)
+		return nil
+	})
+}
+
+func (r *PVZAssignmentRepo) IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	var assigned bool
+	err := r.read(ctx, func(t *tables) error {
+		_, assigned = t.assignments[assignmentKey{userID, pvzID}]
+		return nil
+	})
+	return assigned, err
+}
+
+func (r *PVZAssignmentRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	assignments := make([]entity.PVZAssignment, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for key, a := range t.assignments {
+			if key.pvzID == pvzID {
+				assignments = append(assignments, a)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(assignments, func(a, b entity.PVZAssignment) int {
+		return a.AssignedAt.Compare(b.AssignedAt)
+	})
+	return assignments, err
+}
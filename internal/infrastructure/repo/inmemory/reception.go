@@ -0,0 +1,509 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReceptionRepo struct {
+	*Storage
+}
+
+func NewReceptionRepo(s *Storage) *ReceptionRepo {
+	return &ReceptionRepo{s}
+}
+
+func (r *ReceptionRepo) CreateReception(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	createdBy *uuid.UUID,
+	manifest []entity.ManifestItem,
+) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.write(ctx, func(t *tables) error {
+		if _, ok := t.pvz[pvzID]; !ok {
+			return entity.ErrPVZNotFound
+		}
+		if _, ok := t.activeReception(pvzID); ok {
+			return entity.ErrReceptionConflict
+		}
+
+		reception = entity.Reception{
+			ID:        uuid.New(),
+			DateTime:  r.now(),
+			PVZID:     pvzID,
+			Status:    entity.InProgressStatus,
+			CreatedBy: createdBy,
+		}
+		if len(manifest) > 0 {
+			reception.Manifest = slices.Clone(manifest)
+		}
+
+		err := r.appendEvent(t, entity.EventReceptionCreated, reception.ID, pvzID, entity.ReceptionCreatedData{
+			ID:            reception.ID,
+			PVZID:         pvzID,
+			Status:        reception.Status,
+			DateTime:      reception.DateTime,
+			CreatedBy:     createdBy,
+			ManifestItems: len(manifest),
+		})
+		if err != nil {
+			return err
+		}
+		err = r.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditReceptionCreated,
+			entityType: entity.AuditEntityReception,
+			entityID:   reception.ID,
+			pvzID:      pvzID,
+			after:      reception,
+		})
+		if err != nil {
+			return err
+		}
+
+		stored := reception
+		stored.Manifest = nil
+		t.receptions[reception.ID] = stored
+		if len(manifest) > 0 {
+			t.manifests[reception.ID] = reception.Manifest
+		}
+		r.recordReceptionStatus(t, reception.ID, nil, entity.InProgressStatus, "", createdBy)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID, closedBy *uuid.UUID) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if reception, ok = t.activeReception(pvzID); !ok {
+			return entity.ErrNoActiveReception
+		}
+		return r.closeReception(ctx, t, &reception, "", closedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		if reception, ok = t.receptions[id]; !ok {
+			return entity.ErrReceptionNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) GetActiveByPVZ(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		if reception, ok = t.activeReception(pvzID); !ok {
+			return entity.ErrNoActiveReception
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) ReopenReception(
+	ctx context.Context,
+	id uuid.UUID,
+	window time.Duration,
+	reopenedBy *uuid.UUID,
+) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if reception, ok = t.receptions[id]; !ok {
+			return entity.ErrReceptionNotFound
+		}
+
+		if !reception.Status.CanTransitionTo(entity.InProgressStatus) {
+			return entity.ErrInvalidReceptionStatusTransition
+		}
+		if !reception.CanReopen(time.Now(), window) {
+			return entity.ErrReceptionReopenWindowExpired
+		}
+		if _, ok := t.activeReception(reception.PVZID); ok {
+			return entity.ErrReceptionConflict
+		}
+		if !t.productsInStorage(id) {
+			return entity.ErrReceptionProductsLeftStorage
+		}
+
+		if err := r.changeReceptionStatus(ctx, t, &reception, entity.InProgressStatus, "", reopenedBy); err != nil {
+			return err
+		}
+		return r.moveReceptionProducts(ctx, t, id, entity.StoredProductStatus, entity.ReceivedProductStatus, reopenedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+// CancelReception voids the products of the reception along with it.
+func (r *ReceptionRepo) CancelReception(
+	ctx context.Context,
+	id uuid.UUID,
+	reason string,
+	cancelledBy *uuid.UUID,
+) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if reception, ok = t.receptions[id]; !ok {
+			return entity.ErrReceptionNotFound
+		}
+
+		if !reception.Status.CanTransitionTo(entity.CancelledStatus) {
+			return entity.ErrInvalidReceptionStatusTransition
+		}
+		if !t.productsInStorage(id) {
+			return entity.ErrReceptionProductsLeftStorage
+		}
+		if err := r.changeReceptionStatus(ctx, t, &reception, entity.CancelledStatus, reason, cancelledBy); err != nil {
+			return err
+		}
+
+		now := r.now()
+		for _, product := range t.productsOf(id) {
+			t.softDeleteProduct(t.products[product.ID], now, cancelledBy, reason)
+
+			err := r.appendEvent(t, entity.EventProductDeleted, product.ID, reception.PVZID, entity.ProductDeletedData{
+				ID:          product.ID,
+				ReceptionID: id,
+				PVZID:       reception.PVZID,
+				Reason:      reason,
+				DeletedBy:   cancelledBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = r.appendAudit(ctx, t, auditRecord{
+				action:     entity.AuditProductDeleted,
+				entityType: entity.AuditEntityProduct,
+				entityID:   product.ID,
+				pvzID:      reception.PVZID,
+				before:     product,
+				after: entity.ProductDeletion{
+					ProductID:   product.ID,
+					ReceptionID: id,
+					Reason:      reason,
+					DeletedAt:   now,
+					DeletedBy:   cancelledBy,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func (r *ReceptionRepo) ListStatusHistory(ctx context.Context, receptionID uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	history := make([]entity.ReceptionStatusChange, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, change := range t.receptionLog {
+			if change.ReceptionID == receptionID {
+				history = append(history, change)
+			}
+		}
+		return nil
+	})
+	return history, err
+}
+
+// CloseStaleReceptions closes receptions without any activity for idleFor,
+// oldest first.
+func (r *ReceptionRepo) CloseStaleReceptions(
+	ctx context.Context,
+	idleFor time.Duration,
+	limit int,
+	reason string,
+) ([]entity.Reception, error) {
+	closed := make([]entity.Reception, 0)
+	err := r.write(ctx, func(t *tables) error {
+		deadline := time.Now().Add(-idleFor)
+
+		stale := make([]entity.Reception, 0)
+		for _, reception := range t.receptions {
+			if reception.Status != entity.InProgressStatus {
+				continue
+			}
+
+			lastActivity := t.openedAt(reception)
+			for _, row := range t.products {
+				if row.product.ReceptionID == reception.ID && row.product.DateTime.After(lastActivity) {
+					lastActivity = row.product.DateTime
+				}
+			}
+			if lastActivity.Before(deadline) {
+				stale = append(stale, reception)
+			}
+		}
+		slices.SortFunc(stale, func(a, b entity.Reception) int {
+			return a.DateTime.Compare(b.DateTime)
+		})
+
+		for _, reception := range stale[:min(limit, len(stale))] {
+			if err := r.closeReception(ctx, t, &reception, reason, nil); err != nil {
+				return err
+			}
+			closed = append(closed, reception)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+func (r *ReceptionRepo) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	var stats entity.OpenReceptionStats
+	err := r.read(ctx, func(t *tables) error {
+		for _, reception := range t.receptions {
+			if reception.Status != entity.InProgressStatus {
+				continue
+			}
+
+			stats.Count++
+			openedAt := t.openedAt(reception)
+			if stats.OldestOpenedAt == nil || openedAt.Before(*stats.OldestOpenedAt) {
+				stats.OldestOpenedAt = &openedAt
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *ReceptionRepo) GetDiscrepancyReport(ctx context.Context, receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	var report entity.DiscrepancyReport
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		if report, ok = t.reports[receptionID]; !ok {
+			return entity.ErrDiscrepancyReportNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (t *tables) activeReception(pvzID uuid.UUID) (entity.Reception, bool) {
+	for _, reception := range t.receptions {
+		if reception.PVZID == pvzID && reception.Status == entity.InProgressStatus {
+			return reception, true
+		}
+	}
+	return entity.Reception{}, false
+}
+
+// openedAt is when the reception was last (re)opened.
+func (t *tables) openedAt(reception entity.Reception) time.Time {
+	openedAt := reception.DateTime
+	for _, change := range t.receptionLog {
+		if change.ReceptionID == reception.ID && change.To == entity.InProgressStatus && change.ChangedAt.After(openedAt) {
+			openedAt = change.ChangedAt
+		}
+	}
+	return openedAt
+}
+
+// closeReception moves received products to storage and, when the reception
+// has a manifest, stores the discrepancy report.
+func (s *Storage) closeReception(
+	ctx context.Context,
+	t *tables,
+	reception *entity.Reception,
+	reason string,
+	closedBy *uuid.UUID,
+) error {
+	if err := s.changeReceptionStatus(ctx, t, reception, entity.CloseStatus, reason, closedBy); err != nil {
+		return err
+	}
+
+	err := s.moveReceptionProducts(ctx, t, reception.ID, entity.ReceivedProductStatus, entity.StoredProductStatus, closedBy)
+	if err != nil {
+		return err
+	}
+
+	manifest := t.manifests[reception.ID]
+	if len(manifest) == 0 {
+		return nil
+	}
+	report := entity.BuildDiscrepancyReport(reception.ID, manifest, t.productsOf(reception.ID))
+	report.CreatedAt = s.now()
+	t.reports[reception.ID] = *report
+	reception.DiscrepancyReport = report
+	return nil
+}
+
+// moveReceptionProducts moves the live products of a reception from one status
+// to another, recording the history, a status change event and an audit entry
+// per product.
+func (s *Storage) moveReceptionProducts(
+	ctx context.Context,
+	t *tables,
+	receptionID uuid.UUID,
+	from, to entity.ProductStatus,
+	changedBy *uuid.UUID,
+) error {
+	for _, product := range t.productsOf(receptionID) {
+		if product.Status != from {
+			continue
+		}
+		row := t.products[product.ID]
+		row.product.Status = to
+		t.products[product.ID] = row
+
+		prev := from
+		s.recordProductStatus(t, product.ID, &prev, to, changedBy)
+
+		err := s.appendEvent(t, entity.EventProductStatusChanged, product.ID, product.PVZID, entity.ProductStatusChangedData{
+			ID:          product.ID,
+			ReceptionID: receptionID,
+			PVZID:       product.PVZID,
+			From:        from,
+			To:          to,
+			ChangedBy:   changedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.appendAudit(ctx, t, auditRecord{
+			action:     entity.AuditProductStatusChanged,
+			entityType: entity.AuditEntityProduct,
+			entityID:   product.ID,
+			pvzID:      product.PVZID,
+			before:     product,
+			after:      row.product,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productsInStorage reports whether no live product of the reception has been
+// issued or returned yet.
+func (t *tables) productsInStorage(receptionID uuid.UUID) bool {
+	for _, product := range t.productsOf(receptionID) {
+		if product.Status != entity.ReceivedProductStatus && product.Status != entity.StoredProductStatus {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Storage) changeReceptionStatus(
+	ctx context.Context,
+	t *tables,
+	reception *entity.Reception,
+	next entity.ReceptionsStatus,
+	reason string,
+	changedBy *uuid.UUID,
+) error {
+	if !reception.Status.CanTransitionTo(next) {
+		return entity.ErrInvalidReceptionStatusTransition
+	}
+	before := *reception
+	prev := reception.Status
+
+	err := s.appendEvent(t, entity.ReceptionStatusEvent(next), reception.ID, reception.PVZID, entity.ReceptionStatusChangedData{
+		ID:        reception.ID,
+		PVZID:     reception.PVZID,
+		From:      prev,
+		To:        next,
+		Reason:    reason,
+		ChangedBy: changedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	updated := *reception
+	updated.Status = next
+	switch next {
+	case entity.CloseStatus:
+		closedAt := s.now()
+		updated.ClosedAt = &closedAt
+	case entity.InProgressStatus:
+		updated.ClosedAt = nil
+	case entity.CancelledStatus:
+		updated.CancellationReason = reason
+	}
+
+	err = s.appendAudit(ctx, t, auditRecord{
+		action:     entity.ReceptionStatusAuditAction(next),
+		entityType: entity.AuditEntityReception,
+		entityID:   reception.ID,
+		pvzID:      reception.PVZID,
+		before:     before,
+		after:      updated,
+	})
+	if err != nil {
+		return err
+	}
+
+	*reception = updated
+	stored := updated
+	stored.Manifest, stored.DiscrepancyReport = nil, nil
+	t.receptions[reception.ID] = stored
+	s.recordReceptionStatus(t, reception.ID, &prev, next, reason, changedBy)
+	return nil
+}
+
+func (s *Storage) recordReceptionStatus(
+	t *tables,
+	receptionID uuid.UUID,
+	from *entity.ReceptionsStatus,
+	to entity.ReceptionsStatus,
+	reason string,
+	changedBy *uuid.UUID,
+) {
+	t.receptionLog = append(t.receptionLog, entity.ReceptionStatusChange{
+		ID:          uuid.New(),
+		ReceptionID: receptionID,
+		From:        from,
+		To:          to,
+		Reason:      reason,
+		ChangedAt:   s.now(),
+		ChangedBy:   changedBy,
+	})
+}
@@ -0,0 +1,78 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ReportRepo struct {
+	*Storage
+}
+
+func NewReportRepo(s *Storage) *ReportRepo {
+	return &ReportRepo{s}
+}
+
+func (r *ReportRepo) AcceptanceReport(
+	ctx context.Context,
+	filter entity.AcceptanceReportFilter,
+) ([]entity.AcceptanceReportRow, error) {
+	type groupKey struct {
+		pvzID       uuid.UUID
+		day         string
+		productType entity.ProductType
+	}
+
+	report := make([]entity.AcceptanceReportRow, 0)
+	err := r.read(ctx, func(t *tables) error {
+		groups := make(map[groupKey]*entity.AcceptanceReportRow)
+		for _, row := range t.products {
+			p := row.product
+			if row.deletedAt != nil || p.DateTime.Before(filter.From) || !p.DateTime.Before(filter.To) {
+				continue
+			}
+
+			reception := t.receptions[p.ReceptionID]
+			if reception.Status != entity.CloseStatus {
+				continue
+			}
+
+			pvzID := reception.PVZID
+			city := t.pvz[pvzID].city
+			if filter.PVZID != nil && *filter.PVZID != pvzID ||
+				len(filter.Cities) > 0 && !slices.Contains(filter.Cities, city) {
+				continue
+			}
+
+			key := groupKey{pvzID, p.DateTime.UTC().Format(entity.ReportDayLayout), p.Type}
+			g, ok := groups[key]
+			if !ok {
+				g = &entity.AcceptanceReportRow{PVZID: pvzID, City: city, Day: key.day, ProductType: p.Type}
+				groups[key] = g
+			}
+			g.Accepted++
+		}
+
+		for _, g := range groups {
+			report = append(report, *g)
+		}
+		return nil
+	})
+	slices.SortFunc(report, func(a, b entity.AcceptanceReportRow) int {
+		if c := strings.Compare(string(a.City), string(b.City)); c != 0 {
+			return c
+		}
+		if c := compareUUID(a.PVZID, b.PVZID); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Day, b.Day); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.ProductType), string(b.ProductType))
+	})
+	return report, err
+}
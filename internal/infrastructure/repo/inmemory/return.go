@@ -0,0 +1,189 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnRepo struct {
+	*Storage
+}
+
+func NewReturnRepo(s *Storage) *ReturnRepo {
+	return &ReturnRepo{s}
+}
+
+func (r *ReturnRepo) CreateReturn(ctx context.Context, ret *entity.Return) (*entity.Return, error) {
+	var created entity.Return
+	err := r.write(ctx, func(t *tables) error {
+		product, ok := t.liveProduct(ret.ProductID)
+		if !ok || product.PVZID != ret.PVZID {
+			return entity.ErrProductNotFound
+		}
+		for _, existing := range t.returns {
+			if existing.ProductID == ret.ProductID {
+				return entity.ErrInvalidProductStatusTransition
+			}
+		}
+
+		if err := r.changeProductStatus(ctx, t, &product, entity.ReturnedProductStatus, ret.CreatedBy); err != nil {
+			return err
+		}
+
+		created = entity.Return{
+			ID:        uuid.New(),
+			ProductID: ret.ProductID,
+			PVZID:     ret.PVZID,
+			Reason:    ret.Reason,
+			DateTime:  r.now(),
+			CreatedBy: ret.CreatedBy,
+		}
+		t.returns[created.ID] = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *ReturnRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error) {
+	returns := make([]entity.Return, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, ret := range t.returns {
+			if ret.PVZID != pvzID || outstandingOnly && t.returnShipped(ret) {
+				continue
+			}
+			returns = append(returns, ret)
+		}
+		return nil
+	})
+	slices.SortFunc(returns, func(a, b entity.Return) int {
+		return a.DateTime.Compare(b.DateTime)
+	})
+	return returns, err
+}
+
+func (r *ReturnRepo) OpenShipment(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID) (*entity.ReturnShipment, error) {
+	var shipment entity.ReturnShipment
+	err := r.write(ctx, func(t *tables) error {
+		if _, ok := t.pvz[pvzID]; !ok {
+			return entity.ErrPVZNotFound
+		}
+		if _, ok := t.activeShipment(pvzID); ok {
+			return entity.ErrReturnShipmentConflict
+		}
+
+		shipment = entity.ReturnShipment{
+			ID:        uuid.New(),
+			PVZID:     pvzID,
+			Status:    entity.InProgressStatus,
+			DateTime:  r.now(),
+			CreatedBy: createdBy,
+		}
+		t.shipments[shipment.ID] = shipment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func (r *ReturnRepo) AddToActiveShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error) {
+	var ret entity.Return
+	err := r.write(ctx, func(t *tables) error {
+		shipment, ok := t.activeShipment(pvzID)
+		if !ok {
+			return entity.ErrNoActiveReturnShipment
+		}
+		if ret, ok = t.returns[returnID]; !ok || ret.PVZID != pvzID {
+			return entity.ErrReturnNotFound
+		}
+		if ret.ShipmentID != nil {
+			return entity.ErrReturnAlreadyShipped
+		}
+
+		ret.ShipmentID = &shipment.ID
+		t.returns[returnID] = ret
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (r *ReturnRepo) CloseActiveShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error) {
+	var shipment entity.ReturnShipment
+	err := r.write(ctx, func(t *tables) error {
+		var ok bool
+		if shipment, ok = t.activeShipment(pvzID); !ok {
+			return entity.ErrNoActiveReturnShipment
+		}
+
+		closedAt := r.now()
+		shipment.Status = entity.CloseStatus
+		shipment.ClosedAt = &closedAt
+		t.shipments[shipment.ID] = shipment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func (r *ReturnRepo) Stats(ctx context.Context, from, to time.Time) ([]entity.ReturnStats, error) {
+	stats := make([]entity.ReturnStats, 0)
+	err := r.read(ctx, func(t *tables) error {
+		byPVZ := make(map[uuid.UUID]*entity.ReturnStats)
+		for _, ret := range t.returns {
+			if !from.IsZero() && ret.DateTime.Before(from) || !to.IsZero() && ret.DateTime.After(to) {
+				continue
+			}
+
+			s, ok := byPVZ[ret.PVZID]
+			if !ok {
+				s = &entity.ReturnStats{PVZID: ret.PVZID, City: t.pvz[ret.PVZID].city}
+				byPVZ[ret.PVZID] = s
+			}
+			s.Total++
+			if t.returnShipped(ret) {
+				s.Shipped++
+			} else {
+				s.Outstanding++
+			}
+		}
+
+		for _, s := range byPVZ {
+			stats = append(stats, *s)
+		}
+		return nil
+	})
+	slices.SortFunc(stats, func(a, b entity.ReturnStats) int {
+		if a.Total != b.Total {
+			return b.Total - a.Total
+		}
+		return compareUUID(a.PVZID, b.PVZID)
+	})
+	return stats, err
+}
+
+func (t *tables) activeShipment(pvzID uuid.UUID) (entity.ReturnShipment, bool) {
+	for _, shipment := range t.shipments {
+		if shipment.PVZID == pvzID && shipment.Status == entity.InProgressStatus {
+			return shipment, true
+		}
+	}
+	return entity.ReturnShipment{}, false
+}
+
+// returnShipped reports whether the return left the PVZ with a closed shipment.
+func (t *tables) returnShipped(ret entity.Return) bool {
+	return ret.ShipmentID != nil && t.shipments[*ret.ShipmentID].Status == entity.CloseStatus
+}
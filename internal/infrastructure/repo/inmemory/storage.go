@@ -0,0 +1,208 @@
+// Package inmemory implements the repository contracts on top of process memory.
+// It enforces the same invariants as the Postgres schema and is meant for tests
+// and demo mode; nothing survives a restart.
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Storage holds the tables shared by all in-memory repositories. A single lock
+// guards every table, so checks spanning several of them (one active reception
+// per PVZ, unique barcodes, foreign keys) are race-free.
+type Storage struct {
+	mu sync.RWMutex
+	t  tables
+	// undo is the copy of the tables taken on the first write of the running
+	// TxManager unit of work, nil until then.
+	undo *tables
+
+	lastNow    time.Time
+	eventSeq   int64
+	auditSeq   int64
+	productSeq int64
+
+	// notifyMu keeps committed events flowing to listeners in commit order.
+	notifyMu    sync.Mutex
+	listenersMu sync.Mutex
+	listeners   map[*listener]struct{}
+
+	relayMu sync.Mutex
+}
+
+type tables struct {
+	users          map[uuid.UUID]entity.User
+	refreshTokens  map[string]entity.RefreshToken
+	revokedTokens  map[string]time.Time
+	cities         map[uuid.UUID]entity.CityInfo
+	pvz            map[uuid.UUID]pvzRow
+	assignments    map[assignmentKey]entity.PVZAssignment
+	productTypes   map[uuid.UUID]entity.ProductTypeInfo
+	categories     map[uuid.UUID]entity.ProductCategory
+	receptions     map[uuid.UUID]entity.Reception
+	manifests      map[uuid.UUID][]entity.ManifestItem
+	reports        map[uuid.UUID]entity.DiscrepancyReport
+	receptionLog   []entity.ReceptionStatusChange
+	products       map[uuid.UUID]productRow
+	productLog     []entity.ProductStatusChange
+	returns        map[uuid.UUID]entity.Return
+	shipments      map[uuid.UUID]entity.ReturnShipment
+	outbox         []outboxRow
+	subscriptions  map[uuid.UUID]entity.WebhookSubscription
+	deliveries     map[uuid.UUID]deliveryRow
+	audit          []entity.AuditEntry
+	idempotencyKey map[idempotencyKey]entity.IdempotencyRecord
+}
+
+// NewStorage returns an empty storage seeded with the cities and product types
+// the migrations create.
+func NewStorage() *Storage {
+	s := &Storage{
+		t: tables{
+			users:          make(map[uuid.UUID]entity.User),
+			refreshTokens:  make(map[string]entity.RefreshToken),
+			revokedTokens:  make(map[string]time.Time),
+			cities:         make(map[uuid.UUID]entity.CityInfo),
+			pvz:            make(map[uuid.UUID]pvzRow),
+			assignments:    make(map[assignmentKey]entity.PVZAssignment),
+			productTypes:   make(map[uuid.UUID]entity.ProductTypeInfo),
+			categories:     make(map[uuid.UUID]entity.ProductCategory),
+			receptions:     make(map[uuid.UUID]entity.Reception),
+			manifests:      make(map[uuid.UUID][]entity.ManifestItem),
+			reports:        make(map[uuid.UUID]entity.DiscrepancyReport),
+			products:       make(map[uuid.UUID]productRow),
+			returns:        make(map[uuid.UUID]entity.Return),
+			shipments:      make(map[uuid.UUID]entity.ReturnShipment),
+			subscriptions:  make(map[uuid.UUID]entity.WebhookSubscription),
+			deliveries:     make(map[uuid.UUID]deliveryRow),
+			idempotencyKey: make(map[idempotencyKey]entity.IdempotencyRecord),
+		},
+		listeners: make(map[*listener]struct{}),
+	}
+
+	now := s.now()
+	for _, name := range []entity.City{entity.CityMoscow, entity.CitySpb, entity.CityKazan} {
+		id := uuid.New()
+		s.t.cities[id] = entity.CityInfo{ID: id, Name: name, CreatedAt: now}
+	}
+	for _, name := range []entity.ProductType{
+		entity.ElectronicsProductType,
+		entity.ClothesProductType,
+		entity.ShoesProductType,
+	} {
+		id := uuid.New()
+		s.t.productTypes[id] = entity.ProductTypeInfo{ID: id, Name: name, Active: true, CreatedAt: now}
+	}
+
+	return s
+}
+
+// clone copies every table. Rows are stored by value and never modified in
+// place through shared slices, so a shallow copy of each table is enough.
+func (t *tables) clone() tables {
+	return tables{
+		users:          maps.Clone(t.users),
+		refreshTokens:  maps.Clone(t.refreshTokens),
+		revokedTokens:  maps.Clone(t.revokedTokens),
+		cities:         maps.Clone(t.cities),
+		pvz:            maps.Clone(t.pvz),
+		assignments:    maps.Clone(t.assignments),
+		productTypes:   maps.Clone(t.productTypes),
+		categories:     maps.Clone(t.categories),
+		receptions:     maps.Clone(t.receptions),
+		manifests:      maps.Clone(t.manifests),
+		reports:        maps.Clone(t.reports),
+		receptionLog:   slices.Clone(t.receptionLog),
+		products:       maps.Clone(t.products),
+		productLog:     slices.Clone(t.productLog),
+		returns:        maps.Clone(t.returns),
+		shipments:      maps.Clone(t.shipments),
+		outbox:         slices.Clone(t.outbox),
+		subscriptions:  maps.Clone(t.subscriptions),
+		deliveries:     maps.Clone(t.deliveries),
+		audit:          slices.Clone(t.audit),
+		idempotencyKey: maps.Clone(t.idempotencyKey),
+	}
+}
+
+type txKey struct{}
+
+// inTx reports whether ctx belongs to a unit of work of TxManager that already
+// holds the storage lock.
+func (s *Storage) inTx(ctx context.Context) bool {
+	owner, _ := ctx.Value(txKey{}).(*Storage)
+	return owner == s
+}
+
+// read runs fn under the shared lock.
+func (s *Storage) read(ctx context.Context, fn func(t *tables) error) error {
+	if s.inTx(ctx) {
+		return fn(&s.t)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&s.t)
+}
+
+// write runs fn under the exclusive lock. fn must validate before it changes
+// any row, so that a failed call leaves the tables untouched; events and audit
+// entries it appended are discarded, as with a rolled back savepoint.
+func (s *Storage) write(ctx context.Context, fn func(t *tables) error) (err error) {
+	locked := !s.inTx(ctx)
+	if locked {
+		s.mu.Lock()
+	} else if s.undo == nil {
+		undo := s.t.clone()
+		s.undo = &undo
+	}
+
+	mark, auditMark := len(s.t.outbox), len(s.t.audit)
+	defer func() {
+		if err != nil {
+			s.t.outbox = s.t.outbox[:mark]
+			s.t.audit = s.t.audit[:auditMark]
+		}
+		if locked {
+			s.unlockAndNotify(mark)
+		}
+	}()
+
+	return fn(&s.t)
+}
+
+// unlockAndNotify releases the exclusive lock and hands the events appended
+// after mark to the listeners.
+func (s *Storage) unlockAndNotify(mark int) {
+	events := s.eventsFrom(mark)
+	s.notifyMu.Lock()
+	s.mu.Unlock()
+	defer s.notifyMu.Unlock()
+
+	if len(events) > 0 {
+		s.broadcast(events)
+	}
+}
+
+// now returns strictly increasing timestamps with the precision of Postgres, so
+// rows written one after another keep their order. Callers hold the lock.
+func (s *Storage) now() time.Time {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(s.lastNow) {
+		now = s.lastNow.Add(time.Microsecond)
+	}
+	s.lastNow = now
+	return now
+}
+
+func compareUUID(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
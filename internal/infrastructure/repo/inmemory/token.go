@@ -0,0 +1,99 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshTokenRepo struct {
+	*Storage
+}
+
+func NewRefreshTokenRepo(s *Storage) *RefreshTokenRepo {
+	return &RefreshTokenRepo{s}
+}
+
+func (r *RefreshTokenRepo) Create(ctx context.Context, token *entity.RefreshToken) error {
+	return r.write(ctx, func(t *tables) error {
+		token.ID = uuid.New()
+		token.CreatedAt = r.now()
+		t.refreshTokens[token.TokenHash] = *token
+		return nil
+	})
+}
+
+func (r *RefreshTokenRepo) Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := r.write(ctx, func(t *tables) error {
+		stored, ok := t.refreshTokens[tokenHash]
+		if !ok || stored.RevokedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+			return entity.ErrInvalidRefreshToken
+		}
+		user, ok := t.users[stored.UserID]
+		if !ok {
+			return entity.ErrInvalidRefreshToken
+		}
+
+		now := r.now()
+		stored.RevokedAt = &now
+		t.refreshTokens[tokenHash] = stored
+
+		token = stored
+		token.Email = user.Email
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *RefreshTokenRepo) Revoke(ctx context.Context, tokenHash string) error {
+	return r.write(ctx, func(t *tables) error {
+		stored, ok := t.refreshTokens[tokenHash]
+		if !ok || stored.RevokedAt != nil {
+			return entity.ErrInvalidRefreshToken
+		}
+
+		now := r.now()
+		stored.RevokedAt = &now
+		t.refreshTokens[tokenHash] = stored
+		return nil
+	})
+}
+
+type RevokedTokenRepo struct {
+	*Storage
+}
+
+func NewRevokedTokenRepo(s *Storage) *RevokedTokenRepo {
+	return &RevokedTokenRepo{s}
+}
+
+func (r *RevokedTokenRepo) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return r.write(ctx, func(t *tables) error {
+		if _, ok := t.revokedTokens[tokenID]; !ok {
+			t.revokedTokens[tokenID] = expiresAt
+		}
+
+		now := time.Now()
+		for id, expires := range t.revokedTokens {
+			if expires.Before(now) {
+				delete(t.revokedTokens, id)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *RevokedTokenRepo) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var revoked bool
+	err := r.read(ctx, func(t *tables) error {
+		_, revoked = t.revokedTokens[tokenID]
+		return nil
+	})
+	return revoked, err
+}
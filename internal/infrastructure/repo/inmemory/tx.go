@@ -0,0 +1,49 @@
+package inmemory
+
+import (
+	"context"
+)
+
+type TxManager struct {
+	s *Storage
+}
+
+func NewTxManager(s *Storage) *TxManager {
+	return &TxManager{s}
+}
+
+// WithinTx holds the storage lock for the whole of fn, which makes units of
+// work serializable, and restores the tables when fn fails. The tables are
+// copied on the first write of fn, so read-only units of work copy nothing.
+// Repositories must be called with the ctx passed to fn, otherwise they wait
+// for the lock held by their own transaction. Nested calls join the outer
+// transaction.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if m.s.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	m.s.mu.Lock()
+	mark := len(m.s.t.outbox)
+	defer func() {
+		undo := m.s.undo
+		m.s.undo = nil
+		if p := recover(); p != nil {
+			if undo != nil {
+				m.s.t = *undo
+			}
+			m.s.mu.Unlock()
+			panic(p)
+		}
+		if err != nil {
+			if undo != nil {
+				m.s.t = *undo
+			}
+			m.s.mu.Unlock()
+			return
+		}
+		m.s.unlockAndNotify(mark)
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, m.s))
+}
@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserRepo struct {
+	*Storage
+}
+
+func NewUserRepo(s *Storage) *UserRepo {
+	return &UserRepo{s}
+}
+
+func (r *UserRepo) Create(ctx context.Context, u *entity.User) error {
+	return r.write(ctx, func(t *tables) error {
+		if _, ok := t.userByEmail(u.Email); ok {
+			return entity.ErrUserAlreadyExists
+		}
+
+		u.ID = uuid.New()
+		u.CreatedAt = r.now()
+		t.users[u.ID] = *u
+		return nil
+	})
+}
+
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	var user entity.User
+	err := r.read(ctx, func(t *tables) error {
+		u, ok := t.userByEmail(email)
+		if !ok {
+			return entity.ErrUserNotFound
+		}
+		user = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (t *tables) userByEmail(email string) (entity.User, bool) {
+	for _, u := range t.users {
+		if u.Email == email {
+			return u, true
+		}
+	}
+	return entity.User{}, false
+}
@@ -0,0 +1,257 @@
+package inmemory
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deliveryRow struct {
+	delivery entity.WebhookDelivery
+	event    entity.Event
+}
+
+type WebhookRepo struct {
+	*Storage
+}
+
+func NewWebhookRepo(s *Storage) *WebhookRepo {
+	return &WebhookRepo{s}
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	return r.write(ctx, func(t *tables) error {
+		if sub.PVZID != nil {
+			if _, ok := t.pvz[*sub.PVZID]; !ok {
+				return entity.ErrPVZNotFound
+			}
+		}
+
+		sub.ID = uuid.New()
+		sub.Active = true
+		sub.CreatedAt = r.now()
+
+		stored := *sub
+		stored.EventTypes = slices.Clone(sub.EventTypes)
+		t.subscriptions[sub.ID] = stored
+		return nil
+	})
+}
+
+func (r *WebhookRepo) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+	err := r.read(ctx, func(t *tables) error {
+		var ok bool
+		if sub, ok = t.subscriptions[id]; !ok {
+			return entity.ErrWebhookSubscriptionNotFound
+		}
+		sub.EventTypes = slices.Clone(sub.EventTypes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	subs := make([]entity.WebhookSubscription, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, sub := range t.subscriptions {
+			if sub.Active {
+				sub.EventTypes = slices.Clone(sub.EventTypes)
+				subs = append(subs, sub)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(subs, func(a, b entity.WebhookSubscription) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return subs, err
+}
+
+// DeactivateSubscription gives up the pending deliveries of the subscription.
+func (r *WebhookRepo) DeactivateSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.write(ctx, func(t *tables) error {
+		sub, ok := t.subscriptions[id]
+		if !ok || !sub.Active {
+			return entity.ErrWebhookSubscriptionNotFound
+		}
+
+		sub.Active = false
+		t.subscriptions[id] = sub
+
+		for deliveryID, row := range t.deliveries {
+			if row.delivery.SubscriptionID != id || row.delivery.Status != entity.WebhookDeliveryPending {
+				continue
+			}
+			row.delivery.Status = entity.WebhookDeliveryFailed
+			row.delivery.NextAttemptAt = nil
+			row.delivery.LastError = "subscription deleted"
+			t.deliveries[deliveryID] = row
+		}
+		return nil
+	})
+}
+
+// EnqueueDeliveries creates a pending delivery for every active subscription
+// matching an event; events already enqueued for a subscription are skipped.
+func (r *WebhookRepo) EnqueueDeliveries(ctx context.Context, events []entity.Event) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	var enqueued int
+	err := r.write(ctx, func(t *tables) error {
+		queued := make(map[[2]uuid.UUID]struct{}, len(t.deliveries))
+		for _, row := range t.deliveries {
+			queued[[2]uuid.UUID{row.delivery.SubscriptionID, row.delivery.EventID}] = struct{}{}
+		}
+
+		for _, event := range events {
+			for _, sub := range t.subscriptions {
+				if !t.subscriptionMatches(sub, event) {
+					continue
+				}
+				key := [2]uuid.UUID{sub.ID, event.ID}
+				if _, ok := queued[key]; ok {
+					continue
+				}
+				queued[key] = struct{}{}
+
+				now := r.now()
+				delivery := entity.WebhookDelivery{
+					ID:             uuid.New(),
+					SubscriptionID: sub.ID,
+					EventID:        event.ID,
+					EventType:      event.Type,
+					Status:         entity.WebhookDeliveryPending,
+					NextAttemptAt:  &now,
+					CreatedAt:      now,
+				}
+				t.deliveries[delivery.ID] = deliveryRow{delivery: delivery, event: event}
+				enqueued++
+			}
+		}
+		return nil
+	})
+	return enqueued, err
+}
+
+// ClaimDueDeliveries leases the due deliveries to the caller: they are not
+// claimed again until lease passes without an attempt being recorded.
+func (r *WebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	err := r.write(ctx, func(t *tables) error {
+		now := r.now()
+
+		due := make([]deliveryRow, 0)
+		for _, row := range t.deliveries {
+			d := row.delivery
+			if d.Status == entity.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+				due = append(due, row)
+			}
+		}
+		slices.SortFunc(due, func(a, b deliveryRow) int {
+			return a.delivery.NextAttemptAt.Compare(*b.delivery.NextAttemptAt)
+		})
+
+		leaseUntil := now.Add(lease)
+		for _, row := range due[:min(limit, len(due))] {
+			row.delivery.NextAttemptAt = &leaseUntil
+			t.deliveries[row.delivery.ID] = row
+
+			sub := t.subscriptions[row.delivery.SubscriptionID]
+			delivery := row.delivery
+			delivery.Event = row.event
+			delivery.URL = sub.URL
+			delivery.Secret = sub.Secret
+			deliveries = append(deliveries, delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) RecordAttempt(ctx context.Context, attempt entity.WebhookAttempt) error {
+	return r.write(ctx, func(t *tables) error {
+		row, ok := t.deliveries[attempt.DeliveryID]
+		if !ok {
+			return nil
+		}
+
+		d := &row.delivery
+		d.Attempts++
+		d.Status = entity.WebhookDeliveryPending
+		switch {
+		case attempt.Delivered:
+			d.Status = entity.WebhookDeliveryDelivered
+		case attempt.NextAttemptAt == nil:
+			d.Status = entity.WebhookDeliveryFailed
+		}
+
+		d.LastStatusCode = nil
+		if attempt.StatusCode != 0 {
+			statusCode := attempt.StatusCode
+			d.LastStatusCode = &statusCode
+		}
+		d.LastError = attempt.Error
+		d.NextAttemptAt = attempt.NextAttemptAt
+		d.DeliveredAt = nil
+		if d.Status == entity.WebhookDeliveryDelivered {
+			deliveredAt := r.now()
+			d.DeliveredAt = &deliveredAt
+		}
+
+		t.deliveries[attempt.DeliveryID] = row
+		return nil
+	})
+}
+
+func (r *WebhookRepo) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	status entity.WebhookDeliveryStatus,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	err := r.read(ctx, func(t *tables) error {
+		for _, row := range t.deliveries {
+			d := row.delivery
+			if d.SubscriptionID == subscriptionID && (status == "" || d.Status == status) {
+				deliveries = append(deliveries, d)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(deliveries, func(a, b entity.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUID(a.ID, b.ID)
+	})
+	return deliveries[:min(limit, len(deliveries))], err
+}
+
+func (t *tables) subscriptionMatches(sub entity.WebhookSubscription, event entity.Event) bool {
+	if !sub.Active || !slices.Contains(sub.EventTypes, event.Type) {
+		return false
+	}
+	if sub.PVZID != nil && *sub.PVZID != event.PVZID {
+		return false
+	}
+	if sub.City != nil {
+		pvz, ok := t.pvz[event.PVZID]
+		if !ok || pvz.city != *sub.City {
+			return false
+		}
+	}
+	return true
+}
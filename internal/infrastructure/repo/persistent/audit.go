@@ -0,0 +1,160 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
+	"encoding/json"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type AuditRepo struct {
+	*postgres.Postgres
+}
+
+func NewAuditRepo(pg *postgres.Postgres) *AuditRepo {
+	return &AuditRepo{pg}
+}
+
+func (r *AuditRepo) List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	query := r.Builder.
+		Select(auditColumns).
+		From("audit_log").
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit))
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at <= ?", *filter.To)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			e         entity.AuditEntry
+			role      *string
+			requestID *string
+		)
+		err := rows.Scan(
+			&e.ID,
+			&e.OccurredAt,
+			&e.ActorID,
+			&role,
+			&e.Action,
+			&e.EntityType,
+			&e.EntityID,
+			&e.PVZID,
+			&e.Before,
+			&e.After,
+			&requestID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		if role != nil {
+			e.ActorRole = entity.UserRole(*role)
+		}
+		if requestID != nil {
+			e.RequestID = *requestID
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+const auditColumns = "id, occurred_at, actor_id, actor_role, action, entity_type, entity_id, pvz_id, before, after, request_id"
+
+// auditRecord describes one mutation; the actor and request ID are taken from
+// the context when the record is written.
+type auditRecord struct {
+	action     entity.AuditAction
+	entityType entity.AuditEntityType
+	entityID   uuid.UUID
+	pvzID      uuid.UUID
+	before     any
+	after      any
+}
+
+func appendAudit(ctx context.Context, tx pgx.Tx, builder sq.StatementBuilderType, records ...auditRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var (
+		actorID   *uuid.UUID
+		actorRole *entity.UserRole
+	)
+	if principal, ok := requestctx.Principal(ctx); ok {
+		actorID = principal.Actor()
+		actorRole = &principal.Role
+	}
+	requestID := nullString(requestctx.RequestID(ctx))
+
+	insert := builder.
+		Insert("audit_log").
+		Columns("actor_id", "actor_role", "action", "entity_type", "entity_id", "pvz_id", "before", "after", "request_id")
+	for _, rec := range records {
+		before, err := auditSnapshot(rec.before)
+		if err != nil {
+			return err
+		}
+		after, err := auditSnapshot(rec.after)
+		if err != nil {
+			return err
+		}
+		insert = insert.Values(actorID, actorRole, rec.action, rec.entityType, rec.entityID, rec.pvzID, before, after, requestID)
+	}
+
+	sqlQuery, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, sqlQuery, args...); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func auditSnapshot(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
@@ -0,0 +1,173 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type CatalogueRepo struct {
+	*postgres.Postgres
+}
+
+func NewCatalogueRepo(pg *postgres.Postgres) *CatalogueRepo {
+	return &CatalogueRepo{pg}
+}
+
+func (r *CatalogueRepo) CreateType(ctx context.Context, productType *entity.ProductTypeInfo) error {
+	err := r.Conn(ctx).QueryRow(ctx, `
+		INSERT INTO product_types (name)
+		VALUES ($1)
+		RETURNING id, is_active, created_at`,
+		productType.Name,
+	).Scan(&productType.ID, &productType.Active, &productType.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return entity.ErrProductTypeAlreadyExists
+		}
+		return fmt.Errorf("failed to create product type: %w", err)
+	}
+
+	productType.Categories = []entity.ProductCategory{}
+	return nil
+}
+
+func (r *CatalogueRepo) ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error) {
+	query := r.Builder.
+		Select(
+			"pt.id", "pt.name", "pt.is_active", "pt.created_at",
+			"pc.id", "pc.name", "pc.is_active", "pc.created_at",
+		).
+		From("product_types pt").
+		OrderBy("pt.name", "pc.name")
+
+	if includeInactive {
+		query = query.LeftJoin("product_categories pc ON pc.type_id = pt.id")
+	} else {
+		query = query.
+			LeftJoin("product_categories pc ON pc.type_id = pt.id AND pc.is_active").
+			Where("pt.is_active")
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product types: %w", err)
+	}
+	defer rows.Close()
+
+	types := make([]entity.ProductTypeInfo, 0)
+	for rows.Next() {
+		var (
+			productType       entity.ProductTypeInfo
+			categoryID        uuid.NullUUID
+			categoryName      sql.NullString
+			categoryActive    sql.NullBool
+			categoryCreatedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&productType.ID,
+			&productType.Name,
+			&productType.Active,
+			&productType.CreatedAt,
+			&categoryID,
+			&categoryName,
+			&categoryActive,
+			&categoryCreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+
+		if len(types) == 0 || types[len(types)-1].ID != productType.ID {
+			productType.Categories = []entity.ProductCategory{}
+			types = append(types, productType)
+		}
+
+		if categoryID.Valid {
+			last := &types[len(types)-1]
+			last.Categories = append(last.Categories, entity.ProductCategory{
+				ID:        categoryID.UUID,
+				TypeID:    productType.ID,
+				Name:      categoryName.String,
+				Active:    categoryActive.Bool,
+				CreatedAt: categoryCreatedAt.Time,
+			})
+		}
+	}
+
+	return types, rows.Err()
+}
+
+func (r *CatalogueRepo) SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error) {
+	var productType entity.ProductTypeInfo
+	err := r.Conn(ctx).QueryRow(ctx, `
+		UPDATE product_types
+		SET is_active = $2
+		WHERE id = $1
+		RETURNING id, name, is_active, created_at`,
+		id, active,
+	).Scan(&productType.ID, &productType.Name, &productType.Active, &productType.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductTypeNotFound
+		}
+		return nil, fmt.Errorf("failed to update product type: %w", err)
+	}
+
+	productType.Categories = []entity.ProductCategory{}
+	return &productType, nil
+}
+
+func (r *CatalogueRepo) CreateCategory(ctx context.Context, category *entity.ProductCategory) error {
+	err := r.Conn(ctx).QueryRow(ctx, `
+		INSERT INTO product_categories (type_id, name)
+		VALUES ($1, $2)
+		RETURNING id, is_active, created_at`,
+		category.TypeID, category.Name,
+	).Scan(&category.ID, &category.Active, &category.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return entity.ErrProductCategoryAlreadyExists
+			case "23503":
+				return entity.ErrProductTypeNotFound
+			}
+		}
+		return fmt.Errorf("failed to create product category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CatalogueRepo) SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error) {
+	var category entity.ProductCategory
+	err := r.Conn(ctx).QueryRow(ctx, `
+		UPDATE product_categories
+		SET is_active = $3
+		WHERE id = $1 AND type_id = $2
+		RETURNING id, type_id, name, is_active, created_at`,
+		id, typeID, active,
+	).Scan(&category.ID, &category.TypeID, &category.Name, &category.Active, &category.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to update product category: %w", err)
+	}
+
+	return &category, nil
+}
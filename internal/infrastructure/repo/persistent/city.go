@@ -0,0 +1,131 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type CityRepo struct {
+	*postgres.Postgres
+}
+
+func NewCityRepo(pg *postgres.Postgres) *CityRepo {
+	return &CityRepo{pg}
+}
+
+func (r *CityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	err := r.Conn(ctx).QueryRow(ctx, `
+		INSERT INTO cities (name)
+		VALUES ($1)
+		RETURNING id, created_at`,
+		city.Name,
+	).Scan(&city.ID, &city.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return entity.ErrCityAlreadyExists
+		}
+		return fmt.Errorf("failed to create city: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	var city entity.CityInfo
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT id, name, created_at
+		FROM cities
+		WHERE id = $1`,
+		id,
+	).Scan(&city.ID, &city.Name, &city.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrCityNotFound
+		}
+		return nil, fmt.Errorf("failed to get city: %w", err)
+	}
+
+	return &city, nil
+}
+
+func (r *CityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT id, name, created_at
+		FROM cities
+		ORDER BY name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cities: %w", err)
+	}
+	defer rows.Close()
+
+	cities := make([]entity.CityInfo, 0)
+	for rows.Next() {
+		var city entity.CityInfo
+		if err := rows.Scan(&city.ID, &city.Name, &city.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		cities = append(cities, city)
+	}
+
+	return cities, rows.Err()
+}
+
+func (r *CityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	var city entity.CityInfo
+	err := r.Conn(ctx).QueryRow(ctx, `
+		UPDATE cities
+		SET name = $2
+		WHERE id = $1
+		RETURNING id, name, created_at`,
+		id, name,
+	).Scan(&city.ID, &city.Name, &city.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrCityNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, entity.ErrCityAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to rename city: %w", err)
+	}
+
+	return &city, nil
+}
+
+func (r *CityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.Conn(ctx).Exec(ctx, `DELETE FROM cities WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return entity.ErrCityInUse
+		}
+		return fmt.Errorf("failed to delete city: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrCityNotFound
+	}
+
+	return nil
+}
+
+func (r *CityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	var exists bool
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM cities WHERE name = $1)`,
+		name,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check city: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,158 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"time"
+)
+
+const outboxEventsChannel = "outbox_events"
+
+type EventStreamRepo struct {
+	*postgres.Postgres
+}
+
+func NewEventStreamRepo(pg *postgres.Postgres) *EventStreamRepo {
+	return &EventStreamRepo{pg}
+}
+
+// Listen holds a dedicated connection subscribed to outbox inserts of every app
+// instance and passes each committed event to handle until ctx is done.
+func (r *EventStreamRepo) Listen(ctx context.Context, handle func(entity.Event)) error {
+	conn, err := r.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+outboxEventsChannel); err != nil {
+		return fmt.Errorf("failed to listen for events: %w", err)
+	}
+	defer func() {
+		// A cancelled ctx would abort UNLISTEN, so the connection is closed
+		// instead of being returned to the pool still subscribed.
+		if ctx.Err() != nil {
+			_ = conn.Conn().Close(context.Background())
+		} else {
+			_, _ = conn.Exec(context.Background(), "UNLISTEN "+outboxEventsChannel)
+		}
+	}()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for events: %w", err)
+		}
+
+		seq, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		event, err := scanStreamEvent(conn.QueryRow(ctx, `
+			SELECT `+streamEventColumns+`
+			FROM outbox_events e
+			LEFT JOIN pvz ON pvz.id = e.pvz_id
+			WHERE e.seq = $1`,
+			seq,
+		))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("failed to load event: %w", err)
+		}
+		handle(*event)
+	}
+}
+
+func (r *EventStreamRepo) EventsSince(
+	ctx context.Context,
+	filter entity.EventFilter,
+	afterSeq int64,
+	limit int,
+) ([]entity.Event, error) {
+	query := r.Builder.
+		Select(streamEventColumns).
+		From("outbox_events e").
+		LeftJoin("pvz ON pvz.id = e.pvz_id").
+		Where("e.seq > ?", afterSeq).
+		OrderBy("e.seq").
+		Limit(uint64(limit))
+
+	if filter.PVZID != uuid.Nil {
+		query = query.Where("e.pvz_id = ?", filter.PVZID)
+	}
+	if filter.City != "" {
+		query = query.Where("pvz.city = ?", filter.City)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Pool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]entity.Event, 0)
+	for rows.Next() {
+		event, err := scanStreamEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		events = append(events, *event)
+	}
+
+	return events, rows.Err()
+}
+
+// ReplayStart returns the sequence to replay from when resuming after afterSeq.
+// Sequence numbers are taken at insert, not at commit, so an event with a lower
+// sequence may commit after afterSeq was delivered; the replay therefore starts
+// at the first event that occurred within window before it.
+func (r *EventStreamRepo) ReplayStart(ctx context.Context, afterSeq int64, window time.Duration) (int64, error) {
+	var start int64
+	err := r.Pool.QueryRow(ctx, `
+		SELECT COALESCE(MIN(e.seq) - 1, $1)
+		FROM outbox_events e
+		WHERE e.seq <= $1
+		AND e.occurred_at >= (SELECT occurred_at FROM outbox_events WHERE seq = $1) - make_interval(secs => $2)`,
+		afterSeq, window.Seconds(),
+	).Scan(&start)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find replay start: %w", err)
+	}
+	return start, nil
+}
+
+const streamEventColumns = "e.seq, e.id, e.event_type, e.event_version, e.aggregate_id, e.pvz_id, e.occurred_at, e.payload, " +
+	"COALESCE(pvz.city, '')"
+
+func scanStreamEvent(row pgx.Row) (*entity.Event, error) {
+	var e entity.Event
+	err := row.Scan(
+		&e.Sequence,
+		&e.ID,
+		&e.Type,
+		&e.Version,
+		&e.AggregateID,
+		&e.PVZID,
+		&e.OccurredAt,
+		&e.Data,
+		&e.City,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,175 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"cmp"
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"slices"
+	"time"
+)
+
+// outboxRelayLockKey serialises claiming across app replicas.
+const outboxRelayLockKey = 7_201_605
+
+// outboxClaimTimeout bounds how long a claimed batch stays hidden from other
+// replicas; a relay that dies mid-publish delays the outbox by at most this.
+const outboxClaimTimeout = 5 * time.Minute
+
+type OutboxRepo struct {
+	*postgres.Postgres
+}
+
+func NewOutboxRepo(pg *postgres.Postgres) *OutboxRepo {
+	return &OutboxRepo{pg}
+}
+
+// Relay claims a batch of pending events and publishes it without holding a
+// transaction. A batch is claimed only while no other claim is active, so a
+// single relay is in flight across replicas and events go out in order.
+func (r *OutboxRepo) Relay(
+	ctx context.Context,
+	limit int,
+	publish func(ctx context.Context, events []entity.Event) error,
+) (int, error) {
+	events, err := r.claim(ctx, limit)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	seqs := make([]int64, len(events))
+	for i, e := range events {
+		seqs[i] = e.Sequence
+	}
+
+	if publishErr := publish(ctx, events); publishErr != nil {
+		_, err := r.Conn(ctx).Exec(ctx, `
+			UPDATE outbox_events
+			SET claimed_until = NULL,
+				attempts = attempts + CASE WHEN seq = $2 THEN 1 ELSE 0 END,
+				last_error = CASE WHEN seq = $2 THEN $3 ELSE last_error END
+			WHERE seq = ANY($1)`,
+			seqs, seqs[0], publishErr.Error(),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record publish failure: %w", err)
+		}
+		return 0, fmt.Errorf("failed to publish events: %w", publishErr)
+	}
+
+	_, err = r.Conn(ctx).Exec(ctx, `
+		UPDATE outbox_events
+		SET published_at = NOW(), attempts = attempts + 1, last_error = NULL, claimed_until = NULL
+		WHERE seq = ANY($1)`,
+		seqs,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark events published: %w", err)
+	}
+
+	return len(events), nil
+}
+
+func (r *OutboxRepo) claim(ctx context.Context, limit int) ([]entity.Event, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLockKey).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("failed to acquire relay lock: %w", err)
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	var claimed bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM outbox_events
+			WHERE published_at IS NULL AND claimed_until > NOW()
+		)`,
+	).Scan(&claimed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check claimed events: %w", err)
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE outbox_events
+		SET claimed_until = NOW() + make_interval(secs => $2)
+		WHERE seq IN (
+			SELECT seq FROM outbox_events
+			WHERE published_at IS NULL
+			ORDER BY seq
+			LIMIT $1
+		)
+		RETURNING seq, id, event_type, event_version, aggregate_id, pvz_id, occurred_at, payload`,
+		limit, outboxClaimTimeout.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending events: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Event, error) {
+		var e entity.Event
+		err := row.Scan(&e.Sequence, &e.ID, &e.Type, &e.Version, &e.AggregateID, &e.PVZID, &e.OccurredAt, &e.Data)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending events: %w", err)
+	}
+	slices.SortFunc(events, func(a, b entity.Event) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return events, nil
+}
+
+func appendEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	eventType entity.EventType,
+	aggregateID uuid.UUID,
+	pvzID uuid.UUID,
+	data any,
+) error {
+	event, err := entity.NewEvent(eventType, aggregateID, pvzID, data)
+	if err != nil {
+		return err
+	}
+	return appendEvents(ctx, tx, builder, []entity.Event{event})
+}
+
+func appendEvents(ctx context.Context, tx pgx.Tx, builder sq.StatementBuilderType, events []entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	insert := builder.
+		Insert("outbox_events").
+		Columns("id", "event_type", "event_version", "aggregate_id", "pvz_id", "occurred_at", "payload")
+	for _, e := range events {
+		insert = insert.Values(e.ID, e.Type, e.Version, e.AggregateID, e.PVZID, e.OccurredAt, e.Data)
+	}
+
+	sqlQuery, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, sqlQuery, args...); err != nil {
+		return fmt.Errorf("failed to append events to outbox: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package persistent_test
+
+import (
+	"PVZ-avito-tech/internal/infrastructure/repo/persistent"
+	"PVZ-avito-tech/internal/infrastructure/repo/repotest"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"os"
+	"testing"
+)
+
+// TestConformance needs a migrated database in PG_TEST_URL.
+func TestConformance(t *testing.T) {
+	url := os.Getenv("PG_TEST_URL")
+	if url == "" {
+		t.Skip("PG_TEST_URL is not set")
+	}
+
+	pg, err := postgres.New(url, postgres.MaxPoolSize(10))
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	t.Cleanup(pg.Close)
+
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		return repotest.Repos{
+			Users:         persistent.NewUserRepo(pg),
+			RefreshTokens: persistent.NewRefreshTokenRepo(pg),
+			RevokedTokens: persistent.NewRevokedTokenRepo(pg),
+			PVZ:           persistent.NewPVZRepo(pg),
+			Assignments:   persistent.NewPVZAssignmentRepo(pg),
+			Receptions:    persistent.NewReceptionRepo(pg),
+			Products:      persistent.NewProductRepo(pg),
+			Cities:        persistent.NewCityRepo(pg),
+			Catalogue:     persistent.NewCatalogueRepo(pg),
+			Returns:       persistent.NewReturnRepo(pg),
+			Outbox:        persistent.NewOutboxRepo(pg),
+			EventStream:   persistent.NewEventStreamRepo(pg),
+			Webhooks:      persistent.NewWebhookRepo(pg),
+			Audit:         persistent.NewAuditRepo(pg),
+			Reports:       persistent.NewReportRepo(pg),
+			Idempotency:   persistent.NewIdempotencyRepo(pg),
+			Tx:            postgres.NewTxManager(pg),
+		}
+	})
+}
@@ -4,10 +4,15 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/postgres"
 	"context"
+	"crypto/subtle"
+	"database/sql"
 	"errors"
 	"fmt"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
 )
 
 type ProductRepo struct {
@@ -18,13 +23,22 @@ func NewProductRepo(pg *postgres.Postgres) *ProductRepo {
 	return &ProductRepo{pg}
 }
 
-func (r *ProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error) {
-	tx, err := r.Pool.Begin(ctx)
+func (r *ProductRepo) AddProduct(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	newProduct *entity.Product,
+) (*entity.Product, error) {
+	tx, err := r.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	categoryID, err := resolveCatalogueEntry(ctx, tx, newProduct.Type, newProduct.Category)
+	if err != nil {
+		return nil, err
+	}
+
 	var receptionID uuid.UUID
 	err = tx.QueryRow(ctx, `
 		SELECT id FROM receptions 
@@ -39,21 +53,60 @@ func (r *ProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, productTy
 		return nil, fmt.Errorf("failed to get active reception: %w", err)
 	}
 
-	var product entity.Product
+	product := entity.Product{
+		Category:   newProduct.Category,
+		Barcode:    newProduct.Barcode,
+		PVZID:      pvzID,
+		PickupCode: newProduct.PickupCode,
+	}
 	err = tx.QueryRow(ctx, `
-		INSERT INTO products (reception_id, type)
-		VALUES ($1, $2)
-		RETURNING id, reception_id, type, created_at
-	`, receptionID, productType).Scan(
+		INSERT INTO products (reception_id, type, category_id, created_by, status, pickup_code_hash, barcode)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, reception_id, type, status, created_at, created_by
+	`,
+		receptionID,
+		newProduct.Type,
+		categoryID,
+		newProduct.CreatedBy,
+		entity.ReceivedProductStatus,
+		nullString(newProduct.PickupCodeHash),
+		nullString(newProduct.Barcode),
+	).Scan(
 		&product.ID,
 		&product.ReceptionID,
 		&product.Type,
+		&product.Status,
 		&product.DateTime,
+		&product.CreatedBy,
 	)
 	if err != nil {
+		if isBarcodeConflict(err) {
+			return nil, entity.ErrBarcodeConflict
+		}
 		return nil, fmt.Errorf("failed to insert product: %w", err)
 	}
 
+	err = recordProductStatus(ctx, tx, product.ID, nil, entity.ReceivedProductStatus, newProduct.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = appendEvent(ctx, tx, r.Builder, entity.EventProductAdded, product.ID, pvzID, productAddedData(&product))
+	if err != nil {
+		return nil, err
+	}
+
+	err = appendAudit(ctx, tx, r.Builder, auditRecord{
+		action:     entity.AuditProductAdded,
+		entityType: entity.AuditEntityProduct,
+		entityID:   product.ID,
+		pvzID:      pvzID,
+		after:      product,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -61,8 +114,196 @@ func (r *ProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, productTy
 	return &product, nil
 }
 
+func (r *ProductRepo) AddProducts(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	products []*entity.Product,
+) ([]entity.ProductBatchResult, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var receptionID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM receptions 
+		WHERE pvz_id = $1 AND status = 'in_progress' 
+		LIMIT 1 FOR UPDATE`,
+		pvzID,
+	).Scan(&receptionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNoActiveReception
+		}
+		return nil, fmt.Errorf("failed to get active reception: %w", err)
+	}
+
+	acceptedBarcodes, err := activeBarcodes(ctx, tx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	type catalogueKey struct {
+		productType entity.ProductType
+		category    string
+	}
+	type catalogueEntry struct {
+		categoryID *uuid.UUID
+		err        error
+	}
+	catalogue := make(map[catalogueKey]catalogueEntry)
+
+	results := make([]entity.ProductBatchResult, len(products))
+	insertProducts := r.Builder.
+		Insert("products").
+		Columns("id", "reception_id", "type", "category_id", "created_by", "status", "pickup_code_hash", "barcode")
+	pending := 0
+
+	for i, newProduct := range products {
+		key := catalogueKey{newProduct.Type, newProduct.Category}
+		entry, ok := catalogue[key]
+		if !ok {
+			entry.categoryID, entry.err = resolveCatalogueEntry(ctx, tx, newProduct.Type, newProduct.Category)
+			if entry.err != nil && !isCatalogueError(entry.err) {
+				return nil, entry.err
+			}
+			catalogue[key] = entry
+		}
+		if entry.err != nil {
+			results[i].Err = entry.err
+			continue
+		}
+
+		if newProduct.Barcode != "" {
+			if _, taken := acceptedBarcodes[newProduct.Barcode]; taken {
+				results[i].Err = entity.ErrBarcodeConflict
+				continue
+			}
+			acceptedBarcodes[newProduct.Barcode] = struct{}{}
+		}
+
+		product := &entity.Product{
+			ID:          uuid.New(),
+			Type:        newProduct.Type,
+			ReceptionID: receptionID,
+			Category:    newProduct.Category,
+			Barcode:     newProduct.Barcode,
+			Status:      entity.ReceivedProductStatus,
+			CreatedBy:   newProduct.CreatedBy,
+			PVZID:       pvzID,
+			PickupCode:  newProduct.PickupCode,
+		}
+
+		insertProducts = insertProducts.Values(
+			product.ID,
+			receptionID,
+			product.Type,
+			entry.categoryID,
+			product.CreatedBy,
+			entity.ReceivedProductStatus,
+			nullString(newProduct.PickupCodeHash),
+			nullString(product.Barcode),
+		)
+		pending++
+		results[i].Product = product
+	}
+
+	if pending == 0 {
+		return results, nil
+	}
+
+	// A barcode accepted by a concurrent transaction after the check above
+	// makes only its own item fail: conflicting rows are skipped and are
+	// missing from RETURNING.
+	sqlQuery, args, err := insertProducts.Suffix("ON CONFLICT DO NOTHING RETURNING id, created_at").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert products: %w", err)
+	}
+	inserted := make(map[uuid.UUID]time.Time, pending)
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		inserted[id] = createdAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to insert products: %w", err)
+	}
+
+	insertHistory := r.Builder.
+		Insert("product_status_history").
+		Columns("product_id", "to_status", "changed_by")
+	for i, result := range results {
+		if result.Product == nil {
+			continue
+		}
+		createdAt, ok := inserted[result.Product.ID]
+		if !ok {
+			results[i] = entity.ProductBatchResult{Err: entity.ErrBarcodeConflict}
+			continue
+		}
+		result.Product.DateTime = createdAt
+		insertHistory = insertHistory.Values(result.Product.ID, entity.ReceivedProductStatus, result.Product.CreatedBy)
+	}
+	if len(inserted) == 0 {
+		return results, nil
+	}
+
+	sqlQuery, args, err = insertHistory.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+	if _, err := tx.Exec(ctx, sqlQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to record product status: %w", err)
+	}
+
+	events := make([]entity.Event, 0, len(inserted))
+	audit := make([]auditRecord, 0, len(inserted))
+	for _, result := range results {
+		if result.Product == nil {
+			continue
+		}
+		event, err := entity.NewEvent(entity.EventProductAdded, result.Product.ID, pvzID, productAddedData(result.Product))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+		audit = append(audit, auditRecord{
+			action:     entity.AuditProductAdded,
+			entityType: entity.AuditEntityProduct,
+			entityID:   result.Product.ID,
+			pvzID:      pvzID,
+			after:      result.Product,
+		})
+	}
+	if err := appendEvents(ctx, tx, r.Builder, events); err != nil {
+		return nil, err
+	}
+	if err := appendAudit(ctx, tx, r.Builder, audit...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
 func (r *ProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
-	tx, err := r.Pool.Begin(ctx)
+	tx, err := r.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -83,28 +324,573 @@ func (r *ProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) er
 		return fmt.Errorf("failed to get active reception: %w", err)
 	}
 
-	var productID uuid.UUID
+	var (
+		productID uuid.UUID
+		status    entity.ProductStatus
+	)
 	err = tx.QueryRow(ctx, `
-		DELETE FROM products 
-		WHERE id = (
-			SELECT id FROM products 
-			WHERE reception_id = $1 
-			ORDER BY created_at DESC 
-			LIMIT 1
-		)
-		RETURNING id`,
+		SELECT id, status FROM products 
+		WHERE reception_id = $1 AND deleted_at IS NULL
+		ORDER BY seq DESC
+		LIMIT 1 FOR UPDATE`,
 		receptionID,
-	).Scan(&productID)
+	).Scan(&productID, &status)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.ErrNoProducts
 		}
+		return fmt.Errorf("failed to get last product: %w", err)
+	}
+
+	if status != entity.ReceivedProductStatus {
+		return entity.ErrInvalidProductStatusTransition
+	}
+
+	before, err := scanProduct(tx.QueryRow(ctx, productByIDQuery, productID))
+	if err != nil {
+		return fmt.Errorf("failed to get last product: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM products WHERE id = $1`, productID)
+	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
+	err = appendEvent(ctx, tx, r.Builder, entity.EventProductDeleted, productID, pvzID, entity.ProductDeletedData{
+		ID:          productID,
+		ReceptionID: receptionID,
+		PVZID:       pvzID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appendAudit(ctx, tx, r.Builder, auditRecord{
+		action:     entity.AuditProductDeleted,
+		entityType: entity.AuditEntityProduct,
+		entityID:   productID,
+		pvzID:      pvzID,
+		before:     before,
+	})
+	if err != nil {
+		return err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	return nil
 }
+
+func (r *ProductRepo) DeleteProduct(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	productID uuid.UUID,
+	reason string,
+	deletedBy *uuid.UUID,
+) (*entity.ProductDeletion, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		receptionStatus entity.ReceptionsStatus
+		productStatus   entity.ProductStatus
+		deletion        = entity.ProductDeletion{ProductID: productID, Reason: reason}
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT r.id, r.status, p.status
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		WHERE p.id = $1 AND r.pvz_id = $2 AND p.deleted_at IS NULL
+		FOR UPDATE OF r, p`,
+		productID, pvzID,
+	).Scan(&deletion.ReceptionID, &receptionStatus, &productStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	if receptionStatus != entity.InProgressStatus {
+		return nil, entity.ErrReceptionNotInProgress
+	}
+	if productStatus != entity.ReceivedProductStatus {
+		return nil, entity.ErrInvalidProductStatusTransition
+	}
+
+	before, err := scanProduct(tx.QueryRow(ctx, productByIDQuery, productID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE products
+		SET deleted_at = NOW(), deleted_by = $2, deletion_reason = $3
+		WHERE id = $1
+		RETURNING deleted_at, deleted_by`,
+		productID, deletedBy, reason,
+	).Scan(&deletion.DeletedAt, &deletion.DeletedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	err = appendEvent(ctx, tx, r.Builder, entity.EventProductDeleted, productID, pvzID, entity.ProductDeletedData{
+		ID:          productID,
+		ReceptionID: deletion.ReceptionID,
+		PVZID:       pvzID,
+		Reason:      reason,
+		DeletedBy:   deletion.DeletedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = appendAudit(ctx, tx, r.Builder, auditRecord{
+		action:     entity.AuditProductDeleted,
+		entityType: entity.AuditEntityProduct,
+		entityID:   productID,
+		pvzID:      pvzID,
+		before:     before,
+		after:      deletion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &deletion, nil
+}
+
+func isCatalogueError(err error) bool {
+	return errors.Is(err, entity.ErrInvalidProductType) ||
+		errors.Is(err, entity.ErrProductTypeInactive) ||
+		errors.Is(err, entity.ErrProductCategoryNotFound) ||
+		errors.Is(err, entity.ErrProductCategoryInactive)
+}
+
+func activeBarcodes(ctx context.Context, tx pgx.Tx, products []*entity.Product) (map[string]struct{}, error) {
+	barcodes := make([]string, 0, len(products))
+	for _, p := range products {
+		if p.Barcode != "" {
+			barcodes = append(barcodes, p.Barcode)
+		}
+	}
+
+	active := make(map[string]struct{}, len(barcodes))
+	if len(barcodes) == 0 {
+		return active, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT barcode FROM products
+		WHERE barcode = ANY($1) AND status IN ($2, $3) AND deleted_at IS NULL`,
+		barcodes, entity.ReceivedProductStatus, entity.StoredProductStatus,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check barcodes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var barcode string
+		if err := rows.Scan(&barcode); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		active[barcode] = struct{}{}
+	}
+
+	return active, rows.Err()
+}
+
+func resolveCatalogueEntry(
+	ctx context.Context,
+	tx pgx.Tx,
+	productType entity.ProductType,
+	category string,
+) (*uuid.UUID, error) {
+	var (
+		typeActive     bool
+		categoryID     uuid.NullUUID
+		categoryActive sql.NullBool
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT pt.is_active, pc.id, pc.is_active
+		FROM product_types pt
+		LEFT JOIN product_categories pc ON pc.type_id = pt.id AND pc.name = $2
+		WHERE pt.name = $1`,
+		productType, category,
+	).Scan(&typeActive, &categoryID, &categoryActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrInvalidProductType
+		}
+		return nil, fmt.Errorf("failed to resolve product type: %w", err)
+	}
+
+	if !typeActive {
+		return nil, entity.ErrProductTypeInactive
+	}
+	if category == "" {
+		return nil, nil
+	}
+	if !categoryID.Valid {
+		return nil, entity.ErrProductCategoryNotFound
+	}
+	if !categoryActive.Bool {
+		return nil, entity.ErrProductCategoryInactive
+	}
+
+	return &categoryID.UUID, nil
+}
+
+func (r *ProductRepo) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT p.id, p.reception_id, p.type, COALESCE(pc.name, ''), COALESCE(p.barcode, ''), p.status, p.created_at, p.created_by
+		FROM products p
+		LEFT JOIN product_categories pc ON pc.id = p.category_id
+		WHERE p.reception_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.seq`,
+		receptionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]entity.Product, 0)
+	for rows.Next() {
+		var product entity.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.ReceptionID,
+			&product.Type,
+			&product.Category,
+			&product.Barcode,
+			&product.Status,
+			&product.DateTime,
+			&product.CreatedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+func (r *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	product, err := scanProduct(r.Conn(ctx).QueryRow(ctx, productByIDQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// IssueProduct issues the product if pickupCodeHash matches. A mismatch is
+// counted and committed; after maxAttempts of them the code is no longer checked.
+func (r *ProductRepo) IssueProduct(
+	ctx context.Context,
+	id uuid.UUID,
+	pickupCodeHash string,
+	maxAttempts int,
+	issuedBy *uuid.UUID,
+) (*entity.Product, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	product, err := lockProduct(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case product.PickupCodeHash == "":
+		return nil, entity.ErrPickupCodeNotSet
+	case product.PickupCodeAttempts >= maxAttempts:
+		return nil, entity.ErrPickupCodeAttemptsExceeded
+	case subtle.ConstantTimeCompare([]byte(product.PickupCodeHash), []byte(pickupCodeHash)) != 1:
+		_, err := tx.Exec(ctx, `UPDATE products SET pickup_code_attempts = pickup_code_attempts + 1 WHERE id = $1`, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count pickup code attempt: %w", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil, entity.ErrInvalidPickupCode
+	}
+
+	if err := changeProductStatus(ctx, tx, r.Builder, product, entity.IssuedProductStatus, issuedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return product, nil
+}
+
+// IssueProductWithoutCode is the moderator's path for products without a
+// usable pickup code.
+func (r *ProductRepo) IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	product, err := lockProduct(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeProductStatus(ctx, tx, r.Builder, product, entity.IssuedProductStatus, issuedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return product, nil
+}
+
+func lockProduct(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*entity.Product, error) {
+	product, err := scanProduct(tx.QueryRow(ctx, productByIDQuery+" FOR UPDATE OF p", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return product, nil
+}
+
+func (r *ProductRepo) ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT id, product_id, from_status, to_status, changed_at, changed_by
+		FROM product_status_history
+		WHERE product_id = $1
+		ORDER BY changed_at, id`,
+		productID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]entity.ProductStatusChange, 0)
+	for rows.Next() {
+		var change entity.ProductStatusChange
+		err := rows.Scan(
+			&change.ID,
+			&change.ProductID,
+			&change.From,
+			&change.To,
+			&change.ChangedAt,
+			&change.ChangedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+const productQuery = `
+	SELECT p.id, p.reception_id, r.pvz_id, p.type, COALESCE(pc.name, ''), COALESCE(p.barcode, ''), p.status,
+		COALESCE(p.pickup_code_hash, ''), p.pickup_code_attempts, p.created_at, p.created_by
+	FROM products p
+	JOIN receptions r ON r.id = p.reception_id
+	LEFT JOIN product_categories pc ON pc.id = p.category_id`
+
+const productByIDQuery = productQuery + `
+	WHERE p.id = $1 AND p.deleted_at IS NULL`
+
+func scanProduct(row pgx.Row) (*entity.Product, error) {
+	var product entity.Product
+	err := row.Scan(
+		&product.ID,
+		&product.ReceptionID,
+		&product.PVZID,
+		&product.Type,
+		&product.Category,
+		&product.Barcode,
+		&product.Status,
+		&product.PickupCodeHash,
+		&product.PickupCodeAttempts,
+		&product.DateTime,
+		&product.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func changeProductStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	product *entity.Product,
+	next entity.ProductStatus,
+	changedBy *uuid.UUID,
+) error {
+	if !product.Status.CanTransitionTo(next) {
+		return entity.ErrInvalidProductStatusTransition
+	}
+	before := *product
+
+	_, err := tx.Exec(ctx, `UPDATE products SET status = $1 WHERE id = $2`, next, product.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update product status: %w", err)
+	}
+
+	prev := product.Status
+	if err := recordProductStatus(ctx, tx, product.ID, &prev, next, changedBy); err != nil {
+		return err
+	}
+
+	err = appendEvent(ctx, tx, builder, entity.EventProductStatusChanged, product.ID, product.PVZID, entity.ProductStatusChangedData{
+		ID:          product.ID,
+		ReceptionID: product.ReceptionID,
+		PVZID:       product.PVZID,
+		From:        prev,
+		To:          next,
+		ChangedBy:   changedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	product.Status = next
+
+	return appendAudit(ctx, tx, builder, auditRecord{
+		action:     entity.AuditProductStatusChanged,
+		entityType: entity.AuditEntityProduct,
+		entityID:   product.ID,
+		pvzID:      product.PVZID,
+		before:     before,
+		after:      *product,
+	})
+}
+
+func recordProductStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID uuid.UUID,
+	from *entity.ProductStatus,
+	to entity.ProductStatus,
+	changedBy *uuid.UUID,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO product_status_history (product_id, from_status, to_status, changed_by)
+		VALUES ($1, $2, $3, $4)`,
+		productID, from, to, changedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record product status: %w", err)
+	}
+	return nil
+}
+
+func (r *ProductRepo) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	var (
+		location    entity.ProductLocation
+		pvzID       uuid.UUID
+		pvzCity     entity.City
+		pvzCreated  time.Time
+		receptionAt time.Time
+	)
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT p.id, p.reception_id, p.type, COALESCE(pc.name, ''), COALESCE(p.barcode, ''), p.status, p.created_at, p.created_by,
+			r.id, r.pvz_id, r.status, r.created_at, r.created_by,
+			pvz.id, pvz.city, pvz.created_at
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvz ON pvz.id = r.pvz_id
+		LEFT JOIN product_categories pc ON pc.id = p.category_id
+		WHERE p.barcode = $1 AND p.deleted_at IS NULL
+		ORDER BY p.status IN ($2, $3) DESC, p.seq DESC
+		LIMIT 1`,
+		barcode, entity.ReceivedProductStatus, entity.StoredProductStatus,
+	).Scan(
+		&location.Product.ID,
+		&location.Product.ReceptionID,
+		&location.Product.Type,
+		&location.Product.Category,
+		&location.Product.Barcode,
+		&location.Product.Status,
+		&location.Product.DateTime,
+		&location.Product.CreatedBy,
+		&location.Reception.ID,
+		&location.Reception.PVZID,
+		&location.Reception.Status,
+		&receptionAt,
+		&location.Reception.CreatedBy,
+		&pvzID,
+		&pvzCity,
+		&pvzCreated,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to find product by barcode: %w", err)
+	}
+
+	location.Product.PVZID = pvzID
+	location.Reception.DateTime = receptionAt
+	location.PVZ = entity.PVZ{
+		ID:               &pvzID,
+		City:             pvzCity,
+		RegistrationDate: &pvzCreated,
+	}
+
+	return &location, nil
+}
+
+func productAddedData(product *entity.Product) entity.ProductAddedData {
+	return entity.ProductAddedData{
+		ID:          product.ID,
+		ReceptionID: product.ReceptionID,
+		PVZID:       product.PVZID,
+		Type:        product.Type,
+		Category:    product.Category,
+		Barcode:     product.Barcode,
+		DateTime:    product.DateTime,
+		CreatedBy:   product.CreatedBy,
+	}
+}
+
+func isBarcodeConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "idx_products_active_barcode"
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -6,9 +6,12 @@ import (
 	"PVZ-avito-tech/internal/pkg/postgres"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/lib/pq"
 	"time"
 )
@@ -46,27 +49,117 @@ func (r *PVZRepo) Create(ctx context.Context, pvz *entity.PVZ) error {
 		return fmt.Errorf("failed to build SQL: %w", err)
 	}
 
-	row := r.Pool.QueryRow(ctx, query, args...)
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, query, args...)
 	var id uuid.UUID
 	var created time.Time
 	if err := row.Scan(&id, &created); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return entity.ErrInvalidCity
+		}
 		return entity.ErrCreatePVZ
 	}
 
+	err = appendEvent(ctx, tx, r.Builder, entity.EventPVZCreated, id, id, entity.PVZCreatedData{
+		ID:               id,
+		City:             pvz.City,
+		RegistrationDate: created,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = appendAudit(ctx, tx, r.Builder, auditRecord{
+		action:     entity.AuditPVZCreated,
+		entityType: entity.AuditEntityPVZ,
+		entityID:   id,
+		pvzID:      id,
+		after:      entity.PVZ{ID: &id, City: pvz.City, RegistrationDate: &created},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	pvz.ID = &id
 	pvz.RegistrationDate = &created
 
 	return nil
 }
 
+func (r *PVZRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.PVZ, error) {
+	var (
+		pvzID   uuid.UUID
+		city    entity.City
+		created time.Time
+	)
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT id, city, created_at
+		FROM pvz
+		WHERE id = $1`,
+		id,
+	).Scan(&pvzID, &city, &created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrPVZNotFound
+		}
+		return nil, fmt.Errorf("failed to get pvz: %w", err)
+	}
+
+	return &entity.PVZ{
+		ID:               &pvzID,
+		City:             city,
+		RegistrationDate: &created,
+	}, nil
+}
+
 func (r *PVZRepo) GetPVZWithReceptions(
 	ctx context.Context,
 	filter dto.ReceptionFilter,
 ) (*[]dto.PVZInfo, error) {
+	sortKey := "pvz.created_at"
+	if filter.Sort == dto.PVZSortLastActivity {
+		sortKey = lastActivityExpr
+	}
+
 	subquery := r.Builder.
-		Select("id", "city", "created_at").
+		Select(
+			"id",
+			"city",
+			"created_at",
+			lastActivityExpr+" AS last_activity",
+			sortKey+" AS sort_key",
+		).
 		From("pvz")
 
+	if len(filter.Cities) > 0 {
+		subquery = subquery.Where(sq.Eq{"city": filter.Cities})
+	}
+
+	if filter.HasActiveReception != nil {
+		activeQuery := "EXISTS (SELECT 1 FROM receptions ar WHERE ar.pvz_id = pvz.id AND ar.status = ?)"
+		if !*filter.HasActiveReception {
+			activeQuery = "NOT " + activeQuery
+		}
+		subquery = subquery.Where(sq.Expr(activeQuery, entity.InProgressStatus))
+	}
+
+	if filter.ProductType != "" {
+		subquery = subquery.Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM receptions tr JOIN products tp ON tp.reception_id = tr.id WHERE tr.pvz_id = pvz.id AND tp.type = ? AND tp.deleted_at IS NULL)",
+			filter.ProductType,
+		))
+	}
+
 	if !filter.StartDate.IsZero() || !filter.EndDate.IsZero() {
 		existsQuery := "EXISTS (SELECT 1 FROM receptions r WHERE r.pvz_id = pvz.id"
 		var existsArgs []interface{}
@@ -83,25 +176,39 @@ func (r *PVZRepo) GetPVZWithReceptions(
 	}
 
 	subquery = subquery.
-		OrderBy("created_at DESC").
-		Limit(uint64(filter.Limit)).
-		Offset(uint64((filter.Page - 1) * filter.Limit))
+		OrderBy("sort_key DESC", "id DESC").
+		Limit(uint64(filter.Limit))
+
+	if filter.CursorMode {
+		if filter.After != nil {
+			subquery = subquery.Where(sq.Expr("("+sortKey+", pvz.id) < (?, ?)", filter.After.CreatedAt, filter.After.ID))
+		}
+	} else {
+		subquery = subquery.Offset(uint64((filter.Page - 1) * filter.Limit))
+	}
 
 	baseQuery := r.Builder.
 		Select(
 			"paginated_pvz.id AS pvz_id",
 			"paginated_pvz.city AS pvz_city",
 			"paginated_pvz.created_at AS pvz_created_at",
+			"paginated_pvz.last_activity AS pvz_last_activity",
 			"r.id AS reception_id",
 			"r.created_at AS reception_created_at",
 			"r.status AS reception_status",
+			"r.created_by AS reception_created_by",
 			"p.id AS product_id",
 			"p.type AS product_type",
+			"p.status AS product_status",
+			"p.barcode AS product_barcode",
 			"p.created_at AS product_created_at",
+			"p.created_by AS product_created_by",
+			"pc.name AS product_category",
 		).
 		FromSelect(subquery, "paginated_pvz").
 		LeftJoin("receptions r ON paginated_pvz.id = r.pvz_id").
-		LeftJoin("products p ON r.id = p.reception_id")
+		LeftJoin("products p ON r.id = p.reception_id AND p.deleted_at IS NULL").
+		LeftJoin("product_categories pc ON pc.id = p.category_id")
 
 	var conditions sq.And
 	if !filter.StartDate.IsZero() {
@@ -115,20 +222,21 @@ func (r *PVZRepo) GetPVZWithReceptions(
 	}
 
 	baseQuery = baseQuery.
-		OrderBy("paginated_pvz.created_at DESC", "r.created_at DESC", "p.created_at DESC")
+		OrderBy("paginated_pvz.sort_key DESC", "paginated_pvz.id DESC", "r.created_at DESC", "p.seq DESC")
 
 	query, args, err := baseQuery.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	rows, err := r.Pool.Query(ctx, query, args...)
+	rows, err := r.Conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
 	}
 	defer rows.Close()
 
 	pvzMap := make(map[uuid.UUID]*dto.PVZInfo)
+	pvzOrder := make([]uuid.UUID, 0, filter.Limit)
 	receptionMap := make(map[uuid.UUID]*dto.ReceptionGroup)
 
 	for rows.Next() {
@@ -136,24 +244,36 @@ func (r *PVZRepo) GetPVZWithReceptions(
 			pvzID           uuid.UUID
 			pvzCity         entity.City
 			pvzCreatedAt    time.Time
+			pvzLastActivity time.Time
 			receptionID     uuid.NullUUID
 			receptionDate   pq.NullTime
 			receptionStatus sql.NullString
+			receptionAuthor uuid.NullUUID
 			productID       uuid.NullUUID
 			productType     sql.NullString
+			productStatus   sql.NullString
+			productBarcode  sql.NullString
 			productDate     pq.NullTime
+			productAuthor   uuid.NullUUID
+			productCategory sql.NullString
 		)
 
 		err := rows.Scan(
 			&pvzID,
 			&pvzCity,
 			&pvzCreatedAt,
+			&pvzLastActivity,
 			&receptionID,
 			&receptionDate,
 			&receptionStatus,
+			&receptionAuthor,
 			&productID,
 			&productType,
+			&productStatus,
+			&productBarcode,
 			&productDate,
+			&productAuthor,
+			&productCategory,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
@@ -165,9 +285,11 @@ func (r *PVZRepo) GetPVZWithReceptions(
 					ID:               pvzID,
 					City:             pvzCity,
 					RegistrationDate: pvzCreatedAt,
+					LastActivity:     pvzLastActivity,
 				},
 				Receptions: []*dto.ReceptionGroup{},
 			}
+			pvzOrder = append(pvzOrder, pvzID)
 		}
 
 		if receptionID.Valid {
@@ -175,10 +297,11 @@ func (r *PVZRepo) GetPVZWithReceptions(
 			if _, exists := receptionMap[receptionKey]; !exists {
 				newReception := &dto.ReceptionGroup{
 					Reception: dto.ReceptionWithProducts{
-						ID:       receptionID.UUID,
-						DateTime: receptionDate.Time,
-						PVZID:    pvzID,
-						Status:   entity.ReceptionsStatus(receptionStatus.String),
+						ID:        receptionID.UUID,
+						DateTime:  receptionDate.Time,
+						PVZID:     pvzID,
+						Status:    entity.ReceptionsStatus(receptionStatus.String),
+						CreatedBy: nullUUIDPtr(receptionAuthor),
 					},
 					Products: []dto.ProductDTO{},
 				}
@@ -195,6 +318,10 @@ func (r *PVZRepo) GetPVZWithReceptions(
 					DateTime:    productDate.Time,
 					Type:        entity.ProductType(productType.String),
 					ReceptionID: receptionID.UUID,
+					Category:    productCategory.String,
+					Status:      entity.ProductStatus(productStatus.String),
+					Barcode:     productBarcode.String,
+					CreatedBy:   nullUUIDPtr(productAuthor),
 				}
 				receptionMap[receptionKey].Products = append(
 					receptionMap[receptionKey].Products,
@@ -205,8 +332,9 @@ func (r *PVZRepo) GetPVZWithReceptions(
 		}
 	}
 
-	result := make([]dto.PVZInfo, 0, len(pvzMap))
-	for _, pvz := range pvzMap {
+	result := make([]dto.PVZInfo, 0, len(pvzOrder))
+	for _, id := range pvzOrder {
+		pvz := pvzMap[id]
 		result = append(result, dto.PVZInfo{
 			PVZ:        pvz.PVZ,
 			Receptions: pvz.Receptions,
@@ -215,3 +343,16 @@ func (r *PVZRepo) GetPVZWithReceptions(
 
 	return &result, rows.Err()
 }
+
+const lastActivityExpr = `GREATEST(
+	pvz.created_at,
+	(SELECT MAX(lr.created_at) FROM receptions lr WHERE lr.pvz_id = pvz.id),
+	(SELECT MAX(lp.created_at) FROM products lp JOIN receptions lr ON lr.id = lp.reception_id WHERE lr.pvz_id = pvz.id AND lp.deleted_at IS NULL)
+)`
+
+func nullUUIDPtr(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
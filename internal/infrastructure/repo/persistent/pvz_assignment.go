@@ -0,0 +1,107 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PVZAssignmentRepo struct {
+	*postgres.Postgres
+}
+
+func NewPVZAssignmentRepo(pg *postgres.Postgres) *PVZAssignmentRepo {
+	return &PVZAssignmentRepo{pg}
+}
+
+func (r *PVZAssignmentRepo) Assign(ctx context.Context, a *entity.PVZAssignment) error {
+	query := `
+		INSERT INTO pvz_assignments (user_id, pvz_id, assigned_by)
+		SELECT u.id, $2, $3
+		FROM users u
+		WHERE u.id = $1 AND u.role = $4
+		ON CONFLICT (user_id, pvz_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING user_id, pvz_id, assigned_by, assigned_at
+	`
+
+	err := r.Conn(ctx).QueryRow(ctx, query, a.UserID, a.PVZID, a.AssignedBy, entity.UserRoleEmployee).Scan(
+		&a.UserID,
+		&a.PVZID,
+		&a.AssignedBy,
+		&a.AssignedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrEmployeeNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return entity.ErrPVZNotFound
+		}
+		return fmt.Errorf("failed to assign employee: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PVZAssignmentRepo) Unassign(ctx context.Context, userID, pvzID uuid.UUID) error {
+	tag, err := r.Conn(ctx).Exec(ctx, `
+		DELETE FROM pvz_assignments
+		WHERE user_id = $1 AND pvz_id = $2`,
+		userID, pvzID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unassign employee: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (r *PVZAssignmentRepo) IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	var assigned bool
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pvz_assignments
+			WHERE user_id = $1 AND pvz_id = $2
+		)`,
+		userID, pvzID,
+	).Scan(&assigned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check assignment: %w", err)
+	}
+
+	return assigned, nil
+}
+
+func (r *PVZAssignmentRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT user_id, pvz_id, assigned_by, assigned_at
+		FROM pvz_assignments
+		WHERE pvz_id = $1
+		ORDER BY assigned_at`,
+		pvzID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := make([]entity.PVZAssignment, 0)
+	for rows.Next() {
+		var a entity.PVZAssignment
+		if err := rows.Scan(&a.UserID, &a.PVZID, &a.AssignedBy, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	return assignments, rows.Err()
+}
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"time"
 )
 
 type ReceptionRepo struct {
@@ -19,21 +21,24 @@ func NewReceptionRepo(pool *postgres.Postgres) *ReceptionRepo {
 	return &ReceptionRepo{pool}
 }
 
-func (r *ReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
-	query := `
-		INSERT INTO receptions (pvz_id, status)
-		VALUES ($1, $2)
-		RETURNING id, pvz_id, status, created_at
-	`
-
-	var reception entity.Reception
-	err := r.Pool.QueryRow(ctx, query, pvzID, entity.InProgressStatus).Scan(
-		&reception.ID,
-		&reception.PVZID,
-		&reception.Status,
-		&reception.DateTime,
-	)
+func (r *ReceptionRepo) CreateReception(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	createdBy *uuid.UUID,
+	manifest []entity.ManifestItem,
+) (*entity.Reception, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
+	reception, err := scanReception(tx.QueryRow(ctx, `
+		INSERT INTO receptions (pvz_id, status, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING `+receptionColumns,
+		pvzID, entity.InProgressStatus, createdBy,
+	))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -47,32 +52,73 @@ func (r *ReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID) (*
 		return nil, fmt.Errorf("failed to create reception: %w", err)
 	}
 
-	return &reception, nil
-}
+	err = recordReceptionStatus(ctx, tx, reception.ID, nil, entity.InProgressStatus, "", createdBy)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *ReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
-	query := `
-		UPDATE receptions 
-		SET status = $1 
-		WHERE pvz_id = $2 
-		AND status = $3
-		RETURNING id, pvz_id, status, created_at
-	`
+	if len(manifest) > 0 {
+		insert := r.Builder.
+			Insert("reception_manifest_items").
+			Columns("reception_id", "barcode", "type", "quantity")
+		for _, item := range manifest {
+			insert = insert.Values(reception.ID, nullString(item.Barcode), item.Type, item.Quantity)
+		}
 
-	var reception entity.Reception
-	err := r.Pool.QueryRow(
-		ctx,
-		query,
-		entity.CloseStatus,
-		pvzID,
-		entity.InProgressStatus,
-	).Scan(
-		&reception.ID,
-		&reception.PVZID,
-		&reception.Status,
-		&reception.DateTime,
-	)
+		sqlQuery, args, err := insert.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, sqlQuery, args...); err != nil {
+			return nil, fmt.Errorf("failed to save reception manifest: %w", err)
+		}
+		reception.Manifest = manifest
+	}
+
+	err = appendEvent(ctx, tx, r.Builder, entity.EventReceptionCreated, reception.ID, reception.PVZID, entity.ReceptionCreatedData{
+		ID:            reception.ID,
+		PVZID:         reception.PVZID,
+		Status:        reception.Status,
+		DateTime:      reception.DateTime,
+		CreatedBy:     reception.CreatedBy,
+		ManifestItems: len(manifest),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = appendAudit(ctx, tx, r.Builder, auditRecord{
+		action:     entity.AuditReceptionCreated,
+		entityType: entity.AuditEntityReception,
+		entityID:   reception.ID,
+		pvzID:      reception.PVZID,
+		after:      reception,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reception, nil
+}
+
+func (r *ReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID, closedBy *uuid.UUID) (*entity.Reception, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
+	reception, err := scanReception(tx.QueryRow(ctx, `
+		SELECT `+receptionColumns+`
+		FROM receptions
+		WHERE pvz_id = $1 AND status = $2
+		FOR UPDATE`,
+		pvzID, entity.InProgressStatus,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrNoActiveReception
@@ -80,42 +126,646 @@ func (r *ReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUI
 		return nil, fmt.Errorf("failed to close reception: %w", err)
 	}
 
-	return &reception, nil
+	if err := closeReception(ctx, tx, r.Builder, reception, "", closedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reception, nil
 }
 
-func (r *ReceptionRepo) CloseActiveReceptionWithTx(
+func (r *ReceptionRepo) ReopenReception(
 	ctx context.Context,
-	tx pgx.Tx,
-	pvzID uuid.UUID,
+	id uuid.UUID,
+	window time.Duration,
+	reopenedBy *uuid.UUID,
 ) (*entity.Reception, error) {
-	query := `
-		UPDATE receptions 
-		SET status = $1 
-		WHERE pvz_id = $2 
-		AND status = $3
-		RETURNING id, pvz_id, status, created_at
-	`
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	var reception entity.Reception
-	err := tx.QueryRow(
-		ctx,
-		query,
-		entity.CloseStatus,
-		pvzID,
+	reception, err := lockReception(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reception.Status.CanTransitionTo(entity.InProgressStatus) {
+		return nil, entity.ErrInvalidReceptionStatusTransition
+	}
+	if !reception.CanReopen(time.Now(), window) {
+		return nil, entity.ErrReceptionReopenWindowExpired
+	}
+	products, err := lockReceptionProducts(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !productsInStorage(products) {
+		return nil, entity.ErrReceptionProductsLeftStorage
+	}
+
+	err = changeReceptionStatus(ctx, tx, r.Builder, reception, entity.InProgressStatus, "", reopenedBy)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, entity.ErrReceptionConflict
+		}
+		return nil, err
+	}
+
+	err = moveReceptionProducts(ctx, tx, r.Builder, reception, products, entity.StoredProductStatus, entity.ReceivedProductStatus, reopenedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reception, nil
+}
+
+func (r *ReceptionRepo) CancelReception(
+	ctx context.Context,
+	id uuid.UUID,
+	reason string,
+	cancelledBy *uuid.UUID,
+) (*entity.Reception, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	reception, err := lockReception(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := changeReceptionStatus(ctx, tx, r.Builder, reception, entity.CancelledStatus, reason, cancelledBy); err != nil {
+		return nil, err
+	}
+	products, err := lockReceptionProducts(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !productsInStorage(products) {
+		return nil, entity.ErrReceptionProductsLeftStorage
+	}
+	if err := voidReceptionProducts(ctx, tx, r.Builder, reception, products, reason, cancelledBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return reception, nil
+}
+
+// staleReceptionsLockKey serialises stale reception sweeps across app replicas.
+const staleReceptionsLockKey = 7_201_604
+
+const receptionOpenedAtExpr = `COALESCE(
+	(SELECT MAX(h.changed_at) FROM reception_status_history h WHERE h.reception_id = r.id AND h.to_status = 'in_progress'),
+	r.created_at)`
+
+func (r *ReceptionRepo) CloseStaleReceptions(
+	ctx context.Context,
+	idleFor time.Duration,
+	limit int,
+	reason string,
+) ([]entity.Reception, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, staleReceptionsLockKey).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("failed to acquire sweep lock: %w", err)
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM receptions r
+		WHERE r.status = $1
+		AND GREATEST(`+receptionOpenedAtExpr+`,
+			(SELECT MAX(p.created_at) FROM products p WHERE p.reception_id = r.id)
+		) < NOW() - make_interval(secs => $2)
+		ORDER BY r.created_at
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`,
+		entity.InProgressStatus, idleFor.Seconds(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale receptions: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale receptions: %w", err)
+	}
+
+	closed := make([]entity.Reception, 0, len(ids))
+	for _, id := range ids {
+		reception, err := lockReception(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := closeReception(ctx, tx, r.Builder, reception, reason, nil); err != nil {
+			return nil, err
+		}
+		closed = append(closed, *reception)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return closed, nil
+}
+
+func (r *ReceptionRepo) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	var stats entity.OpenReceptionStats
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT COUNT(*), MIN(`+receptionOpenedAtExpr+`)
+		FROM receptions r
+		WHERE r.status = $1`,
 		entity.InProgressStatus,
-	).Scan(
+	).Scan(&stats.Count, &stats.OldestOpenedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reception stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+func (r *ReceptionRepo) GetDiscrepancyReport(ctx context.Context, receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	var report entity.DiscrepancyReport
+	var createdAt time.Time
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT report, created_at
+		FROM reception_discrepancy_reports
+		WHERE reception_id = $1`,
+		receptionID,
+	).Scan(&report, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrDiscrepancyReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get discrepancy report: %w", err)
+	}
+
+	report.CreatedAt = createdAt
+	return &report, nil
+}
+
+func (r *ReceptionRepo) ListStatusHistory(ctx context.Context, receptionID uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT id, reception_id, from_status, to_status, COALESCE(reason, ''), changed_at, changed_by
+		FROM reception_status_history
+		WHERE reception_id = $1
+		ORDER BY changed_at, id`,
+		receptionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reception status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]entity.ReceptionStatusChange, 0)
+	for rows.Next() {
+		var change entity.ReceptionStatusChange
+		err := rows.Scan(
+			&change.ID,
+			&change.ReceptionID,
+			&change.From,
+			&change.To,
+			&change.Reason,
+			&change.ChangedAt,
+			&change.ChangedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+func closeReception(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	reception *entity.Reception,
+	reason string,
+	closedBy *uuid.UUID,
+) error {
+	if err := changeReceptionStatus(ctx, tx, builder, reception, entity.CloseStatus, reason, closedBy); err != nil {
+		return err
+	}
+
+	products, err := lockReceptionProducts(ctx, tx, reception.ID)
+	if err != nil {
+		return err
+	}
+	err = moveReceptionProducts(ctx, tx, builder, reception, products, entity.ReceivedProductStatus, entity.StoredProductStatus, closedBy)
+	if err != nil {
+		return err
+	}
+
+	report, err := storeDiscrepancyReport(ctx, tx, reception.ID)
+	if err != nil {
+		return err
+	}
+	reception.DiscrepancyReport = report
+
+	return nil
+}
+
+func storeDiscrepancyReport(ctx context.Context, tx pgx.Tx, receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT COALESCE(barcode, ''), type, quantity
+		FROM reception_manifest_items
+		WHERE reception_id = $1`,
+		receptionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reception manifest: %w", err)
+	}
+	manifest, err := pgx.CollectRows(rows, pgx.RowToStructByPos[entity.ManifestItem])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reception manifest: %w", err)
+	}
+	if len(manifest) == 0 {
+		return nil, nil
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT id, type, COALESCE(barcode, '')
+		FROM products
+		WHERE reception_id = $1 AND deleted_at IS NULL
+		ORDER BY seq`,
+		receptionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reception products: %w", err)
+	}
+	products, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Product, error) {
+		var p entity.Product
+		err := row.Scan(&p.ID, &p.Type, &p.Barcode)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reception products: %w", err)
+	}
+
+	report := entity.BuildDiscrepancyReport(receptionID, manifest, products)
+	err = tx.QueryRow(ctx, `
+		INSERT INTO reception_discrepancy_reports (reception_id, report)
+		VALUES ($1, $2)
+		ON CONFLICT (reception_id) DO UPDATE SET report = EXCLUDED.report, created_at = NOW()
+		RETURNING created_at`,
+		receptionID, report,
+	).Scan(&report.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save discrepancy report: %w", err)
+	}
+
+	return report, nil
+}
+
+// moveReceptionProducts moves the given products that are in status from to
+// status to, recording the history, a status change event and an audit entry
+// per product.
+func moveReceptionProducts(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	reception *entity.Reception,
+	products []entity.Product,
+	from, to entity.ProductStatus,
+	changedBy *uuid.UUID,
+) error {
+	ids := make([]uuid.UUID, 0, len(products))
+	events := make([]entity.Event, 0, len(products))
+	audit := make([]auditRecord, 0, len(products))
+	for _, product := range products {
+		if product.Status != from {
+			continue
+		}
+		ids = append(ids, product.ID)
+
+		event, err := entity.NewEvent(entity.EventProductStatusChanged, product.ID, reception.PVZID, entity.ProductStatusChangedData{
+			ID:          product.ID,
+			ReceptionID: reception.ID,
+			PVZID:       reception.PVZID,
+			From:        from,
+			To:          to,
+			ChangedBy:   changedBy,
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+
+		after := product
+		after.Status = to
+		audit = append(audit, auditRecord{
+			action:     entity.AuditProductStatusChanged,
+			entityType: entity.AuditEntityProduct,
+			entityID:   product.ID,
+			pvzID:      reception.PVZID,
+			before:     product,
+			after:      after,
+		})
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		WITH moved AS (
+			UPDATE products
+			SET status = $2
+			WHERE id = ANY($1)
+			RETURNING id
+		)
+		INSERT INTO product_status_history (product_id, from_status, to_status, changed_by)
+		SELECT id, $3, $2, $4 FROM moved`,
+		ids, to, from, changedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to move reception products to %s: %w", to, err)
+	}
+
+	if err := appendEvents(ctx, tx, builder, events); err != nil {
+		return err
+	}
+	return appendAudit(ctx, tx, builder, audit...)
+}
+
+// voidReceptionProducts soft-deletes the given products of a cancelled
+// reception, recording a deletion event and an audit entry per product.
+func voidReceptionProducts(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	reception *entity.Reception,
+	products []entity.Product,
+	reason string,
+	deletedBy *uuid.UUID,
+) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	var deletedAt time.Time
+	err := tx.QueryRow(ctx, `
+		WITH voided AS (
+			UPDATE products
+			SET deleted_at = NOW(), deleted_by = $2, deletion_reason = $3
+			WHERE id = ANY($1)
+			RETURNING deleted_at
+		)
+		SELECT MAX(deleted_at) FROM voided`,
+		ids, deletedBy, reason,
+	).Scan(&deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to void reception products: %w", err)
+	}
+
+	events := make([]entity.Event, 0, len(products))
+	audit := make([]auditRecord, 0, len(products))
+	for _, product := range products {
+		event, err := entity.NewEvent(entity.EventProductDeleted, product.ID, reception.PVZID, entity.ProductDeletedData{
+			ID:          product.ID,
+			ReceptionID: reception.ID,
+			PVZID:       reception.PVZID,
+			Reason:      reason,
+			DeletedBy:   deletedBy,
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+
+		audit = append(audit, auditRecord{
+			action:     entity.AuditProductDeleted,
+			entityType: entity.AuditEntityProduct,
+			entityID:   product.ID,
+			pvzID:      reception.PVZID,
+			before:     product,
+			after: entity.ProductDeletion{
+				ProductID:   product.ID,
+				ReceptionID: reception.ID,
+				Reason:      reason,
+				DeletedAt:   deletedAt,
+				DeletedBy:   deletedBy,
+			},
+		})
+	}
+
+	if err := appendEvents(ctx, tx, builder, events); err != nil {
+		return err
+	}
+	return appendAudit(ctx, tx, builder, audit...)
+}
+
+// lockReceptionProducts locks the live products of a reception and returns them
+// in acceptance order.
+func lockReceptionProducts(ctx context.Context, tx pgx.Tx, receptionID uuid.UUID) ([]entity.Product, error) {
+	rows, err := tx.Query(ctx, productQuery+`
+		WHERE p.reception_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.seq
+		FOR UPDATE OF p`,
+		receptionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock reception products: %w", err)
+	}
+	defer rows.Close()
+
+	products := make([]entity.Product, 0)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock reception products: %w", err)
+		}
+		products = append(products, *product)
+	}
+	return products, rows.Err()
+}
+
+// productsInStorage reports whether none of the products has been issued or
+// returned yet: reopening or cancelling their reception would otherwise rewrite
+// their history.
+func productsInStorage(products []entity.Product) bool {
+	for _, product := range products {
+		if product.Status != entity.ReceivedProductStatus && product.Status != entity.StoredProductStatus {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ReceptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	reception, err := scanReception(r.Conn(ctx).QueryRow(ctx, `
+		SELECT `+receptionColumns+`
+		FROM receptions
+		WHERE id = $1`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrReceptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get reception: %w", err)
+	}
+
+	return reception, nil
+}
+
+func (r *ReceptionRepo) GetActiveByPVZ(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+	reception, err := scanReception(r.Conn(ctx).QueryRow(ctx, `
+		SELECT `+receptionColumns+`
+		FROM receptions
+		WHERE pvz_id = $1 AND status = $2`,
+		pvzID, entity.InProgressStatus,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNoActiveReception
+		}
+		return nil, fmt.Errorf("failed to get active reception: %w", err)
+	}
+
+	return reception, nil
+}
+
+const receptionColumns = "id, pvz_id, status, created_at, closed_at, COALESCE(cancellation_reason, ''), created_by"
+
+func scanReception(row pgx.Row) (*entity.Reception, error) {
+	var reception entity.Reception
+	err := row.Scan(
 		&reception.ID,
 		&reception.PVZID,
 		&reception.Status,
 		&reception.DateTime,
+		&reception.ClosedAt,
+		&reception.CancellationReason,
+		&reception.CreatedBy,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
 
+func lockReception(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*entity.Reception, error) {
+	reception, err := scanReception(tx.QueryRow(ctx, `
+		SELECT `+receptionColumns+`
+		FROM receptions
+		WHERE id = $1
+		FOR UPDATE`,
+		id,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, entity.ErrNoActiveReception
+			return nil, entity.ErrReceptionNotFound
 		}
-		return nil, fmt.Errorf("failed to close reception: %w", err)
+		return nil, fmt.Errorf("failed to get reception: %w", err)
 	}
+	return reception, nil
+}
 
-	return &reception, nil
+func changeReceptionStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	builder sq.StatementBuilderType,
+	reception *entity.Reception,
+	next entity.ReceptionsStatus,
+	reason string,
+	changedBy *uuid.UUID,
+) error {
+	if !reception.Status.CanTransitionTo(next) {
+		return entity.ErrInvalidReceptionStatusTransition
+	}
+	before := *reception
+
+	err := tx.QueryRow(ctx, `
+		UPDATE receptions
+		SET status = $1,
+			closed_at = CASE WHEN $1 = 'close' THEN NOW() WHEN $1 = 'in_progress' THEN NULL ELSE closed_at END,
+			cancellation_reason = CASE WHEN $1 = 'cancelled' THEN $3 ELSE cancellation_reason END
+		WHERE id = $2
+		RETURNING closed_at`,
+		next, reception.ID, nullString(reason),
+	).Scan(&reception.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update reception status: %w", err)
+	}
+
+	prev := reception.Status
+	if err := recordReceptionStatus(ctx, tx, reception.ID, &prev, next, reason, changedBy); err != nil {
+		return err
+	}
+
+	err = appendEvent(ctx, tx, builder, entity.ReceptionStatusEvent(next), reception.ID, reception.PVZID, entity.ReceptionStatusChangedData{
+		ID:        reception.ID,
+		PVZID:     reception.PVZID,
+		From:      prev,
+		To:        next,
+		Reason:    reason,
+		ChangedBy: changedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	reception.Status = next
+	if next == entity.CancelledStatus {
+		reception.CancellationReason = reason
+	}
+
+	return appendAudit(ctx, tx, builder, auditRecord{
+		action:     entity.ReceptionStatusAuditAction(next),
+		entityType: entity.AuditEntityReception,
+		entityID:   reception.ID,
+		pvzID:      reception.PVZID,
+		before:     before,
+		after:      *reception,
+	})
+}
+
+func recordReceptionStatus(
+	ctx context.Context,
+	tx pgx.Tx,
+	receptionID uuid.UUID,
+	from *entity.ReceptionsStatus,
+	to entity.ReceptionsStatus,
+	reason string,
+	changedBy *uuid.UUID,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO reception_status_history (reception_id, from_status, to_status, reason, changed_by)
+		VALUES ($1, $2, $3, $4, $5)`,
+		receptionID, from, to, nullString(reason), changedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record reception status: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,73 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type ReportRepo struct {
+	*postgres.Postgres
+}
+
+func NewReportRepo(pg *postgres.Postgres) *ReportRepo {
+	return &ReportRepo{pg}
+}
+
+// AcceptanceReport counts the products accepted in [From, To) per PVZ, UTC day
+// and product type. Only products of closed receptions are counted; deleted
+// products, including those of cancelled receptions, are not.
+func (r *ReportRepo) AcceptanceReport(
+	ctx context.Context,
+	filter entity.AcceptanceReportFilter,
+) ([]entity.AcceptanceReportRow, error) {
+	query := r.Builder.
+		Select(
+			"pvz.id",
+			"pvz.city",
+			"to_char(p.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day",
+			"p.type",
+			"COUNT(*)",
+		).
+		From("products p").
+		Join("receptions r ON r.id = p.reception_id").
+		Join("pvz ON pvz.id = r.pvz_id").
+		Where("p.deleted_at IS NULL").
+		Where("r.status = ?", entity.CloseStatus).
+		Where("p.created_at >= ?", filter.From).
+		Where("p.created_at < ?", filter.To).
+		GroupBy("pvz.id", "pvz.city", "day", "p.type").
+		OrderBy("pvz.city", "pvz.id", "day", "p.type")
+
+	if filter.PVZID != nil {
+		query = query.Where("pvz.id = ?", *filter.PVZID)
+	}
+	if len(filter.Cities) > 0 {
+		query = query.Where(sq.Eq{"pvz.city": filter.Cities})
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acceptance report: %w", err)
+	}
+	defer rows.Close()
+
+	report := make([]entity.AcceptanceReportRow, 0)
+	for rows.Next() {
+		var row entity.AcceptanceReportRow
+		if err := rows.Scan(&row.PVZID, &row.City, &row.Day, &row.ProductType, &row.Accepted); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		report = append(report, row)
+	}
+
+	return report, rows.Err()
+}
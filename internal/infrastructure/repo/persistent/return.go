@@ -0,0 +1,286 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+type ReturnRepo struct {
+	*postgres.Postgres
+}
+
+func NewReturnRepo(pg *postgres.Postgres) *ReturnRepo {
+	return &ReturnRepo{pg}
+}
+
+func (r *ReturnRepo) CreateReturn(ctx context.Context, ret *entity.Return) (*entity.Return, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	product, err := scanProduct(tx.QueryRow(ctx, productByIDQuery+" FOR UPDATE OF p", ret.ProductID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product.PVZID != ret.PVZID {
+		return nil, entity.ErrProductNotFound
+	}
+
+	if err := changeProductStatus(ctx, tx, r.Builder, product, entity.ReturnedProductStatus, ret.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	created, err := scanReturn(tx.QueryRow(ctx, `
+		INSERT INTO returns (product_id, pvz_id, reason, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+returnColumns,
+		ret.ProductID, ret.PVZID, ret.Reason, ret.CreatedBy,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, entity.ErrInvalidProductStatusTransition
+		}
+		return nil, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *ReturnRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error) {
+	query := r.Builder.
+		Select(
+			"ret.id",
+			"ret.product_id",
+			"ret.pvz_id",
+			"ret.reason",
+			"ret.shipment_id",
+			"ret.created_at",
+			"ret.created_by",
+		).
+		From("returns ret").
+		LeftJoin("return_shipments rs ON rs.id = ret.shipment_id").
+		Where("ret.pvz_id = ?", pvzID).
+		OrderBy("ret.created_at")
+
+	if outstandingOnly {
+		query = query.Where("(rs.id IS NULL OR rs.status <> ?)", entity.CloseStatus)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list returns: %w", err)
+	}
+	defer rows.Close()
+
+	returns := make([]entity.Return, 0)
+	for rows.Next() {
+		ret, err := scanReturn(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		returns = append(returns, *ret)
+	}
+
+	return returns, rows.Err()
+}
+
+func (r *ReturnRepo) OpenShipment(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID) (*entity.ReturnShipment, error) {
+	shipment, err := scanShipment(r.Conn(ctx).QueryRow(ctx, `
+		INSERT INTO return_shipments (pvz_id, status, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING `+shipmentColumns,
+		pvzID, entity.InProgressStatus, createdBy,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, entity.ErrReturnShipmentConflict
+			case "23503":
+				return nil, entity.ErrPVZNotFound
+			}
+		}
+		return nil, fmt.Errorf("failed to open return shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+func (r *ReturnRepo) AddToActiveShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var shipmentID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM return_shipments
+		WHERE pvz_id = $1 AND status = $2
+		LIMIT 1 FOR UPDATE`,
+		pvzID, entity.InProgressStatus,
+	).Scan(&shipmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNoActiveReturnShipment
+		}
+		return nil, fmt.Errorf("failed to get active return shipment: %w", err)
+	}
+
+	var current uuid.NullUUID
+	err = tx.QueryRow(ctx, `
+		SELECT shipment_id FROM returns
+		WHERE id = $1 AND pvz_id = $2
+		FOR UPDATE`,
+		returnID, pvzID,
+	).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	if current.Valid {
+		return nil, entity.ErrReturnAlreadyShipped
+	}
+
+	ret, err := scanReturn(tx.QueryRow(ctx, `
+		UPDATE returns SET shipment_id = $1
+		WHERE id = $2
+		RETURNING `+returnColumns,
+		shipmentID, returnID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add return to shipment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (r *ReturnRepo) CloseActiveShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error) {
+	shipment, err := scanShipment(r.Conn(ctx).QueryRow(ctx, `
+		UPDATE return_shipments
+		SET status = $1, closed_at = NOW()
+		WHERE pvz_id = $2 AND status = $3
+		RETURNING `+shipmentColumns,
+		entity.CloseStatus, pvzID, entity.InProgressStatus,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNoActiveReturnShipment
+		}
+		return nil, fmt.Errorf("failed to close return shipment: %w", err)
+	}
+
+	return shipment, nil
+}
+
+func (r *ReturnRepo) Stats(ctx context.Context, from, to time.Time) ([]entity.ReturnStats, error) {
+	query := r.Builder.
+		Select(
+			"pvz.id",
+			"pvz.city",
+			"COUNT(ret.id)",
+			"COUNT(ret.id) FILTER (WHERE rs.id IS NULL OR rs.status <> 'close')",
+			"COUNT(ret.id) FILTER (WHERE rs.status = 'close')",
+		).
+		From("pvz").
+		Join("returns ret ON ret.pvz_id = pvz.id").
+		LeftJoin("return_shipments rs ON rs.id = ret.shipment_id").
+		GroupBy("pvz.id", "pvz.city").
+		OrderBy("COUNT(ret.id) DESC", "pvz.id")
+
+	if !from.IsZero() {
+		query = query.Where("ret.created_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("ret.created_at <= ?", to)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]entity.ReturnStats, 0)
+	for rows.Next() {
+		var s entity.ReturnStats
+		if err := rows.Scan(&s.PVZID, &s.City, &s.Total, &s.Outstanding, &s.Shipped); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+const (
+	returnColumns   = "id, product_id, pvz_id, reason, shipment_id, created_at, created_by"
+	shipmentColumns = "id, pvz_id, status, created_at, closed_at, created_by"
+)
+
+func scanReturn(row pgx.Row) (*entity.Return, error) {
+	var ret entity.Return
+	err := row.Scan(
+		&ret.ID,
+		&ret.ProductID,
+		&ret.PVZID,
+		&ret.Reason,
+		&ret.ShipmentID,
+		&ret.DateTime,
+		&ret.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func scanShipment(row pgx.Row) (*entity.ReturnShipment, error) {
+	var shipment entity.ReturnShipment
+	err := row.Scan(
+		&shipment.ID,
+		&shipment.PVZID,
+		&shipment.Status,
+		&shipment.DateTime,
+		&shipment.ClosedAt,
+		&shipment.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
@@ -0,0 +1,135 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type RefreshTokenRepo struct {
+	*postgres.Postgres
+}
+
+func NewRefreshTokenRepo(pg *postgres.Postgres) *RefreshTokenRepo {
+	return &RefreshTokenRepo{pg}
+}
+
+func (r *RefreshTokenRepo) Create(ctx context.Context, t *entity.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, role, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := r.Conn(ctx).QueryRow(ctx, query, t.UserID, t.Role, t.TokenHash, t.ExpiresAt).
+		Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepo) Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `
+		UPDATE refresh_tokens rt
+		SET revoked_at = NOW()
+		FROM users u
+		WHERE u.id = rt.user_id
+		AND rt.token_hash = $1
+		AND rt.revoked_at IS NULL
+		AND rt.expires_at > NOW()
+		RETURNING rt.id, rt.user_id, u.email, rt.role, rt.token_hash, rt.expires_at, rt.revoked_at, rt.created_at
+	`
+
+	var t entity.RefreshToken
+	err := r.Conn(ctx).QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Email,
+		&t.Role,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.RevokedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to consume refresh token: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *RefreshTokenRepo) Revoke(ctx context.Context, tokenHash string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE token_hash = $1
+		AND revoked_at IS NULL
+	`
+	tag, err := r.Conn(ctx).Exec(ctx, query, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+type RevokedTokenRepo struct {
+	*postgres.Postgres
+}
+
+func NewRevokedTokenRepo(pg *postgres.Postgres) *RevokedTokenRepo {
+	return &RevokedTokenRepo{pg}
+}
+
+func (r *RevokedTokenRepo) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING`,
+		tokenID, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired revocations: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RevokedTokenRepo) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var revoked bool
+	err := r.Conn(ctx).QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`,
+		tokenID,
+	).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+
+	return revoked, nil
+}
@@ -24,7 +24,7 @@ func (r *UserRepo) Create(ctx context.Context, u *entity.User) error {
         VALUES ($1, $2, $3)
         RETURNING id, created_at
     `
-	err := r.Pool.QueryRow(ctx, query, u.Email, u.Password, u.Role).
+	err := r.Conn(ctx).QueryRow(ctx, query, u.Email, u.Password, u.Role).
 		Scan(&u.ID, &u.CreatedAt)
 
 	if err != nil {
@@ -48,7 +48,7 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*entity.User,
         WHERE email = $1
     `
 	var u entity.User
-	err := r.Pool.QueryRow(ctx, query, email).
+	err := r.Conn(ctx).QueryRow(ctx, query, email).
 		Scan(&u.ID, &u.Email, &u.Password, &u.Role, &u.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
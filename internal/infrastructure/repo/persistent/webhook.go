@@ -0,0 +1,319 @@
+package persistent
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/postgres"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+type WebhookRepo struct {
+	*postgres.Postgres
+}
+
+func NewWebhookRepo(pg *postgres.Postgres) *WebhookRepo {
+	return &WebhookRepo{pg}
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	err := r.Conn(ctx).QueryRow(ctx, `
+		INSERT INTO webhook_subscriptions (url, event_types, pvz_id, city, secret, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, active, created_at`,
+		sub.URL, eventTypeStrings(sub.EventTypes), sub.PVZID, sub.City, sub.Secret, sub.CreatedBy,
+	).Scan(&sub.ID, &sub.Active, &sub.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return entity.ErrPVZNotFound
+		}
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	sub, err := scanWebhookSubscription(r.Conn(ctx).QueryRow(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE id = $1`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrWebhookSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE active
+		ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]entity.WebhookSubscription, 0)
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepo) DeactivateSubscription(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE webhook_subscriptions SET active = FALSE WHERE id = $1 AND active`, id)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookSubscriptionNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, next_attempt_at = NULL, last_error = 'subscription deleted'
+		WHERE subscription_id = $1 AND status = $3`,
+		id, entity.WebhookDeliveryFailed, entity.WebhookDeliveryPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel pending deliveries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) EnqueueDeliveries(ctx context.Context, events []entity.Event) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	var (
+		ids      = make([]uuid.UUID, len(events))
+		types    = make([]string, len(events))
+		pvzIDs   = make([]uuid.UUID, len(events))
+		payloads = make([]string, len(events))
+	)
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+		}
+		ids[i], types[i], pvzIDs[i], payloads[i] = event.ID, string(event.Type), event.PVZID, string(payload)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT s.id, e.id, e.type, e.payload::jsonb
+		FROM unnest($1::uuid[], $2::text[], $3::uuid[], $4::text[]) AS e(id, type, pvz_id, payload)
+		JOIN webhook_subscriptions s ON s.active AND e.type = ANY(s.event_types)
+		LEFT JOIN pvz ON pvz.id = e.pvz_id
+		WHERE (s.pvz_id IS NULL OR s.pvz_id = e.pvz_id)
+		AND (s.city IS NULL OR s.city = pvz.city)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+		ids, types, pvzIDs, payloads,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *WebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	rows, err := r.Conn(ctx).Query(ctx, `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $1 AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $3)
+		FROM due, webhook_subscriptions s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING `+webhookDeliveryColumns+`, d.payload, s.url, s.secret`,
+		entity.WebhookDeliveryPending, limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var (
+			delivery entity.WebhookDelivery
+			payload  []byte
+		)
+		dest := append(webhookDeliveryDest(&delivery), &payload, &delivery.URL, &delivery.Secret)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		if err := json.Unmarshal(payload, &delivery.Event); err != nil {
+			return nil, fmt.Errorf("failed to decode delivery %s payload: %w", delivery.ID, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepo) RecordAttempt(ctx context.Context, attempt entity.WebhookAttempt) error {
+	var statusCode *int
+	if attempt.StatusCode != 0 {
+		statusCode = &attempt.StatusCode
+	}
+
+	status := entity.WebhookDeliveryPending
+	switch {
+	case attempt.Delivered:
+		status = entity.WebhookDeliveryDelivered
+	case attempt.NextAttemptAt == nil:
+		status = entity.WebhookDeliveryFailed
+	}
+
+	_, err := r.Conn(ctx).Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			status = $2,
+			last_status_code = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() END
+		WHERE id = $1`,
+		attempt.DeliveryID, status, statusCode, nullString(attempt.Error), attempt.NextAttemptAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	status entity.WebhookDeliveryStatus,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	query := r.Builder.
+		Select(webhookDeliveryColumns).
+		From("webhook_deliveries d").
+		Where("d.subscription_id = ?", subscriptionID).
+		OrderBy("d.created_at DESC", "d.id").
+		Limit(uint64(limit))
+
+	if status != "" {
+		query = query.Where("d.status = ?", status)
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		if err := rows.Scan(webhookDeliveryDest(&delivery)...); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+const (
+	webhookSubscriptionColumns = "id, url, event_types, pvz_id, city, secret, active, created_at, created_by"
+	webhookDeliveryColumns     = "d.id, d.subscription_id, d.event_id, d.event_type, d.status, d.attempts, " +
+		"d.next_attempt_at, d.last_status_code, COALESCE(d.last_error, ''), d.created_at, d.delivered_at"
+)
+
+func scanWebhookSubscription(row pgx.Row) (*entity.WebhookSubscription, error) {
+	var (
+		sub        entity.WebhookSubscription
+		eventTypes []string
+	)
+	err := row.Scan(
+		&sub.ID,
+		&sub.URL,
+		&eventTypes,
+		&sub.PVZID,
+		&sub.City,
+		&sub.Secret,
+		&sub.Active,
+		&sub.CreatedAt,
+		&sub.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.EventTypes = make([]entity.EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = entity.EventType(t)
+	}
+	return &sub, nil
+}
+
+func webhookDeliveryDest(d *entity.WebhookDelivery) []any {
+	return []any{
+		&d.ID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	}
+}
+
+func eventTypeStrings(types []entity.EventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
@@ -0,0 +1,279 @@
+package repotest
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/requestctx"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const relayBatchSize = 100
+
+func testOutboxRelay(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	errPublish := errors.New("publish failed")
+
+	_, err := r.Outbox.Relay(ctx, relayBatchSize, func(ctx context.Context, events []entity.Event) error {
+		return errPublish
+	})
+	assert.ErrorIs(t, err, errPublish)
+
+	// A reception opened while its PVZ event is being published is not part of
+	// that batch and must not be marked published with it.
+	var reception *entity.Reception
+	batches := make(map[uuid.UUID]int)
+	for batch := 0; ; batch++ {
+		n, err := r.Outbox.Relay(ctx, relayBatchSize, func(ctx context.Context, events []entity.Event) error {
+			for _, e := range events {
+				batches[e.ID] = batch
+				if e.AggregateID == *pvz.ID && reception == nil {
+					reception = openReception(t, r, pvz)
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+	require.NotNil(t, reception, "the failed batch is published again")
+
+	events, err := r.EventStream.EventsSince(ctx, entity.EventFilter{PVZID: *pvz.ID}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.EventPVZCreated, events[0].Type)
+	assert.Equal(t, entity.EventReceptionCreated, events[1].Type)
+
+	created, ok := batches[events[0].ID]
+	require.True(t, ok)
+	opened, ok := batches[events[1].ID]
+	require.True(t, ok, "the event appended during publish is relayed later")
+	assert.Greater(t, opened, created)
+}
+
+func testEventsSince(t *testing.T, r Repos) {
+	ctx := context.Background()
+	city := newCity(t, r)
+	pvz := newPVZ(t, r, city)
+	openReception(t, r, pvz)
+	newPVZ(t, r, city)
+
+	events, err := r.EventStream.EventsSince(ctx, entity.EventFilter{PVZID: *pvz.ID}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.EventPVZCreated, events[0].Type)
+	assert.Equal(t, entity.EventReceptionCreated, events[1].Type)
+	assert.Less(t, events[0].Sequence, events[1].Sequence)
+	for _, e := range events {
+		assert.Equal(t, *pvz.ID, e.PVZID)
+		assert.Equal(t, city, e.City)
+	}
+
+	after, err := r.EventStream.EventsSince(ctx, entity.EventFilter{PVZID: *pvz.ID}, events[0].Sequence, 10)
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	assert.Equal(t, events[1].ID, after[0].ID)
+
+	byCity, err := r.EventStream.EventsSince(ctx, entity.EventFilter{City: city}, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, byCity, 3)
+
+	limited, err := r.EventStream.EventsSince(ctx, entity.EventFilter{City: city}, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, events[0].ID, limited[0].ID)
+
+	start, err := r.EventStream.ReplayStart(ctx, events[1].Sequence, time.Minute)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, start, events[1].Sequence)
+}
+
+func testEventStreamListen(t *testing.T, r Repos) {
+	city := newCity(t, r)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan entity.Event, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- r.EventStream.Listen(ctx, func(e entity.Event) {
+			if e.City != city {
+				return
+			}
+			select {
+			case received <- e:
+			default:
+			}
+		})
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Listen subscribes asynchronously, so events are written until one arrives.
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		pvz := newPVZ(t, r, city)
+		select {
+		case e := <-received:
+			assert.Equal(t, entity.EventPVZCreated, e.Type)
+			assert.NotZero(t, e.Sequence)
+			return
+		case <-ticker.C:
+		case <-timeout:
+			t.Fatalf("no event received for pvz %s", *pvz.ID)
+		}
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	unknown := uuid.New()
+	err := r.Webhooks.CreateSubscription(ctx, &entity.WebhookSubscription{
+		URL:        "https://203.0.113.10/hooks",
+		EventTypes: []entity.EventType{entity.EventReceptionCreated},
+		PVZID:      &unknown,
+		Secret:     "secret",
+	})
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+
+	sub := &entity.WebhookSubscription{
+		URL:        "https://203.0.113.10/hooks",
+		EventTypes: []entity.EventType{entity.EventReceptionCreated},
+		PVZID:      pvz.ID,
+		Secret:     "secret",
+	}
+	require.NoError(t, r.Webhooks.CreateSubscription(ctx, sub))
+	defer func() {
+		_ = r.Webhooks.DeactivateSubscription(ctx, sub.ID)
+	}()
+
+	got, err := r.Webhooks.GetSubscription(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Active)
+	assert.Equal(t, sub.EventTypes, got.EventTypes)
+	assert.Equal(t, "secret", got.Secret)
+
+	openReception(t, r, pvz)
+	events, err := r.EventStream.EventsSince(ctx, entity.EventFilter{PVZID: *pvz.ID}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	_, err = r.Webhooks.EnqueueDeliveries(ctx, events)
+	require.NoError(t, err)
+	again, err := r.Webhooks.EnqueueDeliveries(ctx, events)
+	require.NoError(t, err)
+	assert.Zero(t, again, "events already enqueued are skipped")
+
+	deliveries, err := r.Webhooks.ListDeliveries(ctx, sub.ID, "", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1, "only the subscribed event type is enqueued")
+	assert.Equal(t, events[1].ID, deliveries[0].EventID)
+	assert.Equal(t, entity.WebhookDeliveryPending, deliveries[0].Status)
+
+	claimed := claimDelivery(t, r, deliveries[0].ID)
+	require.NotNil(t, claimed)
+	assert.Equal(t, sub.URL, claimed.URL)
+	assert.Equal(t, "secret", claimed.Secret)
+	assert.Equal(t, events[1].ID, claimed.Event.ID)
+	assert.Nil(t, claimDelivery(t, r, deliveries[0].ID), "a leased delivery is not claimed again")
+
+	require.NoError(t, r.Webhooks.RecordAttempt(ctx, entity.WebhookAttempt{
+		DeliveryID: claimed.ID,
+		StatusCode: 500,
+		Error:      "server error",
+	}))
+	failed, err := r.Webhooks.ListDeliveries(ctx, sub.ID, entity.WebhookDeliveryFailed, 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 1, failed[0].Attempts)
+	require.NotNil(t, failed[0].LastStatusCode)
+	assert.Equal(t, 500, *failed[0].LastStatusCode)
+	assert.Equal(t, "server error", failed[0].LastError)
+
+	require.NoError(t, r.Webhooks.DeactivateSubscription(ctx, sub.ID))
+	assert.ErrorIs(t, r.Webhooks.DeactivateSubscription(ctx, sub.ID), entity.ErrWebhookSubscriptionNotFound)
+
+	subs, err := r.Webhooks.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	for _, s := range subs {
+		assert.NotEqual(t, sub.ID, s.ID)
+	}
+}
+
+func testAuditLog(t *testing.T, r Repos) {
+	moderator := newUser(t, r, entity.UserRoleModerator)
+	ctx := requestctx.WithRequestID(context.Background(), uuid.NewString())
+	ctx = requestctx.WithPrincipal(ctx, entity.Principal{UserID: moderator.ID, Role: entity.UserRoleModerator})
+
+	pvz := &entity.PVZ{City: newCity(t, r)}
+	require.NoError(t, r.PVZ.Create(ctx, pvz))
+
+	entries, err := r.Audit.List(ctx, entity.AuditFilter{EntityID: pvz.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entity.AuditPVZCreated, entries[0].Action)
+	assert.Equal(t, entity.AuditEntityPVZ, entries[0].EntityType)
+	assert.Equal(t, &moderator.ID, entries[0].ActorID)
+	assert.Equal(t, entity.UserRoleModerator, entries[0].ActorRole)
+	assert.Equal(t, requestctx.RequestID(ctx), entries[0].RequestID)
+	assert.Nil(t, entries[0].Before)
+	assert.NotEmpty(t, entries[0].After)
+
+	openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+	_, err = r.Receptions.CloseActiveReception(context.Background(), *pvz.ID, nil)
+	require.NoError(t, err)
+
+	entries, err = r.Audit.List(ctx, entity.AuditFilter{
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   &product.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, entity.AuditProductStatusChanged, entries[0].Action, "newest entry first")
+	assert.Nil(t, entries[0].ActorID)
+	assert.Equal(t, entity.AuditProductAdded, entries[1].Action)
+
+	older, err := r.Audit.List(ctx, entity.AuditFilter{EntityID: &product.ID, BeforeID: entries[0].ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, older, 1)
+	assert.Equal(t, entries[1].ID, older[0].ID)
+
+	byActor, err := r.Audit.List(ctx, entity.AuditFilter{ActorID: &moderator.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, byActor, 1)
+	assert.Equal(t, *pvz.ID, byActor[0].EntityID)
+}
+
+// claimDelivery claims due deliveries until it meets id; deliveries of other
+// cases claimed on the way are leased as well.
+func claimDelivery(t *testing.T, r Repos, id uuid.UUID) *entity.WebhookDelivery {
+	t.Helper()
+
+	for {
+		deliveries, err := r.Webhooks.ClaimDueDeliveries(context.Background(), relayBatchSize, time.Minute)
+		require.NoError(t, err)
+		if len(deliveries) == 0 {
+			return nil
+		}
+		for _, d := range deliveries {
+			if d.ID == id {
+				return &d
+			}
+		}
+	}
+}
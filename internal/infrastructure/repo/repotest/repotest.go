@@ -0,0 +1,988 @@
+// Package repotest is a conformance suite for implementations of the
+// repository contracts. Every backend runs the same cases, so the in-memory
+// repositories keep behaving like the Postgres ones.
+//
+// Cases do not expect an empty storage: each one works in a city of its own and
+// uses unique emails and barcodes, so the suite can run against a shared database.
+package repotest
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Repos struct {
+	Users         repo.UserRepo
+	RefreshTokens repo.RefreshTokenRepo
+	RevokedTokens repo.RevokedTokenRepo
+	PVZ           repo.PVZRepo
+	Assignments   repo.PVZAssignmentRepo
+	Receptions    repo.ReceptionRepo
+	Products      repo.ProductRepo
+	Cities        repo.CityRepo
+	Catalogue     repo.CatalogueRepo
+	Returns       repo.ReturnRepo
+	Outbox        repo.OutboxRepo
+	EventStream   repo.EventStreamRepo
+	Webhooks      repo.WebhookRepo
+	Audit         repo.AuditRepo
+	Reports       repo.ReportRepo
+	Idempotency   repo.IdempotencyRepo
+	Tx            repo.TxManager
+}
+
+// Run runs the suite; newRepos is called once per case.
+func Run(t *testing.T, newRepos func(t *testing.T) Repos) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, r Repos)
+	}{
+		{"UserDuplicateEmail", testUserDuplicateEmail},
+		{"UserNotFound", testUserNotFound},
+		{"RefreshTokenConsume", testRefreshTokenConsume},
+		{"RevokedTokens", testRevokedTokens},
+		{"PVZCreateAndGet", testPVZCreateAndGet},
+		{"PVZUnknownCity", testPVZUnknownCity},
+		{"Assignments", testAssignments},
+		{"Catalogue", testCatalogue},
+		{"InactiveProductType", testInactiveProductType},
+		{"OneActiveReceptionPerPVZ", testOneActiveReceptionPerPVZ},
+		{"ConcurrentReceptions", testConcurrentReceptions},
+		{"CloseWithoutActiveReception", testCloseWithoutActiveReception},
+		{"CloseStoresProducts", testCloseStoresProducts},
+		{"ReopenConflict", testReopenConflict},
+		{"ReopenReturnsProducts", testReopenReturnsProducts},
+		{"ReopenAfterIssue", testReopenAfterIssue},
+		{"CancelVoidsProducts", testCancelVoidsProducts},
+		{"AddProductWithoutActiveReception", testAddProductWithoutActiveReception},
+		{"AddProductInvalidType", testAddProductInvalidType},
+		{"BarcodeConflict", testBarcodeConflict},
+		{"AddProductsPartialFailure", testAddProductsPartialFailure},
+		{"AddProductsOrder", testAddProductsOrder},
+		{"ConcurrentBatchBarcodeConflict", testConcurrentBatchBarcodeConflict},
+		{"DeleteProductLIFO", testDeleteProductLIFO},
+		{"DeleteProductLIFOEmpty", testDeleteProductLIFOEmpty},
+		{"IssueProductAttempts", testIssueProductAttempts},
+		{"PVZWithReceptionsFilter", testPVZWithReceptionsFilter},
+		{"AcceptanceReport", testAcceptanceReport},
+		{"Returns", testReturns},
+		{"OutboxRelay", testOutboxRelay},
+		{"EventsSince", testEventsSince},
+		{"EventStreamListen", testEventStreamListen},
+		{"WebhookDeliveries", testWebhookDeliveries},
+		{"AuditLog", testAuditLog},
+		{"Idempotency", testIdempotency},
+		{"TxRollback", testTxRollback},
+		{"TxRollbackAfterSeveralWrites", testTxRollbackAfterSeveralWrites},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepos(t))
+		})
+	}
+}
+
+func testUserDuplicateEmail(t *testing.T, r Repos) {
+	ctx := context.Background()
+	email := uniqueEmail()
+
+	err := r.Users.Create(ctx, &entity.User{ID: uuid.New(), Email: email, Password: "hash", Role: entity.UserRoleEmployee})
+	require.NoError(t, err)
+
+	err = r.Users.Create(ctx, &entity.User{ID: uuid.New(), Email: email, Password: "hash", Role: entity.UserRoleModerator})
+	assert.ErrorIs(t, err, entity.ErrUserAlreadyExists)
+
+	user, err := r.Users.GetByEmail(ctx, email)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleEmployee, user.Role)
+}
+
+func testUserNotFound(t *testing.T, r Repos) {
+	_, err := r.Users.GetByEmail(context.Background(), uniqueEmail())
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func testRefreshTokenConsume(t *testing.T, r Repos) {
+	ctx := context.Background()
+	user := newUser(t, r, entity.UserRoleEmployee)
+
+	token := &entity.RefreshToken{
+		UserID:    user.ID,
+		Role:      user.Role,
+		TokenHash: uuid.NewString(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, r.RefreshTokens.Create(ctx, token))
+	assert.NotEqual(t, uuid.Nil, token.ID)
+
+	consumed, err := r.RefreshTokens.Consume(ctx, token.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, consumed.UserID)
+	assert.Equal(t, user.Email, consumed.Email)
+	assert.Equal(t, entity.UserRoleEmployee, consumed.Role)
+	assert.NotNil(t, consumed.RevokedAt)
+
+	_, err = r.RefreshTokens.Consume(ctx, token.TokenHash)
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+	assert.ErrorIs(t, r.RefreshTokens.Revoke(ctx, token.TokenHash), entity.ErrInvalidRefreshToken)
+
+	revoked := &entity.RefreshToken{
+		UserID:    user.ID,
+		Role:      user.Role,
+		TokenHash: uuid.NewString(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, r.RefreshTokens.Create(ctx, revoked))
+	require.NoError(t, r.RefreshTokens.Revoke(ctx, revoked.TokenHash))
+	_, err = r.RefreshTokens.Consume(ctx, revoked.TokenHash)
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+
+	expired := &entity.RefreshToken{
+		UserID:    user.ID,
+		Role:      user.Role,
+		TokenHash: uuid.NewString(),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, r.RefreshTokens.Create(ctx, expired))
+	_, err = r.RefreshTokens.Consume(ctx, expired.TokenHash)
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+
+	_, err = r.RefreshTokens.Consume(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+}
+
+func testRevokedTokens(t *testing.T, r Repos) {
+	ctx := context.Background()
+	tokenID := uuid.NewString()
+
+	revoked, err := r.RevokedTokens.IsRevoked(ctx, tokenID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, r.RevokedTokens.Revoke(ctx, tokenID, time.Now().Add(time.Hour)))
+	require.NoError(t, r.RevokedTokens.Revoke(ctx, tokenID, time.Now().Add(time.Hour)))
+
+	revoked, err = r.RevokedTokens.IsRevoked(ctx, tokenID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func testPVZCreateAndGet(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	got, err := r.PVZ.GetByID(ctx, *pvz.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pvz.City, got.City)
+	assert.True(t, pvz.RegistrationDate.Equal(*got.RegistrationDate))
+
+	_, err = r.PVZ.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+}
+
+func testPVZUnknownCity(t *testing.T, r Repos) {
+	err := r.PVZ.Create(context.Background(), &entity.PVZ{City: entity.City(uuid.NewString())})
+	assert.ErrorIs(t, err, entity.ErrInvalidCity)
+}
+
+func testAssignments(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	employee := newUser(t, r, entity.UserRoleEmployee)
+	moderator := newUser(t, r, entity.UserRoleModerator)
+
+	first := &entity.PVZAssignment{UserID: employee.ID, PVZID: *pvz.ID, AssignedBy: &moderator.ID}
+	require.NoError(t, r.Assignments.Assign(ctx, first))
+	assert.False(t, first.AssignedAt.IsZero())
+
+	again := &entity.PVZAssignment{UserID: employee.ID, PVZID: *pvz.ID}
+	require.NoError(t, r.Assignments.Assign(ctx, again))
+	assert.True(t, first.AssignedAt.Equal(again.AssignedAt), "the original assignment is kept")
+	assert.Equal(t, &moderator.ID, again.AssignedBy)
+
+	err := r.Assignments.Assign(ctx, &entity.PVZAssignment{UserID: moderator.ID, PVZID: *pvz.ID})
+	assert.ErrorIs(t, err, entity.ErrEmployeeNotFound)
+	err = r.Assignments.Assign(ctx, &entity.PVZAssignment{UserID: employee.ID, PVZID: uuid.New()})
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+
+	assigned, err := r.Assignments.IsAssigned(ctx, employee.ID, *pvz.ID)
+	require.NoError(t, err)
+	assert.True(t, assigned)
+
+	assignments, err := r.Assignments.ListByPVZ(ctx, *pvz.ID)
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	assert.Equal(t, employee.ID, assignments[0].UserID)
+
+	require.NoError(t, r.Assignments.Unassign(ctx, employee.ID, *pvz.ID))
+	assert.ErrorIs(t, r.Assignments.Unassign(ctx, employee.ID, *pvz.ID), entity.ErrAssignmentNotFound)
+
+	assigned, err = r.Assignments.IsAssigned(ctx, employee.ID, *pvz.ID)
+	require.NoError(t, err)
+	assert.False(t, assigned)
+}
+
+func testCatalogue(t *testing.T, r Repos) {
+	ctx := context.Background()
+
+	productType := &entity.ProductTypeInfo{Name: entity.ProductType("type-" + uuid.NewString())}
+	require.NoError(t, r.Catalogue.CreateType(ctx, productType))
+	assert.True(t, productType.Active)
+	assert.ErrorIs(t, r.Catalogue.CreateType(ctx, &entity.ProductTypeInfo{Name: productType.Name}), entity.ErrProductTypeAlreadyExists)
+
+	category := &entity.ProductCategory{TypeID: productType.ID, Name: "sneakers"}
+	require.NoError(t, r.Catalogue.CreateCategory(ctx, category))
+	assert.True(t, category.Active)
+	err := r.Catalogue.CreateCategory(ctx, &entity.ProductCategory{TypeID: productType.ID, Name: "sneakers"})
+	assert.ErrorIs(t, err, entity.ErrProductCategoryAlreadyExists)
+	err = r.Catalogue.CreateCategory(ctx, &entity.ProductCategory{TypeID: uuid.New(), Name: "sneakers"})
+	assert.ErrorIs(t, err, entity.ErrProductTypeNotFound)
+
+	_, err = r.Catalogue.SetCategoryActive(ctx, uuid.New(), category.ID, false)
+	assert.ErrorIs(t, err, entity.ErrProductCategoryNotFound)
+	disabled, err := r.Catalogue.SetCategoryActive(ctx, productType.ID, category.ID, false)
+	require.NoError(t, err)
+	assert.False(t, disabled.Active)
+
+	listed := findProductType(t, r, productType.ID, false)
+	require.NotNil(t, listed)
+	assert.Empty(t, listed.Categories)
+
+	_, err = r.Catalogue.SetTypeActive(ctx, uuid.New(), false)
+	assert.ErrorIs(t, err, entity.ErrProductTypeNotFound)
+	_, err = r.Catalogue.SetTypeActive(ctx, productType.ID, false)
+	require.NoError(t, err)
+
+	assert.Nil(t, findProductType(t, r, productType.ID, false))
+	listed = findProductType(t, r, productType.ID, true)
+	require.NotNil(t, listed)
+	assert.False(t, listed.Active)
+	require.Len(t, listed.Categories, 1)
+	assert.Equal(t, category.ID, listed.Categories[0].ID)
+}
+
+func testInactiveProductType(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, pvz)
+
+	productType := &entity.ProductTypeInfo{Name: entity.ProductType("type-" + uuid.NewString())}
+	require.NoError(t, r.Catalogue.CreateType(ctx, productType))
+	category := &entity.ProductCategory{TypeID: productType.ID, Name: "boots"}
+	require.NoError(t, r.Catalogue.CreateCategory(ctx, category))
+
+	_, err := r.Products.AddProduct(ctx, *pvz.ID, &entity.Product{Type: productType.Name, Category: category.Name})
+	require.NoError(t, err)
+
+	_, err = r.Catalogue.SetCategoryActive(ctx, productType.ID, category.ID, false)
+	require.NoError(t, err)
+	_, err = r.Products.AddProduct(ctx, *pvz.ID, &entity.Product{Type: productType.Name, Category: category.Name})
+	assert.ErrorIs(t, err, entity.ErrProductCategoryInactive)
+
+	_, err = r.Catalogue.SetTypeActive(ctx, productType.ID, false)
+	require.NoError(t, err)
+	_, err = r.Products.AddProduct(ctx, *pvz.ID, &entity.Product{Type: productType.Name})
+	assert.ErrorIs(t, err, entity.ErrProductTypeInactive)
+}
+
+func testOneActiveReceptionPerPVZ(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	first, err := r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InProgressStatus, first.Status)
+
+	_, err = r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil)
+	assert.ErrorIs(t, err, entity.ErrReceptionConflict)
+
+	active, err := r.Receptions.GetActiveByPVZ(ctx, *pvz.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, active.ID)
+
+	_, err = r.Receptions.CreateReception(ctx, uuid.New(), nil, nil)
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+}
+
+func testConcurrentReceptions(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	const workers = 8
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var created int
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, entity.ErrReceptionConflict)
+	}
+	assert.Equal(t, 1, created)
+}
+
+func testCloseWithoutActiveReception(t *testing.T, r Repos) {
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	_, err := r.Receptions.CloseActiveReception(context.Background(), *pvz.ID, nil)
+	assert.ErrorIs(t, err, entity.ErrNoActiveReception)
+}
+
+func testCloseStoresProducts(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+	employee := newUser(t, r, entity.UserRoleEmployee)
+
+	closed, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, &employee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CloseStatus, closed.Status)
+	assert.NotNil(t, closed.ClosedAt)
+
+	stored, err := r.Products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StoredProductStatus, stored.Status)
+
+	history, err := r.Receptions.ListStatusHistory(ctx, reception.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Nil(t, history[0].From)
+	assert.Equal(t, entity.InProgressStatus, history[0].To)
+	assert.Equal(t, entity.CloseStatus, history[1].To)
+	assert.Equal(t, &employee.ID, history[1].ChangedBy)
+
+	productHistory, err := r.Products.ListStatusHistory(ctx, product.ID)
+	require.NoError(t, err)
+	require.Len(t, productHistory, 2)
+	assert.Equal(t, entity.StoredProductStatus, productHistory[1].To)
+	assert.Equal(t, &employee.ID, productHistory[1].ChangedBy)
+}
+
+func testReopenConflict(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	first := openReception(t, r, pvz)
+
+	_, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+	openReception(t, r, pvz)
+
+	_, err = r.Receptions.ReopenReception(ctx, first.ID, time.Hour, nil)
+	assert.ErrorIs(t, err, entity.ErrReceptionConflict)
+
+	_, err = r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+
+	reopened, err := r.Receptions.ReopenReception(ctx, first.ID, time.Hour, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InProgressStatus, reopened.Status)
+	assert.Nil(t, reopened.ClosedAt)
+}
+
+func testReopenReturnsProducts(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+
+	_, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+	_, err = r.Receptions.ReopenReception(ctx, reception.ID, time.Hour, nil)
+	require.NoError(t, err)
+
+	received, err := r.Products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReceivedProductStatus, received.Status)
+
+	require.NoError(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID))
+	_, err = r.Products.GetByID(ctx, product.ID)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+}
+
+func testReopenAfterIssue(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+
+	_, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+	_, err = r.Products.IssueProductWithoutCode(ctx, product.ID, nil)
+	require.NoError(t, err)
+
+	_, err = r.Receptions.ReopenReception(ctx, reception.ID, time.Hour, nil)
+	assert.ErrorIs(t, err, entity.ErrReceptionProductsLeftStorage)
+
+	closed, err := r.Receptions.GetByID(ctx, reception.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CloseStatus, closed.Status)
+}
+
+func testCancelVoidsProducts(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+
+	cancelled, err := r.Receptions.CancelReception(ctx, reception.ID, "wrong pvz", nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CancelledStatus, cancelled.Status)
+	assert.Equal(t, "wrong pvz", cancelled.CancellationReason)
+
+	_, err = r.Products.GetByID(ctx, product.ID)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+
+	products, err := r.Products.ListByReception(ctx, reception.ID)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	_, err = r.Receptions.CancelReception(ctx, reception.ID, "again", nil)
+	assert.ErrorIs(t, err, entity.ErrInvalidReceptionStatusTransition)
+}
+
+func testAddProductWithoutActiveReception(t *testing.T, r Repos) {
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	_, err := r.Products.AddProduct(context.Background(), *pvz.ID, &entity.Product{Type: entity.ElectronicsProductType})
+	assert.ErrorIs(t, err, entity.ErrNoActiveReception)
+}
+
+func testAddProductInvalidType(t *testing.T, r Repos) {
+	pvz := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, pvz)
+
+	_, err := r.Products.AddProduct(context.Background(), *pvz.ID, &entity.Product{Type: entity.ProductType(uuid.NewString())})
+	assert.ErrorIs(t, err, entity.ErrInvalidProductType)
+}
+
+func testBarcodeConflict(t *testing.T, r Repos) {
+	ctx := context.Background()
+	barcode := uniqueBarcode()
+
+	first := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, first)
+	addProduct(t, r, first, barcode)
+
+	second := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, second)
+	_, err := r.Products.AddProduct(ctx, *second.ID, &entity.Product{Type: entity.ShoesProductType, Barcode: barcode})
+	assert.ErrorIs(t, err, entity.ErrBarcodeConflict)
+
+	location, err := r.Products.FindByBarcode(ctx, barcode)
+	require.NoError(t, err)
+	assert.Equal(t, *first.ID, *location.PVZ.ID)
+}
+
+func testAddProductsPartialFailure(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	barcode := uniqueBarcode()
+
+	results, err := r.Products.AddProducts(ctx, *pvz.ID, []*entity.Product{
+		{Type: entity.ElectronicsProductType, Barcode: barcode},
+		{Type: entity.ProductType(uuid.NewString())},
+		{Type: entity.ClothesProductType, Barcode: barcode},
+		{Type: entity.ShoesProductType},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, entity.ErrInvalidProductType)
+	assert.ErrorIs(t, results[2].Err, entity.ErrBarcodeConflict)
+	assert.NoError(t, results[3].Err)
+
+	products, err := r.Products.ListByReception(ctx, reception.ID)
+	require.NoError(t, err)
+	assert.Len(t, products, 2)
+}
+
+func testAddProductsOrder(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+
+	batch := make([]*entity.Product, 5)
+	for i := range batch {
+		batch[i] = &entity.Product{Type: entity.ShoesProductType, Barcode: uniqueBarcode()}
+	}
+	results, err := r.Products.AddProducts(ctx, *pvz.ID, batch)
+	require.NoError(t, err)
+
+	products, err := r.Products.ListByReception(ctx, reception.ID)
+	require.NoError(t, err)
+	require.Len(t, products, len(batch))
+	for i, product := range products {
+		assert.Equal(t, results[i].Product.ID, product.ID)
+	}
+
+	require.NoError(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID))
+	_, err = r.Products.GetByID(ctx, results[len(results)-1].Product.ID)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+}
+
+func testConcurrentBatchBarcodeConflict(t *testing.T, r Repos) {
+	ctx := context.Background()
+	city := newCity(t, r)
+	barcode := uniqueBarcode()
+
+	const workers = 4
+	pvzIDs := make([]uuid.UUID, workers)
+	for i := range pvzIDs {
+		pvz := newPVZ(t, r, city)
+		openReception(t, r, pvz)
+		pvzIDs[i] = *pvz.ID
+	}
+
+	results := make([][]entity.ProductBatchResult, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = r.Products.AddProducts(ctx, pvzIDs[i], []*entity.Product{
+				{Type: entity.ElectronicsProductType, Barcode: barcode},
+				{Type: entity.ClothesProductType},
+			})
+		}()
+	}
+	wg.Wait()
+
+	var accepted int
+	for i := range workers {
+		require.NoError(t, errs[i])
+		require.Len(t, results[i], 2)
+		assert.NoError(t, results[i][1].Err)
+		if results[i][0].Err == nil {
+			accepted++
+			continue
+		}
+		assert.ErrorIs(t, results[i][0].Err, entity.ErrBarcodeConflict)
+	}
+	assert.Equal(t, 1, accepted)
+}
+
+func testDeleteProductLIFO(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	first := addProduct(t, r, pvz, uniqueBarcode())
+	last := addProduct(t, r, pvz, uniqueBarcode())
+
+	require.NoError(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID))
+
+	_, err := r.Products.GetByID(ctx, last.ID)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+	history, err := r.Products.ListStatusHistory(ctx, last.ID)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	products, err := r.Products.ListByReception(ctx, reception.ID)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, first.ID, products[0].ID)
+
+	require.NoError(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID))
+	assert.ErrorIs(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID), entity.ErrNoProducts)
+}
+
+func testDeleteProductLIFOEmpty(t *testing.T, r Repos) {
+	pvz := newPVZ(t, r, newCity(t, r))
+
+	err := r.Products.DeleteProductLIFO(context.Background(), *pvz.ID)
+	assert.ErrorIs(t, err, entity.ErrNoActiveReception)
+}
+
+func testIssueProductAttempts(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, pvz)
+
+	product, err := r.Products.AddProduct(ctx, *pvz.ID, &entity.Product{
+		Type:           entity.ElectronicsProductType,
+		PickupCodeHash: "right",
+	})
+	require.NoError(t, err)
+	withoutCode := addProduct(t, r, pvz, uniqueBarcode())
+	_, err = r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+
+	_, err = r.Products.IssueProduct(ctx, withoutCode.ID, "right", 2, nil)
+	assert.ErrorIs(t, err, entity.ErrPickupCodeNotSet)
+
+	for i := 0; i < 2; i++ {
+		_, err = r.Products.IssueProduct(ctx, product.ID, "wrong", 2, nil)
+		assert.ErrorIs(t, err, entity.ErrInvalidPickupCode)
+	}
+	_, err = r.Products.IssueProduct(ctx, product.ID, "right", 2, nil)
+	assert.ErrorIs(t, err, entity.ErrPickupCodeAttemptsExceeded)
+
+	for _, id := range []uuid.UUID{product.ID, withoutCode.ID} {
+		issued, err := r.Products.IssueProductWithoutCode(ctx, id, nil)
+		require.NoError(t, err)
+		assert.Equal(t, entity.IssuedProductStatus, issued.Status)
+	}
+}
+
+func testPVZWithReceptionsFilter(t *testing.T, r Repos) {
+	ctx := context.Background()
+	city := newCity(t, r)
+	idle := newPVZ(t, r, city)
+	busy := newPVZ(t, r, city)
+	reception := openReception(t, r, busy)
+	product := addProduct(t, r, busy, uniqueBarcode())
+
+	all, err := r.PVZ.GetPVZWithReceptions(ctx, dto.ReceptionFilter{
+		Page:   1,
+		Limit:  10,
+		Cities: []entity.City{city},
+	})
+	require.NoError(t, err)
+	require.Len(t, *all, 2)
+	assert.Equal(t, *busy.ID, (*all)[0].PVZ.ID, "newest PVZ first")
+	assert.Equal(t, *idle.ID, (*all)[1].PVZ.ID)
+
+	require.Len(t, (*all)[0].Receptions, 1)
+	group := (*all)[0].Receptions[0]
+	assert.Equal(t, reception.ID, group.Reception.ID)
+	require.Len(t, group.Products, 1)
+	assert.Equal(t, product.ID, group.Products[0].ID)
+	assert.Empty(t, (*all)[1].Receptions)
+
+	hasActive := true
+	active, err := r.PVZ.GetPVZWithReceptions(ctx, dto.ReceptionFilter{
+		Page:               1,
+		Limit:              10,
+		Cities:             []entity.City{city},
+		HasActiveReception: &hasActive,
+	})
+	require.NoError(t, err)
+	require.Len(t, *active, 1)
+	assert.Equal(t, *busy.ID, (*active)[0].PVZ.ID)
+
+	page, err := r.PVZ.GetPVZWithReceptions(ctx, dto.ReceptionFilter{
+		Page:   2,
+		Limit:  1,
+		Cities: []entity.City{city},
+	})
+	require.NoError(t, err)
+	require.Len(t, *page, 1)
+	assert.Equal(t, *idle.ID, (*page)[0].PVZ.ID)
+}
+
+func testAcceptanceReport(t *testing.T, r Repos) {
+	ctx := context.Background()
+	city := newCity(t, r)
+	pvz := newPVZ(t, r, city)
+	openReception(t, r, pvz)
+	first := addProduct(t, r, pvz, uniqueBarcode())
+	addProduct(t, r, pvz, uniqueBarcode())
+	_, err := r.Products.AddProduct(ctx, *pvz.ID, &entity.Product{Type: entity.ShoesProductType})
+	require.NoError(t, err)
+	require.NoError(t, r.Products.DeleteProductLIFO(ctx, *pvz.ID))
+
+	day := first.DateTime.UTC().Truncate(24 * time.Hour)
+	filter := entity.AcceptanceReportFilter{
+		From:   day,
+		To:     day.AddDate(0, 0, 1),
+		Cities: []entity.City{city},
+	}
+	report, err := r.Reports.AcceptanceReport(ctx, filter)
+	require.NoError(t, err)
+	assert.Empty(t, report, "products of an open reception are not accepted yet")
+
+	_, err = r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+	openReception(t, r, pvz)
+	addProduct(t, r, pvz, uniqueBarcode())
+
+	report, err = r.Reports.AcceptanceReport(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.AcceptanceReportRow{{
+		PVZID:       *pvz.ID,
+		City:        city,
+		Day:         day.Format(entity.ReportDayLayout),
+		ProductType: entity.ElectronicsProductType,
+		Accepted:    2,
+	}}, report)
+
+	otherPVZ := uuid.New()
+	report, err = r.Reports.AcceptanceReport(ctx, entity.AcceptanceReportFilter{
+		From:  day,
+		To:    day.AddDate(0, 0, 1),
+		PVZID: &otherPVZ,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, report)
+}
+
+func testReturns(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+	_, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+
+	_, err = r.Returns.CreateReturn(ctx, &entity.Return{ProductID: product.ID, PVZID: uuid.New()})
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+
+	ret, err := r.Returns.CreateReturn(ctx, &entity.Return{ProductID: product.ID, PVZID: *pvz.ID, Reason: "damaged"})
+	require.NoError(t, err)
+	assert.Equal(t, "damaged", ret.Reason)
+
+	returned, err := r.Products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReturnedProductStatus, returned.Status)
+
+	_, err = r.Returns.CreateReturn(ctx, &entity.Return{ProductID: product.ID, PVZID: *pvz.ID})
+	assert.ErrorIs(t, err, entity.ErrInvalidProductStatusTransition)
+
+	_, err = r.Returns.AddToActiveShipment(ctx, *pvz.ID, ret.ID)
+	assert.ErrorIs(t, err, entity.ErrNoActiveReturnShipment)
+
+	shipment, err := r.Returns.OpenShipment(ctx, *pvz.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.InProgressStatus, shipment.Status)
+	_, err = r.Returns.OpenShipment(ctx, *pvz.ID, nil)
+	assert.ErrorIs(t, err, entity.ErrReturnShipmentConflict)
+	_, err = r.Returns.OpenShipment(ctx, uuid.New(), nil)
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+
+	_, err = r.Returns.AddToActiveShipment(ctx, *pvz.ID, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrReturnNotFound)
+	shipped, err := r.Returns.AddToActiveShipment(ctx, *pvz.ID, ret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &shipment.ID, shipped.ShipmentID)
+	_, err = r.Returns.AddToActiveShipment(ctx, *pvz.ID, ret.ID)
+	assert.ErrorIs(t, err, entity.ErrReturnAlreadyShipped)
+
+	outstanding, err := r.Returns.ListByPVZ(ctx, *pvz.ID, true)
+	require.NoError(t, err)
+	assert.Len(t, outstanding, 1, "a return stays outstanding until its shipment is closed")
+
+	closed, err := r.Returns.CloseActiveShipment(ctx, *pvz.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CloseStatus, closed.Status)
+	assert.NotNil(t, closed.ClosedAt)
+	_, err = r.Returns.CloseActiveShipment(ctx, *pvz.ID)
+	assert.ErrorIs(t, err, entity.ErrNoActiveReturnShipment)
+
+	outstanding, err = r.Returns.ListByPVZ(ctx, *pvz.ID, true)
+	require.NoError(t, err)
+	assert.Empty(t, outstanding)
+	all, err := r.Returns.ListByPVZ(ctx, *pvz.ID, false)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, ret.ID, all[0].ID)
+
+	stats, err := r.Returns.Stats(ctx, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	var found bool
+	for _, s := range stats {
+		if s.PVZID == *pvz.ID {
+			found = true
+			assert.Equal(t, entity.ReturnStats{PVZID: *pvz.ID, City: pvz.City, Total: 1, Shipped: 1}, s)
+		}
+	}
+	assert.True(t, found)
+}
+
+func testIdempotency(t *testing.T, r Repos) {
+	ctx := context.Background()
+	scope := uuid.NewString()
+
+	record, err := r.Idempotency.Acquire(ctx, scope, "key", "hash", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	record, err = r.Idempotency.Acquire(ctx, scope, "key", "hash", time.Hour, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.False(t, record.Completed())
+
+	require.NoError(t, r.Idempotency.Complete(ctx, scope, "key", 201, "application/json", []byte(`{"id":"1"}`)))
+	require.NoError(t, r.Idempotency.Release(ctx, scope, "key"))
+
+	record, err = r.Idempotency.Acquire(ctx, scope, "key", "other", time.Hour, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, "hash", record.RequestHash)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.Equal(t, "application/json", record.ContentType)
+	assert.Equal(t, []byte(`{"id":"1"}`), record.Body)
+
+	released, err := r.Idempotency.Acquire(ctx, scope, "released", "hash", time.Hour, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, released)
+	require.NoError(t, r.Idempotency.Release(ctx, scope, "released"))
+	released, err = r.Idempotency.Acquire(ctx, scope, "released", "hash", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, released)
+
+	abandoned, err := r.Idempotency.Acquire(ctx, scope, "abandoned", "hash", time.Hour, 0)
+	require.NoError(t, err)
+	require.Nil(t, abandoned)
+	abandoned, err = r.Idempotency.Acquire(ctx, scope, "abandoned", "hash", time.Hour, 0)
+	require.NoError(t, err)
+	assert.Nil(t, abandoned, "a request abandoned for longer than the lock timeout is taken over")
+
+	expired, err := r.Idempotency.Acquire(ctx, scope, "expired", "hash", -time.Second, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, expired)
+	deleted, err := r.Idempotency.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+	expired, err = r.Idempotency.Acquire(ctx, scope, "expired", "hash", time.Hour, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, expired)
+}
+
+func testTxRollback(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	errAbort := errors.New("abort")
+
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	_, err = r.Receptions.GetActiveByPVZ(ctx, *pvz.ID)
+	assert.ErrorIs(t, err, entity.ErrNoActiveReception)
+
+	err = r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil)
+		return err
+	})
+	require.NoError(t, err)
+
+	_, err = r.Receptions.GetActiveByPVZ(ctx, *pvz.ID)
+	assert.NoError(t, err)
+}
+
+func testTxRollbackAfterSeveralWrites(t *testing.T, r Repos) {
+	ctx := context.Background()
+	pvz := newPVZ(t, r, newCity(t, r))
+	reception := openReception(t, r, pvz)
+	product := addProduct(t, r, pvz, uniqueBarcode())
+	errAbort := errors.New("abort")
+
+	err := r.Tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.Receptions.CloseActiveReception(ctx, *pvz.ID, nil); err != nil {
+			return err
+		}
+		if _, err := r.Receptions.CreateReception(ctx, *pvz.ID, nil, nil); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	active, err := r.Receptions.GetActiveByPVZ(ctx, *pvz.ID)
+	require.NoError(t, err)
+	assert.Equal(t, reception.ID, active.ID)
+
+	received, err := r.Products.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReceivedProductStatus, received.Status)
+
+	history, err := r.Receptions.ListStatusHistory(ctx, reception.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func newUser(t *testing.T, r Repos, role entity.UserRole) *entity.User {
+	t.Helper()
+
+	user := &entity.User{ID: uuid.New(), Email: uniqueEmail(), Password: "hash", Role: role}
+	require.NoError(t, r.Users.Create(context.Background(), user))
+	return user
+}
+
+func findProductType(t *testing.T, r Repos, id uuid.UUID, includeInactive bool) *entity.ProductTypeInfo {
+	t.Helper()
+
+	types, err := r.Catalogue.ListTypes(context.Background(), includeInactive)
+	require.NoError(t, err)
+	for _, productType := range types {
+		if productType.ID == id {
+			return &productType
+		}
+	}
+	return nil
+}
+
+func newCity(t *testing.T, r Repos) entity.City {
+	t.Helper()
+
+	city := entity.CityInfo{Name: entity.City("city-" + uuid.NewString())}
+	require.NoError(t, r.Cities.Create(context.Background(), &city))
+	return city.Name
+}
+
+func newPVZ(t *testing.T, r Repos, city entity.City) *entity.PVZ {
+	t.Helper()
+
+	pvz := &entity.PVZ{City: city}
+	require.NoError(t, r.PVZ.Create(context.Background(), pvz))
+	require.NotNil(t, pvz.ID)
+	require.NotNil(t, pvz.RegistrationDate)
+	return pvz
+}
+
+func openReception(t *testing.T, r Repos, pvz *entity.PVZ) *entity.Reception {
+	t.Helper()
+
+	reception, err := r.Receptions.CreateReception(context.Background(), *pvz.ID, nil, nil)
+	require.NoError(t, err)
+	return reception
+}
+
+func addProduct(t *testing.T, r Repos, pvz *entity.PVZ, barcode string) *entity.Product {
+	t.Helper()
+
+	product, err := r.Products.AddProduct(context.Background(), *pvz.ID, &entity.Product{
+		Type:    entity.ElectronicsProductType,
+		Barcode: barcode,
+	})
+	require.NoError(t, err)
+	return product
+}
+
+func uniqueEmail() string {
+	return uuid.NewString() + "@example.com"
+}
+
+func uniqueBarcode() string {
+	return "test-" + uuid.NewString()
+}
@@ -2,22 +2,43 @@ package auth
 
 import (
 	"PVZ-avito-tech/internal/entity"
+	"context"
 	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrInvalidToken = errors.New("invalid token")
+	ErrRevokedToken = errors.New("token has been revoked")
 )
 
 type Claims struct {
-	Role entity.UserRole `json:"role"`
+	Role  entity.UserRole `json:"role"`
+	Email string          `json:"email,omitempty"`
 	jwt.RegisteredClaims
 }
 
+func (c *Claims) Principal() entity.Principal {
+	userID, err := uuid.Parse(c.Subject)
+	if err != nil {
+		userID = uuid.Nil
+	}
+
+	return entity.Principal{
+		UserID: userID,
+		Email:  c.Email,
+		Role:   c.Role,
+	}
+}
+
 type (
 	TokenService interface {
-		Generate(role entity.UserRole) (string, error)
-		Validate(tokenString string) (*Claims, error)
+		Generate(principal entity.Principal) (string, error)
+		Validate(ctx context.Context, tokenString string) (*Claims, error)
+	}
+
+	RevocationList interface {
+		IsRevoked(ctx context.Context, tokenID string) (bool, error)
 	}
 )
@@ -3,10 +3,17 @@ package jwt
 import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/auth"
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	_defaultAccessTTL = 15 * time.Minute
 )
 
 var (
@@ -14,21 +21,43 @@ var (
 )
 
 type Service struct {
-	secret []byte
+	secret      []byte
+	accessTTL   time.Duration
+	revocations auth.RevocationList
 }
 
-func NewService(secretKey []byte) (*Service, error) {
+func NewService(secretKey []byte, opts ...Option) (*Service, error) {
 	if len(secretKey) == 0 {
 		return nil, ErrEmptySecret
 	}
-	return &Service{secret: secretKey}, nil
+
+	s := &Service{
+		secret:    secretKey,
+		accessTTL: _defaultAccessTTL,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
-func (s *Service) Generate(role entity.UserRole) (string, error) {
+func (s *Service) Generate(principal entity.Principal) (string, error) {
+	var subject string
+	if principal.UserID != uuid.Nil {
+		subject = principal.UserID.String()
+	}
+
+	now := time.Now()
 	claims := auth.Claims{
-		Role: role,
+		Role:  principal.Role,
+		Email: principal.Email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			IssuedAt: jwt.NewNumericDate(time.Now()),
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTTL)),
 		},
 	}
 
@@ -36,18 +65,34 @@ func (s *Service) Generate(role entity.UserRole) (string, error) {
 	return token.SignedString(s.secret)
 }
 
-func (s *Service) Validate(tokenString string) (*auth.Claims, error) {
+func (s *Service) Validate(ctx context.Context, tokenString string) (*auth.Claims, error) {
 	claims := &auth.Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-		return s.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		func(t *jwt.Token) (interface{}, error) {
+			return s.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return nil, auth.ErrInvalidToken
 	}
-	if !token.Valid {
+	if !token.Valid || claims.ID == "" {
 		return nil, auth.ErrInvalidToken
 	}
 
+	if s.revocations != nil {
+		revoked, err := s.revocations.IsRevoked(ctx, claims.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, auth.ErrRevokedToken
+		}
+	}
+
 	return claims, nil
 }
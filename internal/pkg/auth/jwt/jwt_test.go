@@ -4,10 +4,13 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/auth"
 	jwtpkg "PVZ-avito-tech/internal/pkg/auth/jwt"
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,7 +74,7 @@ func TestGenerate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := service.Generate(tt.role)
+			token, err := service.Generate(entity.Principal{Role: tt.role})
 
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -88,6 +91,9 @@ func TestGenerate(t *testing.T) {
 				assert.NoError(t, err)
 				assert.True(t, parsedToken.Valid)
 				assert.Equal(t, tt.role, claims.Role)
+				assert.NotEmpty(t, claims.ID)
+				require.NotNil(t, claims.ExpiresAt)
+				assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, time.Minute)
 			}
 		})
 	}
@@ -100,13 +106,13 @@ func TestValidate(t *testing.T) {
 	require.NotNil(t, service)
 
 	validRole := entity.UserRoleModerator
-	validToken, err := service.Generate(validRole)
+	validToken, err := service.Generate(entity.Principal{Role: validRole})
 	require.NoError(t, err)
 	require.NotEmpty(t, validToken)
 
 	invalidSecretService, err := jwtpkg.NewService([]byte("different-secret"))
 	require.NoError(t, err)
-	invalidToken, err := invalidSecretService.Generate(validRole)
+	invalidToken, err := invalidSecretService.Generate(entity.Principal{Role: validRole})
 	require.NoError(t, err)
 
 	expiredClaims := auth.Claims{
@@ -119,6 +125,17 @@ func TestValidate(t *testing.T) {
 	expiredTokenString, err := expiredToken.SignedString(secretKey)
 	require.NoError(t, err)
 
+	eternalClaims := auth.Claims{
+		Role: validRole,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       "legacy",
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	eternalToken := jwt.NewWithClaims(jwt.SigningMethodHS256, eternalClaims)
+	eternalTokenString, err := eternalToken.SignedString(secretKey)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name      string
 		token     string
@@ -151,11 +168,16 @@ func TestValidate(t *testing.T) {
 			token:     expiredTokenString,
 			expectErr: true,
 		},
+		{
+			name:      "token without expiration",
+			token:     eternalTokenString,
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			claims, err := service.Validate(tt.token)
+			claims, err := service.Validate(context.Background(), tt.token)
 
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -169,3 +191,76 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+type stubRevocationList struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (s *stubRevocationList) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	return s.revoked[tokenID], s.err
+}
+
+func TestValidate_Revocation(t *testing.T) {
+	secretKey := []byte("test-secret-key")
+	list := &stubRevocationList{revoked: map[string]bool{}}
+	service, err := jwtpkg.NewService(secretKey, jwtpkg.Revocations(list))
+	require.NoError(t, err)
+
+	token, err := service.Generate(entity.Principal{Role: entity.UserRoleEmployee})
+	require.NoError(t, err)
+
+	claims, err := service.Validate(context.Background(), token)
+	require.NoError(t, err)
+
+	list.revoked[claims.ID] = true
+	claims, err = service.Validate(context.Background(), token)
+	assert.Nil(t, claims)
+	assert.Equal(t, auth.ErrRevokedToken, err)
+
+	list.err = errors.New("db is down")
+	claims, err = service.Validate(context.Background(), token)
+	assert.Nil(t, claims)
+	assert.Error(t, err)
+}
+
+func TestGenerate_AccessTTL(t *testing.T) {
+	secretKey := []byte("test-secret-key")
+	service, err := jwtpkg.NewService(secretKey, jwtpkg.AccessTTL(time.Hour))
+	require.NoError(t, err)
+
+	token, err := service.Generate(entity.Principal{Role: entity.UserRoleModerator})
+	require.NoError(t, err)
+
+	claims, err := service.Validate(context.Background(), token)
+	require.NoError(t, err)
+	require.NotNil(t, claims.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+}
+
+func TestGenerate_Principal(t *testing.T) {
+	service, err := jwtpkg.NewService([]byte("test-secret-key"))
+	require.NoError(t, err)
+
+	principal := entity.Principal{
+		UserID: uuid.New(),
+		Email:  "employee@example.com",
+		Role:   entity.UserRoleEmployee,
+	}
+
+	token, err := service.Generate(principal)
+	require.NoError(t, err)
+
+	claims, err := service.Validate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, principal.UserID.String(), claims.Subject)
+	assert.Equal(t, principal, claims.Principal())
+
+	anonymous, err := service.Generate(entity.Principal{Role: entity.UserRoleModerator})
+	require.NoError(t, err)
+
+	claims, err = service.Validate(context.Background(), anonymous)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Subject)
+	assert.Nil(t, claims.Principal().Actor())
+}
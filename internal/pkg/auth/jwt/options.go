@@ -0,0 +1,20 @@
+package jwt
+
+import (
+	"PVZ-avito-tech/internal/pkg/auth"
+	"time"
+)
+
+type Option func(*Service)
+
+func AccessTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.accessTTL = ttl
+	}
+}
+
+func Revocations(list auth.RevocationList) Option {
+	return func(s *Service) {
+		s.revocations = list
+	}
+}
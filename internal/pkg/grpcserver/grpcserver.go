@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"PVZ-avito-tech/config"
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	server          *grpc.Server
+	address         string
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+func New(cfg *config.Config, server *grpc.Server, opts ...Option) *Server {
+	s := &Server{
+		server:          server,
+		address:         net.JoinHostPort("", cfg.GRPC.Port),
+		notify:          make(chan error, 1),
+		shutdownTimeout: cfg.GRPC.ShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *Server) Start() {
+	go func() {
+		listener, err := net.Listen("tcp", s.address)
+		if err != nil {
+			s.notify <- err
+			close(s.notify)
+			return
+		}
+
+		s.notify <- s.server.Serve(listener)
+		close(s.notify)
+	}()
+}
+
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+func (s *Server) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
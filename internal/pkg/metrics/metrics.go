@@ -17,6 +17,17 @@ var (
 		Buckets: []float64{0.1, 0.5, 1, 2, 5},
 	}, []string{"method", "path"})
 
+	GRPCRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_requests_total",
+		Help: "Total number of gRPC requests",
+	}, []string{"method", "code"})
+
+	GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_request_duration_seconds",
+		Help:    "Duration of gRPC requests",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5},
+	}, []string{"method"})
+
 	PVZCreated = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "business_pvz_created_total",
 		Help: "Total number of created PVZ",
@@ -27,8 +38,58 @@ var (
 		Help: "Total number of created receptions",
 	})
 
+	ReceptionsAutoClosed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "business_receptions_auto_closed_total",
+		Help: "Total number of stale receptions closed automatically",
+	})
+
+	ReceptionsOpen = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "business_receptions_open",
+		Help: "Number of receptions currently in progress",
+	})
+
+	ReceptionsOpenOldestAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "business_receptions_open_oldest_age_seconds",
+		Help: "Age of the oldest reception currently in progress",
+	})
+
 	ProductsAdded = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "business_products_added_total",
 		Help: "Total number of added products",
 	})
+
+	ProductsIssued = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "business_products_issued_total",
+		Help: "Total number of products issued to customers",
+	})
+
+	ReturnsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "business_returns_created_total",
+		Help: "Total number of customer returns",
+	})
+
+	EventsPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_events_published_total",
+		Help: "Total number of outbox events delivered to the publisher",
+	})
+
+	EventsPublishFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_publish_failures_total",
+		Help: "Total number of failed outbox relay attempts",
+	})
+
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_deliveries_total",
+		Help: "Total number of webhook delivery attempts by result",
+	}, []string{"result"})
+
+	IdempotentReplays = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "idempotent_replays_total",
+		Help: "Total number of responses replayed for a repeated Idempotency-Key",
+	})
+
+	IdempotencyKeysPurged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "idempotency_keys_purged_total",
+		Help: "Total number of expired idempotency keys removed",
+	})
 )
@@ -0,0 +1,43 @@
+package netguard_test
+
+import (
+	"PVZ-avito-tech/internal/pkg/netguard"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.216.34", want: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.1.2.3", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "fc00::1", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, netguard.IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestControl(t *testing.T) {
+	assert.NoError(t, netguard.Control("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, netguard.Control("tcp4", "127.0.0.1:8080", nil), netguard.ErrNonPublicAddress)
+	assert.ErrorIs(t, netguard.Control("tcp6", "[::1]:8080", nil), netguard.ErrNonPublicAddress)
+}
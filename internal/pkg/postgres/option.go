@@ -1,6 +1,10 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
 
 type Option func(*Postgres)
 
@@ -21,3 +25,21 @@ func ConnTimeout(timeout time.Duration) Option {
 		c.connTimeout = timeout
 	}
 }
+
+type TxOption func(*TxManager)
+
+func TxIsolation(level pgx.TxIsoLevel) TxOption {
+	return func(m *TxManager) {
+		if level != "" {
+			m.isoLevel = level
+		}
+	}
+}
+
+func TxMaxRetries(retries int) TxOption {
+	return func(m *TxManager) {
+		if retries >= 0 {
+			m.maxRetries = retries
+		}
+	}
+}
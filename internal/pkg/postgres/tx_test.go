@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTx struct {
+	pgx.Tx
+	savepoints int
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx.savepoints++
+	return &fakeTx{}, nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if !tx.committed {
+		tx.rolledBack = true
+	}
+	return nil
+}
+
+type fakePool struct {
+	txs []*fakeTx
+}
+
+func (p *fakePool) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	p.txs = append(p.txs, tx)
+	return tx, nil
+}
+
+func TestTxManager_WithinTx(t *testing.T) {
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name             string
+		errs             []error
+		maxRetries       int
+		expectedError    error
+		expectedAttempts int
+	}{
+		{
+			name:             "commits on success",
+			errs:             []error{nil},
+			maxRetries:       3,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries serialization failure",
+			errs:             []error{&pgconn.PgError{Code: "40001"}, nil},
+			maxRetries:       3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries deadlock",
+			errs:             []error{&pgconn.PgError{Code: "40P01"}, &pgconn.PgError{Code: "40P01"}, nil},
+			maxRetries:       3,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "gives up after max retries",
+			errs:             []error{&pgconn.PgError{Code: "40001"}, &pgconn.PgError{Code: "40001"}, &pgconn.PgError{Code: "40001"}},
+			maxRetries:       2,
+			expectedError:    &pgconn.PgError{Code: "40001"},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "does not retry unique violation",
+			errs:             []error{&pgconn.PgError{Code: "23505"}},
+			maxRetries:       3,
+			expectedError:    &pgconn.PgError{Code: "23505"},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "does not retry other errors",
+			errs:             []error{errDB},
+			maxRetries:       3,
+			expectedError:    errDB,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &fakePool{}
+			m := &TxManager{pool: pool, isoLevel: pgx.ReadCommitted, maxRetries: tt.maxRetries}
+
+			attempts := 0
+			err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedAttempts, attempts)
+			assert.Len(t, pool.txs, tt.expectedAttempts)
+			for i, tx := range pool.txs {
+				last := i == len(pool.txs)-1
+				assert.Equal(t, last && tt.expectedError == nil, tx.committed)
+				assert.Equal(t, !last || tt.expectedError != nil, tx.rolledBack)
+			}
+		})
+	}
+}
+
+func TestTxManager_WithinTxJoinsOuterTransaction(t *testing.T) {
+	pool := &fakePool{}
+	m := &TxManager{pool: pool, isoLevel: pgx.ReadCommitted, maxRetries: 3}
+	pg := &Postgres{}
+
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		assert.Same(t, pool.txs[0], pg.Conn(ctx))
+
+		return m.WithinTx(ctx, func(ctx context.Context) error {
+			savepoint, err := pg.Begin(ctx)
+			if err != nil {
+				return err
+			}
+			return savepoint.Commit(ctx)
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, pool.txs, 1)
+	assert.Equal(t, 1, pool.txs[0].savepoints)
+	assert.True(t, pool.txs[0].committed)
+}
@@ -0,0 +1,32 @@
+// Package requestctx carries request-scoped metadata from the transport layer
+// down to repositories that record it, such as the audit log.
+package requestctx
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"context"
+)
+
+type (
+	principalKey struct{}
+	requestIDKey struct{}
+)
+
+func WithPrincipal(ctx context.Context, principal entity.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Principal returns the authenticated caller; background jobs have none.
+func Principal(ctx context.Context) (entity.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(entity.Principal)
+	return principal, ok
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
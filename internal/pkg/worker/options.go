@@ -0,0 +1,19 @@
+package worker
+
+import "time"
+
+type Option func(*Worker)
+
+func Interval(interval time.Duration) Option {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(w *Worker) {
+		w.shutdownTimeout = timeout
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"PVZ-avito-tech/internal/pkg/logger"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	_defaultInterval        = time.Minute
+	_defaultShutdownTimeout = 5 * time.Second
+)
+
+type Task func(ctx context.Context) error
+
+type Worker struct {
+	name            string
+	task            Task
+	logger          logger.Interface
+	interval        time.Duration
+	shutdownTimeout time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(name string, task Task, l logger.Interface, opts ...Option) *Worker {
+	w := &Worker{
+		name:            name,
+		task:            task,
+		logger:          l,
+		interval:        _defaultInterval,
+		shutdownTimeout: _defaultShutdownTimeout,
+		done:            make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *Worker) Shutdown() error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-time.After(w.shutdownTimeout):
+		return fmt.Errorf("worker %s: shutdown timed out", w.name)
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	if err := w.task(ctx); err != nil && ctx.Err() == nil {
+		w.logger.Error(fmt.Errorf("worker %s: %w", w.name, err))
+	}
+}
@@ -0,0 +1,34 @@
+package worker_test
+
+import (
+	"PVZ-avito-tech/internal/pkg/logger"
+	"PVZ-avito-tech/internal/pkg/worker"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker_RunsUntilShutdown(t *testing.T) {
+	var runs atomic.Int32
+	w := worker.New("test", func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("task failed")
+	}, logger.NewMock(), worker.Interval(10*time.Millisecond))
+
+	w.Start()
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, w.Shutdown())
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestWorker_ShutdownWithoutStart(t *testing.T) {
+	w := worker.New("test", func(ctx context.Context) error { return nil }, logger.NewMock())
+	assert.NoError(t, w.Shutdown())
+}
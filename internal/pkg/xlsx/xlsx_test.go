@@ -0,0 +1,101 @@
+package xlsx_test
+
+import (
+	"PVZ-avito-tech/internal/pkg/xlsx"
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type worksheet struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := xlsx.Write(&buf, "Приемка", [][]any{
+		{"city", "accepted"},
+		{"Москва <ЦАО>", 42},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		parts[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml",
+	} {
+		assert.Contains(t, parts, name)
+	}
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `name="Приемка"`)
+
+	var sheet worksheet
+	require.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 2)
+
+	header := sheet.Rows[0].Cells
+	require.Len(t, header, 2)
+	assert.Equal(t, "A1", header[0].Ref)
+	assert.Equal(t, "city", header[0].Inline)
+
+	data := sheet.Rows[1].Cells
+	require.Len(t, data, 2)
+	assert.Equal(t, "inlineStr", data[0].Type)
+	assert.Equal(t, "Москва <ЦАО>", data[0].Inline)
+	assert.Equal(t, "B2", data[1].Ref)
+	assert.Empty(t, data[1].Type)
+	assert.Equal(t, "42", data[1].Value)
+}
+
+func TestWrite_ColumnNames(t *testing.T) {
+	row := make([]any, 28)
+	for i := range row {
+		row[i] = i
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, xlsx.Write(&buf, "Sheet1", [][]any{row}))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	require.NoError(t, err)
+	defer f.Close()
+
+	var sheet worksheet
+	require.NoError(t, xml.NewDecoder(f).Decode(&sheet))
+	cells := sheet.Rows[0].Cells
+	assert.Equal(t, "Z1", cells[25].Ref)
+	assert.Equal(t, "AA1", cells[26].Ref)
+	assert.Equal(t, "AB1", cells[27].Ref)
+}
+
+func TestWrite_InvalidSheetName(t *testing.T) {
+	assert.Error(t, xlsx.Write(io.Discard, "", nil))
+	assert.Error(t, xlsx.Write(io.Discard, "a sheet name that is far too long for excel", nil))
+}
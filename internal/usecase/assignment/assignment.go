@@ -0,0 +1,67 @@
+package assignment
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"github.com/google/uuid"
+)
+
+type UseCase struct {
+	repo    repo.PVZAssignmentRepo
+	enforce bool
+}
+
+func NewUseCase(repo repo.PVZAssignmentRepo, enforce bool) *UseCase {
+	return &UseCase{
+		repo:    repo,
+		enforce: enforce,
+	}
+}
+
+func (uc *UseCase) Assign(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	userID uuid.UUID,
+	actor entity.Principal,
+) (*entity.PVZAssignment, error) {
+	assignment := &entity.PVZAssignment{
+		UserID:     userID,
+		PVZID:      pvzID,
+		AssignedBy: actor.Actor(),
+	}
+
+	if err := uc.repo.Assign(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (uc *UseCase) Unassign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) error {
+	return uc.repo.Unassign(ctx, userID, pvzID)
+}
+
+func (uc *UseCase) ListEmployees(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	return uc.repo.ListByPVZ(ctx, pvzID)
+}
+
+func (uc *UseCase) CheckAccess(ctx context.Context, principal entity.Principal, pvzID uuid.UUID) error {
+	if !uc.enforce || principal.Role == entity.UserRoleModerator {
+		return nil
+	}
+
+	if principal.UserID == uuid.Nil {
+		return entity.ErrPVZAccessDenied
+	}
+
+	assigned, err := uc.repo.IsAssigned(ctx, principal.UserID, pvzID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return entity.ErrPVZAccessDenied
+	}
+
+	return nil
+}
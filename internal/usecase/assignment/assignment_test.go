@@ -0,0 +1,171 @@
+package assignment_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/assignment"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type MockPVZAssignmentRepo struct {
+	mock.Mock
+}
+
+func (m *MockPVZAssignmentRepo) Assign(ctx context.Context, a *entity.PVZAssignment) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockPVZAssignmentRepo) Unassign(ctx context.Context, userID, pvzID uuid.UUID) error {
+	args := m.Called(ctx, userID, pvzID)
+	return args.Error(0)
+}
+
+func (m *MockPVZAssignmentRepo) IsAssigned(ctx context.Context, userID, pvzID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, pvzID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPVZAssignmentRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error) {
+	args := m.Called(ctx, pvzID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PVZAssignment), args.Error(1)
+}
+
+func TestUseCase_Assign(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	userID := uuid.New()
+	moderatorID := uuid.New()
+	moderator := entity.Principal{UserID: moderatorID, Role: entity.UserRoleModerator}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockPVZAssignmentRepo)
+		expectedError error
+	}{
+		{
+			name: "successful assignment",
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {
+				mockRepo.On("Assign", ctx, mock.MatchedBy(func(a *entity.PVZAssignment) bool {
+					return a.UserID == userID && a.PVZID == pvzID && *a.AssignedBy == moderatorID
+				})).Return(nil)
+			},
+		},
+		{
+			name: "user is not an employee",
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {
+				mockRepo.On("Assign", ctx, mock.Anything).Return(entity.ErrEmployeeNotFound)
+			},
+			expectedError: entity.ErrEmployeeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPVZAssignmentRepo)
+			uc := assignment.NewUseCase(mockRepo, true)
+
+			tt.mockSetup(mockRepo)
+
+			resp, err := uc.Assign(ctx, pvzID, userID, moderator)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, resp.UserID)
+				assert.Equal(t, pvzID, resp.PVZID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_CheckAccess(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		enforce       bool
+		principal     entity.Principal
+		mockSetup     func(*MockPVZAssignmentRepo)
+		expectedError error
+	}{
+		{
+			name:      "assigned employee",
+			enforce:   true,
+			principal: entity.Principal{UserID: userID, Role: entity.UserRoleEmployee},
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {
+				mockRepo.On("IsAssigned", ctx, userID, pvzID).Return(true, nil)
+			},
+		},
+		{
+			name:      "not assigned employee",
+			enforce:   true,
+			principal: entity.Principal{UserID: userID, Role: entity.UserRoleEmployee},
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {
+				mockRepo.On("IsAssigned", ctx, userID, pvzID).Return(false, nil)
+			},
+			expectedError: entity.ErrPVZAccessDenied,
+		},
+		{
+			name:          "employee token without subject",
+			enforce:       true,
+			principal:     entity.Principal{Role: entity.UserRoleEmployee},
+			mockSetup:     func(mockRepo *MockPVZAssignmentRepo) {},
+			expectedError: entity.ErrPVZAccessDenied,
+		},
+		{
+			name:      "moderator bypasses assignment",
+			enforce:   true,
+			principal: entity.Principal{UserID: userID, Role: entity.UserRoleModerator},
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {},
+		},
+		{
+			name:      "enforcement disabled",
+			enforce:   false,
+			principal: entity.Principal{Role: entity.UserRoleEmployee},
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {},
+		},
+		{
+			name:      "repository error",
+			enforce:   true,
+			principal: entity.Principal{UserID: userID, Role: entity.UserRoleEmployee},
+			mockSetup: func(mockRepo *MockPVZAssignmentRepo) {
+				mockRepo.On("IsAssigned", ctx, userID, pvzID).Return(false, errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPVZAssignmentRepo)
+			uc := assignment.NewUseCase(mockRepo, tt.enforce)
+
+			tt.mockSetup(mockRepo)
+
+			err := uc.CheckAccess(ctx, tt.principal, pvzID)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
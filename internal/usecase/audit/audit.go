@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const _defaultLimit = 100
+
+type UseCase struct {
+	repo repo.AuditRepo
+}
+
+func NewUseCase(repo repo.AuditRepo) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+// List returns audit entries newest first; pass the last ID as BeforeID to
+// fetch the next page.
+func (uc *UseCase) List(ctx context.Context, filter dto.AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.EntityType != "" && !filter.EntityType.IsValid() {
+		return nil, entity.ErrInvalidAuditFilter
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, entity.ErrInvalidAuditPeriod
+	}
+
+	entityID, err := parseOptionalUUID(filter.EntityID)
+	if err != nil {
+		return nil, entity.ErrInvalidAuditFilter
+	}
+	actorID, err := parseOptionalUUID(filter.ActorID)
+	if err != nil {
+		return nil, entity.ErrInvalidAuditFilter
+	}
+
+	query := entity.AuditFilter{
+		EntityType: filter.EntityType,
+		EntityID:   entityID,
+		ActorID:    actorID,
+		From:       optionalTime(filter.From),
+		To:         optionalTime(filter.To),
+		BeforeID:   filter.BeforeID,
+		Limit:      filter.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = _defaultLimit
+	}
+
+	return uc.repo.List(ctx, query)
+}
+
+func parseOptionalUUID(s string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,121 @@
+package audit_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/audit"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepo) List(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AuditEntry), args.Error(1)
+}
+
+func TestUseCase_List(t *testing.T) {
+	ctx := context.Background()
+	entityID := uuid.New()
+	actorID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	entries := []entity.AuditEntry{{ID: 7, Action: entity.AuditPVZCreated}}
+
+	tests := []struct {
+		name          string
+		filter        dto.AuditFilter
+		mockSetup     func(*MockAuditRepo)
+		expected      []entity.AuditEntry
+		expectedError error
+	}{
+		{
+			name:   "default limit",
+			filter: dto.AuditFilter{},
+			mockSetup: func(repo *MockAuditRepo) {
+				repo.On("List", ctx, entity.AuditFilter{Limit: 100}).Return(entries, nil)
+			},
+			expected: entries,
+		},
+		{
+			name: "all filters",
+			filter: dto.AuditFilter{
+				EntityType: entity.AuditEntityProduct,
+				EntityID:   entityID.String(),
+				ActorID:    actorID.String(),
+				From:       from,
+				To:         to,
+				BeforeID:   42,
+				Limit:      10,
+			},
+			mockSetup: func(repo *MockAuditRepo) {
+				repo.On("List", ctx, entity.AuditFilter{
+					EntityType: entity.AuditEntityProduct,
+					EntityID:   &entityID,
+					ActorID:    &actorID,
+					From:       &from,
+					To:         &to,
+					BeforeID:   42,
+					Limit:      10,
+				}).Return(entries, nil)
+			},
+			expected: entries,
+		},
+		{
+			name:          "unknown entity type",
+			filter:        dto.AuditFilter{EntityType: "user"},
+			expectedError: entity.ErrInvalidAuditFilter,
+		},
+		{
+			name:          "malformed actor id",
+			filter:        dto.AuditFilter{ActorID: "not-a-uuid"},
+			expectedError: entity.ErrInvalidAuditFilter,
+		},
+		{
+			name:          "inverted period",
+			filter:        dto.AuditFilter{From: to, To: from},
+			expectedError: entity.ErrInvalidAuditPeriod,
+		},
+		{
+			name:   "repository error",
+			filter: dto.AuditFilter{},
+			mockSetup: func(repo *MockAuditRepo) {
+				repo.On("List", ctx, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			expectedError: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAuditRepo)
+			if tt.mockSetup != nil {
+				tt.mockSetup(repo)
+			}
+
+			uc := audit.NewUseCase(repo)
+			result, err := uc.List(ctx, tt.filter)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
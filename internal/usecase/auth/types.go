@@ -6,7 +6,9 @@ import (
 )
 
 type LoginResponse struct {
-	Role entity.UserRole `json:"role"`
+	Id    uuid.UUID       `json:"id"`
+	Email string          `json:"email"`
+	Role  entity.UserRole `json:"role"`
 }
 
 type RegisterResponse struct {
@@ -56,5 +56,5 @@ func (uc *UserUsecase) Login(ctx context.Context, email string, rawPassword stri
 		return LoginResponse{}, err
 	}
 
-	return LoginResponse{Role: u.Role}, nil
+	return LoginResponse{Id: u.ID, Email: u.Email, Role: u.Role}, nil
 }
@@ -150,7 +150,8 @@ func TestUserUsecase_Login(t *testing.T) {
 				mockHasher.On("Verify", "hashed_password", "password123").Return(nil)
 			},
 			expectedResp: auth.LoginResponse{
-				Role: entity.UserRoleModerator,
+				Email: "test@example.com",
+				Role:  entity.UserRoleModerator,
 			},
 			expectedError: nil,
 		},
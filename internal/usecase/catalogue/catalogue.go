@@ -0,0 +1,55 @@
+package catalogue
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UseCase struct {
+	repo repo.CatalogueRepo
+}
+
+func NewUseCase(repo repo.CatalogueRepo) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func (uc *UseCase) CreateType(ctx context.Context, name entity.ProductType) (*entity.ProductTypeInfo, error) {
+	if !name.IsValidProductType() {
+		return nil, entity.ErrInvalidProductType
+	}
+
+	productType := &entity.ProductTypeInfo{Name: name}
+	if err := uc.repo.CreateType(ctx, productType); err != nil {
+		return nil, err
+	}
+
+	return productType, nil
+}
+
+func (uc *UseCase) ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error) {
+	return uc.repo.ListTypes(ctx, includeInactive)
+}
+
+func (uc *UseCase) SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error) {
+	return uc.repo.SetTypeActive(ctx, id, active)
+}
+
+func (uc *UseCase) CreateCategory(ctx context.Context, typeID uuid.UUID, name string) (*entity.ProductCategory, error) {
+	if !entity.IsValidCatalogueName(name) {
+		return nil, entity.ErrInvalidProductCategory
+	}
+
+	category := &entity.ProductCategory{TypeID: typeID, Name: name}
+	if err := uc.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (uc *UseCase) SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error) {
+	return uc.repo.SetCategoryActive(ctx, typeID, id, active)
+}
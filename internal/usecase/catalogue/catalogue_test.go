@@ -0,0 +1,163 @@
+package catalogue_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/catalogue"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCatalogueRepo struct {
+	mock.Mock
+}
+
+func (m *MockCatalogueRepo) CreateType(ctx context.Context, productType *entity.ProductTypeInfo) error {
+	args := m.Called(ctx, productType)
+	return args.Error(0)
+}
+
+func (m *MockCatalogueRepo) ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error) {
+	args := m.Called(ctx, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductTypeInfo), args.Error(1)
+}
+
+func (m *MockCatalogueRepo) SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error) {
+	args := m.Called(ctx, id, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductTypeInfo), args.Error(1)
+}
+
+func (m *MockCatalogueRepo) CreateCategory(ctx context.Context, category *entity.ProductCategory) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCatalogueRepo) SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error) {
+	args := m.Called(ctx, typeID, id, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductCategory), args.Error(1)
+}
+
+func TestUseCase_CreateType(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		productType   entity.ProductType
+		mockSetup     func(*MockCatalogueRepo)
+		expectedError error
+	}{
+		{
+			name:        "successful creation",
+			productType: "мебель",
+			mockSetup: func(mockRepo *MockCatalogueRepo) {
+				mockRepo.On("CreateType", ctx, mock.MatchedBy(func(pt *entity.ProductTypeInfo) bool {
+					return pt.Name == "мебель"
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "invalid name",
+			productType:   "",
+			mockSetup:     func(mockRepo *MockCatalogueRepo) {},
+			expectedError: entity.ErrInvalidProductType,
+		},
+		{
+			name:        "duplicate type",
+			productType: entity.ShoesProductType,
+			mockSetup: func(mockRepo *MockCatalogueRepo) {
+				mockRepo.On("CreateType", ctx, mock.Anything).Return(entity.ErrProductTypeAlreadyExists)
+			},
+			expectedError: entity.ErrProductTypeAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCatalogueRepo)
+			uc := catalogue.NewUseCase(mockRepo)
+
+			tt.mockSetup(mockRepo)
+
+			resp, err := uc.CreateType(ctx, tt.productType)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.productType, resp.Name)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_CreateCategory(t *testing.T) {
+	ctx := context.Background()
+	typeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		category      string
+		mockSetup     func(*MockCatalogueRepo)
+		expectedError error
+	}{
+		{
+			name:     "successful creation",
+			category: "кроссовки",
+			mockSetup: func(mockRepo *MockCatalogueRepo) {
+				mockRepo.On("CreateCategory", ctx, mock.MatchedBy(func(c *entity.ProductCategory) bool {
+					return c.TypeID == typeID && c.Name == "кроссовки"
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "invalid name",
+			category:      " кроссовки",
+			mockSetup:     func(mockRepo *MockCatalogueRepo) {},
+			expectedError: entity.ErrInvalidProductCategory,
+		},
+		{
+			name:     "unknown type",
+			category: "кеды",
+			mockSetup: func(mockRepo *MockCatalogueRepo) {
+				mockRepo.On("CreateCategory", ctx, mock.Anything).Return(entity.ErrProductTypeNotFound)
+			},
+			expectedError: entity.ErrProductTypeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCatalogueRepo)
+			uc := catalogue.NewUseCase(mockRepo)
+
+			tt.mockSetup(mockRepo)
+
+			resp, err := uc.CreateCategory(ctx, typeID, tt.category)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, typeID, resp.TypeID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package city
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UseCase struct {
+	repo repo.CityRepo
+}
+
+func NewUseCase(repo repo.CityRepo) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func (uc *UseCase) Create(ctx context.Context, name entity.City) (*entity.CityInfo, error) {
+	if !name.IsValidCity() {
+		return nil, entity.ErrInvalidCity
+	}
+
+	city := &entity.CityInfo{Name: name}
+	if err := uc.repo.Create(ctx, city); err != nil {
+		return nil, err
+	}
+
+	return city, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+func (uc *UseCase) List(ctx context.Context) ([]entity.CityInfo, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *UseCase) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	if !name.IsValidCity() {
+		return nil, entity.ErrInvalidCity
+	}
+
+	return uc.repo.Rename(ctx, id, name)
+}
+
+func (uc *UseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.repo.Delete(ctx, id)
+}
@@ -0,0 +1,176 @@
+package city_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/city"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCityRepo struct {
+	mock.Mock
+}
+
+func (m *MockCityRepo) Create(ctx context.Context, c *entity.CityInfo) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestUseCase_Create(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		city          entity.City
+		mockSetup     func(*MockCityRepo)
+		expectedError error
+	}{
+		{
+			name: "successful creation",
+			city: "Новосибирск",
+			mockSetup: func(mockRepo *MockCityRepo) {
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(c *entity.CityInfo) bool {
+					return c.Name == "Новосибирск"
+				})).Run(func(args mock.Arguments) {
+					c := args.Get(1).(*entity.CityInfo)
+					c.ID = id
+					c.CreatedAt = now
+				}).Return(nil)
+			},
+		},
+		{
+			name:          "invalid name",
+			city:          "",
+			mockSetup:     func(mockRepo *MockCityRepo) {},
+			expectedError: entity.ErrInvalidCity,
+		},
+		{
+			name: "duplicate city",
+			city: entity.CityMoscow,
+			mockSetup: func(mockRepo *MockCityRepo) {
+				mockRepo.On("Create", ctx, mock.Anything).Return(entity.ErrCityAlreadyExists)
+			},
+			expectedError: entity.ErrCityAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCityRepo)
+			uc := city.NewUseCase(mockRepo)
+
+			tt.mockSetup(mockRepo)
+
+			resp, err := uc.Create(ctx, tt.city)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, id, resp.ID)
+				assert.Equal(t, tt.city, resp.Name)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Rename(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		city          entity.City
+		mockSetup     func(*MockCityRepo)
+		expectedError error
+	}{
+		{
+			name: "successful rename",
+			city: "Нижний Новгород",
+			mockSetup: func(mockRepo *MockCityRepo) {
+				mockRepo.On("Rename", ctx, id, entity.City("Нижний Новгород")).Return(&entity.CityInfo{
+					ID:   id,
+					Name: "Нижний Новгород",
+				}, nil)
+			},
+		},
+		{
+			name:          "invalid name",
+			city:          "  ",
+			mockSetup:     func(mockRepo *MockCityRepo) {},
+			expectedError: entity.ErrInvalidCity,
+		},
+		{
+			name: "city not found",
+			city: "Тверь",
+			mockSetup: func(mockRepo *MockCityRepo) {
+				mockRepo.On("Rename", ctx, id, entity.City("Тверь")).Return(nil, entity.ErrCityNotFound)
+			},
+			expectedError: entity.ErrCityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCityRepo)
+			uc := city.NewUseCase(mockRepo)
+
+			tt.mockSetup(mockRepo)
+
+			resp, err := uc.Rename(ctx, id, tt.city)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.city, resp.Name)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,9 +3,13 @@ package usecase
 import (
 	"PVZ-avito-tech/internal/controller/http/dto"
 	"PVZ-avito-tech/internal/entity"
+	authPkg "PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/usecase/auth"
+	"PVZ-avito-tech/internal/usecase/token"
+	"PVZ-avito-tech/internal/usecase/webhook"
 	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type (
@@ -16,16 +20,93 @@ type (
 	DummyLogin interface {
 		GenerateDummyToken(role entity.UserRole) (string, error)
 	}
+	Token interface {
+		Issue(ctx context.Context, principal entity.Principal) (token.Pair, error)
+		Refresh(ctx context.Context, refreshToken string) (token.Pair, error)
+		Logout(ctx context.Context, refreshToken string, claims *authPkg.Claims) error
+	}
 	PVZUseCase interface {
 		CreatePVZ(ctx context.Context, pvz *entity.PVZ) (*entity.PVZ, error)
 		GetPVZWithReceptions(ctx context.Context, filter dto.ReceptionFilter) (*[]dto.PVZInfo, error)
+		GetPVZPage(ctx context.Context, filter dto.ReceptionFilter) (*dto.PVZPage, error)
+		GetPVZ(ctx context.Context, id uuid.UUID) (*dto.PVZDetails, error)
+	}
+	City interface {
+		Create(ctx context.Context, name entity.City) (*entity.CityInfo, error)
+		Get(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error)
+		List(ctx context.Context) ([]entity.CityInfo, error)
+		Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error)
+		Delete(ctx context.Context, id uuid.UUID) error
+	}
+	Catalogue interface {
+		CreateType(ctx context.Context, name entity.ProductType) (*entity.ProductTypeInfo, error)
+		ListTypes(ctx context.Context, includeInactive bool) ([]entity.ProductTypeInfo, error)
+		SetTypeActive(ctx context.Context, id uuid.UUID, active bool) (*entity.ProductTypeInfo, error)
+		CreateCategory(ctx context.Context, typeID uuid.UUID, name string) (*entity.ProductCategory, error)
+		SetCategoryActive(ctx context.Context, typeID, id uuid.UUID, active bool) (*entity.ProductCategory, error)
 	}
 	ReceptionUseCase interface {
-		CreateReception(ctx context.Context, request dto.ReceptionsRequest) (*entity.Reception, error)
-		CloseReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error)
+		CreateReception(ctx context.Context, request dto.ReceptionsRequest, actor entity.Principal) (*entity.Reception, error)
+		CloseReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error)
+		GetReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error)
+		ReopenReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error)
+		CancelReception(ctx context.Context, id uuid.UUID, reason string, actor entity.Principal) (*entity.Reception, error)
+		StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ReceptionStatusChange, error)
+		CloseStaleReceptions(ctx context.Context, idleFor time.Duration) ([]entity.Reception, error)
+		OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error)
+		DiscrepancyReport(ctx context.Context, id uuid.UUID) (*entity.DiscrepancyReport, error)
+		ListProducts(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error)
+	}
+	PVZAssignment interface {
+		Assign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID, actor entity.Principal) (*entity.PVZAssignment, error)
+		Unassign(ctx context.Context, pvzID uuid.UUID, userID uuid.UUID) error
+		ListEmployees(ctx context.Context, pvzID uuid.UUID) ([]entity.PVZAssignment, error)
+		CheckAccess(ctx context.Context, principal entity.Principal, pvzID uuid.UUID) error
+	}
+	Returns interface {
+		CreateReturn(ctx context.Context, request dto.CreateReturnRequest, actor entity.Principal) (*entity.Return, error)
+		ListReturns(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error)
+		OpenShipment(ctx context.Context, pvzID uuid.UUID, actor entity.Principal) (*entity.ReturnShipment, error)
+		AddToShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error)
+		CloseShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error)
+		Report(ctx context.Context, filter dto.ReturnsReportFilter) ([]entity.ReturnStats, error)
+	}
+	Reports interface {
+		Acceptance(ctx context.Context, filter dto.AcceptanceReportFilter) ([]entity.AcceptanceReportRow, error)
 	}
 	ProductUseCase interface {
-		AddProduct(ctx context.Context, product *dto.PostAddProductRequest) (*entity.Product, error)
+		AddProduct(ctx context.Context, product *dto.PostAddProductRequest, actor entity.Principal) (*entity.Product, error)
+		AddProducts(ctx context.Context, batch *dto.PostAddProductsBatchRequest, actor entity.Principal) ([]entity.ProductBatchResult, error)
 		DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error
+		DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, actor entity.Principal) (*entity.ProductDeletion, error)
+		GetProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+		IssueProduct(ctx context.Context, id uuid.UUID, pickupCode string, actor entity.Principal) (*entity.Product, error)
+		StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ProductStatusChange, error)
+		FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error)
+	}
+	Outbox interface {
+		Relay(ctx context.Context) (int, error)
+	}
+	Idempotency interface {
+		Begin(ctx context.Context, scope, key, requestHash string) (*entity.IdempotencyRecord, error)
+		Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error
+		Release(ctx context.Context, scope, key string) error
+		Purge(ctx context.Context) (int64, error)
+	}
+	Audit interface {
+		List(ctx context.Context, filter dto.AuditFilter) ([]entity.AuditEntry, error)
+	}
+	Events interface {
+		Listen(ctx context.Context) error
+		SubscribePVZ(ctx context.Context, pvzID uuid.UUID, lastEventID int64) (<-chan entity.Event, error)
+		SubscribeCity(ctx context.Context, cityID uuid.UUID, lastEventID int64) (<-chan entity.Event, error)
+	}
+	Webhooks interface {
+		CreateSubscription(ctx context.Context, request dto.CreateWebhookSubscriptionRequest, actor entity.Principal) (*dto.WebhookSubscriptionCreated, error)
+		ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+		DeleteSubscription(ctx context.Context, id uuid.UUID) error
+		Deliveries(ctx context.Context, subscriptionID uuid.UUID, filter dto.WebhookDeliveryFilter) ([]entity.WebhookDelivery, error)
+		Publish(ctx context.Context, events []entity.Event) error
+		Deliver(ctx context.Context) (webhook.DeliveryStats, error)
 	}
 )
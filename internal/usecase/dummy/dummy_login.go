@@ -14,5 +14,5 @@ func NewDummyAuthUseCase(jwtService auth.TokenService) *AuthUseCase {
 }
 
 func (d *AuthUseCase) GenerateDummyToken(role entity.UserRole) (string, error) {
-	return d.jwtService.Generate(role)
+	return d.jwtService.Generate(entity.Principal{Role: role})
 }
@@ -4,6 +4,7 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/pkg/auth"
 	"PVZ-avito-tech/internal/usecase/dummy"
+	"context"
 	"errors"
 	"testing"
 
@@ -15,13 +16,13 @@ type MockTokenService struct {
 	mock.Mock
 }
 
-func (m *MockTokenService) Generate(role entity.UserRole) (string, error) {
-	args := m.Called(role)
+func (m *MockTokenService) Generate(principal entity.Principal) (string, error) {
+	args := m.Called(principal)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockTokenService) Validate(token string) (*auth.Claims, error) {
-	args := m.Called(token)
+func (m *MockTokenService) Validate(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -40,7 +41,7 @@ func TestAuthUseCase_GenerateDummyToken(t *testing.T) {
 			name: "successful token generation for employee",
 			role: entity.UserRoleEmployee,
 			mockSetup: func(mockJWT *MockTokenService) {
-				mockJWT.On("Generate", entity.UserRoleEmployee).Return("employee-token", nil)
+				mockJWT.On("Generate", entity.Principal{Role: entity.UserRoleEmployee}).Return("employee-token", nil)
 			},
 			expectedToken: "employee-token",
 			expectedError: nil,
@@ -49,7 +50,7 @@ func TestAuthUseCase_GenerateDummyToken(t *testing.T) {
 			name: "successful token generation for moderator",
 			role: entity.UserRoleModerator,
 			mockSetup: func(mockJWT *MockTokenService) {
-				mockJWT.On("Generate", entity.UserRoleModerator).Return("moderator-token", nil)
+				mockJWT.On("Generate", entity.Principal{Role: entity.UserRoleModerator}).Return("moderator-token", nil)
 			},
 			expectedToken: "moderator-token",
 			expectedError: nil,
@@ -58,7 +59,7 @@ func TestAuthUseCase_GenerateDummyToken(t *testing.T) {
 			name: "error during token generation",
 			role: entity.UserRoleEmployee,
 			mockSetup: func(mockJWT *MockTokenService) {
-				mockJWT.On("Generate", entity.UserRoleEmployee).Return("", errors.New("token generation failed"))
+				mockJWT.On("Generate", entity.Principal{Role: entity.UserRoleEmployee}).Return("", errors.New("token generation failed"))
 			},
 			expectedToken: "",
 			expectedError: errors.New("token generation failed"),
@@ -67,7 +68,7 @@ func TestAuthUseCase_GenerateDummyToken(t *testing.T) {
 			name: "invalid role",
 			role: "invalid-role",
 			mockSetup: func(mockJWT *MockTokenService) {
-				mockJWT.On("Generate", entity.UserRole("invalid-role")).Return("", errors.New("invalid role"))
+				mockJWT.On("Generate", entity.Principal{Role: entity.UserRole("invalid-role")}).Return("", errors.New("invalid role"))
 			},
 			expectedToken: "",
 			expectedError: errors.New("invalid role"),
@@ -0,0 +1,193 @@
+package events
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// _bufferSize bounds how far a subscriber may lag behind the live stream
+	// before it is dropped and has to reconnect with Last-Event-ID.
+	_bufferSize = 64
+	_replayPage = 500
+	// _replayWindow covers transactions that commit their events after later
+	// ones; it must outlast the longest transaction writing to the outbox.
+	_replayWindow = time.Minute
+)
+
+type subscriber struct {
+	filter entity.EventFilter
+	live   chan entity.Event
+}
+
+type UseCase struct {
+	repo     repo.EventStreamRepo
+	pvzRepo  repo.PVZRepo
+	cityRepo repo.CityRepo
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func NewUseCase(repo repo.EventStreamRepo, pvzRepo repo.PVZRepo, cityRepo repo.CityRepo) *UseCase {
+	return &UseCase{
+		repo:        repo,
+		pvzRepo:     pvzRepo,
+		cityRepo:    cityRepo,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Listen feeds committed events of all app instances to the local subscribers
+// until ctx is done or the underlying connection fails. Subscribers are then
+// disconnected, since events published while nobody listens would be missed;
+// clients resume from Last-Event-ID.
+func (uc *UseCase) Listen(ctx context.Context) error {
+	defer uc.closeAll()
+	return uc.repo.Listen(ctx, uc.broadcast)
+}
+
+func (uc *UseCase) SubscribePVZ(ctx context.Context, pvzID uuid.UUID, lastEventID int64) (<-chan entity.Event, error) {
+	if _, err := uc.pvzRepo.GetByID(ctx, pvzID); err != nil {
+		return nil, err
+	}
+	return uc.subscribe(ctx, entity.EventFilter{PVZID: pvzID}, lastEventID)
+}
+
+func (uc *UseCase) SubscribeCity(ctx context.Context, cityID uuid.UUID, lastEventID int64) (<-chan entity.Event, error) {
+	city, err := uc.cityRepo.GetByID(ctx, cityID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.subscribe(ctx, entity.EventFilter{City: city.Name}, lastEventID)
+}
+
+// subscribe registers for live events before replaying the backlog after
+// lastEventID, so nothing committed in between is lost. The replay reaches back
+// over events that may have committed late, so clients see some events again
+// and deduplicate them by event ID. The returned channel is closed when ctx is
+// done or the subscriber falls too far behind.
+func (uc *UseCase) subscribe(ctx context.Context, filter entity.EventFilter, lastEventID int64) (<-chan entity.Event, error) {
+	sub := &subscriber{
+		filter: filter,
+		live:   make(chan entity.Event, _bufferSize),
+	}
+	uc.add(sub)
+
+	var backlog []entity.Event
+	if lastEventID > 0 {
+		after, err := uc.repo.ReplayStart(ctx, lastEventID, _replayWindow)
+		if err != nil {
+			uc.remove(sub)
+			return nil, err
+		}
+		for {
+			page, err := uc.repo.EventsSince(ctx, filter, after, _replayPage)
+			if err != nil {
+				uc.remove(sub)
+				return nil, err
+			}
+			backlog = append(backlog, page...)
+			if len(page) < _replayPage {
+				break
+			}
+			after = page[len(page)-1].Sequence
+		}
+	}
+
+	out := make(chan entity.Event)
+	go uc.forward(ctx, sub, backlog, out)
+
+	return out, nil
+}
+
+// forward sends the backlog and then the live events. Live events that were
+// already replayed are skipped by ID: a sequence cut-off would also drop events
+// that committed after the replay with a lower sequence.
+func (uc *UseCase) forward(ctx context.Context, sub *subscriber, backlog []entity.Event, out chan<- entity.Event) {
+	defer close(out)
+	defer uc.remove(sub)
+
+	send := func(event entity.Event) bool {
+		select {
+		case out <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	replayed := make(map[uuid.UUID]struct{}, len(backlog))
+	for _, event := range backlog {
+		if !send(event) {
+			return
+		}
+		replayed[event.ID] = struct{}{}
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.live:
+			if !ok {
+				return
+			}
+			if _, ok := replayed[event.ID]; ok {
+				continue
+			}
+			if !send(event) {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (uc *UseCase) broadcast(event entity.Event) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for sub := range uc.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.live <- event:
+		default:
+			delete(uc.subscribers, sub)
+			close(sub.live)
+		}
+	}
+}
+
+func (uc *UseCase) add(sub *subscriber) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.subscribers[sub] = struct{}{}
+}
+
+func (uc *UseCase) remove(sub *subscriber) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if _, ok := uc.subscribers[sub]; ok {
+		delete(uc.subscribers, sub)
+		close(sub.live)
+	}
+}
+
+func (uc *UseCase) closeAll() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for sub := range uc.subscribers {
+		delete(uc.subscribers, sub)
+		close(sub.live)
+	}
+}
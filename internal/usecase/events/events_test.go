@@ -0,0 +1,275 @@
+package events_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/events"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockEventStreamRepo struct {
+	mock.Mock
+	feed chan entity.Event
+}
+
+func newMockEventStreamRepo() *MockEventStreamRepo {
+	return &MockEventStreamRepo{feed: make(chan entity.Event)}
+}
+
+func (m *MockEventStreamRepo) Listen(ctx context.Context, handle func(entity.Event)) error {
+	for {
+		select {
+		case event := <-m.feed:
+			handle(event)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *MockEventStreamRepo) EventsSince(
+	ctx context.Context,
+	filter entity.EventFilter,
+	afterSeq int64,
+	limit int,
+) ([]entity.Event, error) {
+	args := m.Called(ctx, filter, afterSeq, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Event), args.Error(1)
+}
+
+func (m *MockEventStreamRepo) ReplayStart(ctx context.Context, afterSeq int64, window time.Duration) (int64, error) {
+	args := m.Called(ctx, afterSeq, window)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockPVZRepo struct {
+	mock.Mock
+}
+
+func (m *MockPVZRepo) Create(ctx context.Context, pvz *entity.PVZ) error {
+	args := m.Called(ctx, pvz)
+	return args.Error(0)
+}
+
+func (m *MockPVZRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.PVZ, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PVZ), args.Error(1)
+}
+
+func (m *MockPVZRepo) GetPVZWithReceptions(ctx context.Context, filter dto.ReceptionFilter) (*[]dto.PVZInfo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.PVZInfo), args.Error(1)
+}
+
+type MockCityRepo struct {
+	mock.Mock
+}
+
+func (m *MockCityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	args := m.Called(ctx, city)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func newEvent(seq int64, pvzID uuid.UUID, city entity.City) entity.Event {
+	return entity.Event{
+		ID:       uuid.New(),
+		Type:     entity.EventProductAdded,
+		Version:  1,
+		PVZID:    pvzID,
+		Sequence: seq,
+		City:     city,
+	}
+}
+
+func receive(t *testing.T, ch <-chan entity.Event, n int) []int64 {
+	t.Helper()
+	seqs := make([]int64, 0, n)
+	for len(seqs) < n {
+		select {
+		case event, ok := <-ch:
+			require.True(t, ok, "stream closed after %v", seqs)
+			seqs = append(seqs, event.Sequence)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %v", seqs)
+		}
+	}
+	return seqs
+}
+
+func waitClosed(t *testing.T, ch <-chan entity.Event) int {
+	t.Helper()
+	received := 0
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return received
+			}
+			received++
+		case <-time.After(time.Second):
+			t.Fatal("stream was not closed")
+		}
+	}
+}
+
+func startListener(t *testing.T, uc *events.UseCase) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = uc.Listen(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestUseCase_SubscribePVZ_UnknownPVZ(t *testing.T) {
+	pvzRepo := new(MockPVZRepo)
+	pvzID := uuid.New()
+	pvzRepo.On("GetByID", mock.Anything, pvzID).Return(nil, entity.ErrPVZNotFound)
+
+	uc := events.NewUseCase(newMockEventStreamRepo(), pvzRepo, new(MockCityRepo))
+
+	_, err := uc.SubscribePVZ(context.Background(), pvzID, 0)
+
+	assert.ErrorIs(t, err, entity.ErrPVZNotFound)
+}
+
+func TestUseCase_SubscribePVZ_ReplaysThenStreamsLive(t *testing.T) {
+	streamRepo := newMockEventStreamRepo()
+	pvzRepo := new(MockPVZRepo)
+	pvzID := uuid.New()
+	filter := entity.EventFilter{PVZID: pvzID}
+
+	backlog := []entity.Event{newEvent(2, pvzID, ""), newEvent(4, pvzID, ""), newEvent(5, pvzID, "")}
+
+	pvzRepo.On("GetByID", mock.Anything, pvzID).Return(&entity.PVZ{ID: &pvzID}, nil)
+	streamRepo.On("ReplayStart", mock.Anything, int64(3), mock.Anything).Return(int64(1), nil)
+	streamRepo.On("EventsSince", mock.Anything, filter, int64(1), mock.Anything).Return(backlog, nil)
+
+	uc := events.NewUseCase(streamRepo, pvzRepo, new(MockCityRepo))
+	stop := startListener(t, uc)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := uc.SubscribePVZ(ctx, pvzID, 3)
+	require.NoError(t, err)
+
+	streamRepo.feed <- backlog[2]
+	streamRepo.feed <- newEvent(6, uuid.New(), "")
+	// Committed after the replay with a lower sequence than the replayed ones.
+	streamRepo.feed <- newEvent(3, pvzID, "")
+	streamRepo.feed <- newEvent(7, pvzID, "")
+
+	assert.Equal(t, []int64{2, 4, 5, 3, 7}, receive(t, stream, 5))
+}
+
+func TestUseCase_SubscribeCity_FiltersByCityName(t *testing.T) {
+	streamRepo := newMockEventStreamRepo()
+	cityRepo := new(MockCityRepo)
+	cityID := uuid.New()
+
+	cityRepo.On("GetByID", mock.Anything, cityID).Return(&entity.CityInfo{ID: cityID, Name: entity.CityKazan}, nil)
+
+	uc := events.NewUseCase(streamRepo, new(MockPVZRepo), cityRepo)
+	stop := startListener(t, uc)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := uc.SubscribeCity(ctx, cityID, 0)
+	require.NoError(t, err)
+
+	streamRepo.feed <- newEvent(1, uuid.New(), entity.CityMoscow)
+	streamRepo.feed <- newEvent(2, uuid.New(), entity.CityKazan)
+
+	assert.Equal(t, []int64{2}, receive(t, stream, 1))
+	streamRepo.AssertNotCalled(t, "EventsSince")
+}
+
+func TestUseCase_DropsSlowSubscriber(t *testing.T) {
+	streamRepo := newMockEventStreamRepo()
+	pvzRepo := new(MockPVZRepo)
+	pvzID := uuid.New()
+	pvzRepo.On("GetByID", mock.Anything, pvzID).Return(&entity.PVZ{ID: &pvzID}, nil)
+
+	uc := events.NewUseCase(streamRepo, pvzRepo, new(MockCityRepo))
+	stop := startListener(t, uc)
+	defer stop()
+
+	stream, err := uc.SubscribePVZ(context.Background(), pvzID, 0)
+	require.NoError(t, err)
+
+	const published = 500
+	for seq := int64(1); seq <= published; seq++ {
+		streamRepo.feed <- newEvent(seq, pvzID, "")
+	}
+
+	assert.Less(t, waitClosed(t, stream), published)
+}
+
+func TestUseCase_ListenerStopClosesStreams(t *testing.T) {
+	pvzRepo := new(MockPVZRepo)
+	pvzID := uuid.New()
+	pvzRepo.On("GetByID", mock.Anything, pvzID).Return(&entity.PVZ{ID: &pvzID}, nil)
+
+	uc := events.NewUseCase(newMockEventStreamRepo(), pvzRepo, new(MockCityRepo))
+	stop := startListener(t, uc)
+
+	stream, err := uc.SubscribePVZ(context.Background(), pvzID, 0)
+	require.NoError(t, err)
+
+	stop()
+
+	assert.Zero(t, waitClosed(t, stream))
+}
@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"time"
+)
+
+const maxKeyLength = 255
+
+type UseCase struct {
+	repo        repo.IdempotencyRepo
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+func NewUseCase(repo repo.IdempotencyRepo, ttl, lockTimeout time.Duration) *UseCase {
+	return &UseCase{
+		repo:        repo,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// Begin claims key for a new request and returns nil, or returns the stored
+// response of a completed request with the same key and body.
+func (uc *UseCase) Begin(ctx context.Context, scope, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	if key == "" || len(key) > maxKeyLength {
+		return nil, entity.ErrInvalidIdempotencyKey
+	}
+
+	record, err := uc.repo.Acquire(ctx, scope, key, requestHash, uc.ttl, uc.lockTimeout)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, entity.ErrIdempotencyKeyReused
+	}
+	if !record.Completed() {
+		return nil, entity.ErrIdempotencyKeyInProgress
+	}
+
+	return record, nil
+}
+
+func (uc *UseCase) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	return uc.repo.Complete(ctx, scope, key, statusCode, contentType, body)
+}
+
+// Release frees a key whose request failed so that a retry is processed anew.
+func (uc *UseCase) Release(ctx context.Context, scope, key string) error {
+	return uc.repo.Release(ctx, scope, key)
+}
+
+func (uc *UseCase) Purge(ctx context.Context) (int64, error) {
+	return uc.repo.DeleteExpired(ctx)
+}
@@ -0,0 +1,132 @@
+package idempotency_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/idempotency"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepo struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepo) Acquire(
+	ctx context.Context,
+	scope, key, requestHash string,
+	ttl, lockTimeout time.Duration,
+) (*entity.IdempotencyRecord, error) {
+	args := m.Called(ctx, scope, key, requestHash, ttl, lockTimeout)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepo) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	args := m.Called(ctx, scope, key, statusCode, contentType, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestUseCase_Begin(t *testing.T) {
+	ctx := context.Background()
+	ttl, lockTimeout := 24*time.Hour, time.Minute
+	completed := &entity.IdempotencyRecord{
+		RequestHash: "hash",
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"1"}`),
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		mockSetup     func(*MockIdempotencyRepo)
+		expected      *entity.IdempotencyRecord
+		expectedError error
+	}{
+		{
+			name: "first request acquires key",
+			key:  "key",
+			mockSetup: func(repo *MockIdempotencyRepo) {
+				repo.On("Acquire", ctx, "scope", "key", "hash", ttl, lockTimeout).Return(nil, nil)
+			},
+		},
+		{
+			name: "retry replays stored response",
+			key:  "key",
+			mockSetup: func(repo *MockIdempotencyRepo) {
+				repo.On("Acquire", ctx, "scope", "key", "hash", ttl, lockTimeout).Return(completed, nil)
+			},
+			expected: completed,
+		},
+		{
+			name: "different body",
+			key:  "key",
+			mockSetup: func(repo *MockIdempotencyRepo) {
+				repo.On("Acquire", ctx, "scope", "key", "hash", ttl, lockTimeout).
+					Return(&entity.IdempotencyRecord{RequestHash: "other", StatusCode: 201}, nil)
+			},
+			expectedError: entity.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "original request still running",
+			key:  "key",
+			mockSetup: func(repo *MockIdempotencyRepo) {
+				repo.On("Acquire", ctx, "scope", "key", "hash", ttl, lockTimeout).
+					Return(&entity.IdempotencyRecord{RequestHash: "hash"}, nil)
+			},
+			expectedError: entity.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:          "key too long",
+			key:           strings.Repeat("k", 256),
+			expectedError: entity.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "repository error",
+			key:  "key",
+			mockSetup: func(repo *MockIdempotencyRepo) {
+				repo.On("Acquire", ctx, "scope", "key", "hash", ttl, lockTimeout).Return(nil, errors.New("db down"))
+			},
+			expectedError: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockIdempotencyRepo)
+			if tt.mockSetup != nil {
+				tt.mockSetup(repo)
+			}
+
+			uc := idempotency.NewUseCase(repo, ttl, lockTimeout)
+			record, err := uc.Begin(ctx, "scope", tt.key, "hash")
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, record)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, record)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"PVZ-avito-tech/internal/infrastructure/publisher"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+)
+
+const _defaultBatchSize = 100
+
+type UseCase struct {
+	repo      repo.OutboxRepo
+	publisher publisher.Publisher
+	batchSize int
+}
+
+func NewUseCase(repo repo.OutboxRepo, publisher publisher.Publisher, batchSize int) *UseCase {
+	if batchSize <= 0 {
+		batchSize = _defaultBatchSize
+	}
+	return &UseCase{
+		repo:      repo,
+		publisher: publisher,
+		batchSize: batchSize,
+	}
+}
+
+// Relay publishes pending outbox events in batches until the backlog is drained
+// and returns the number of published events.
+func (uc *UseCase) Relay(ctx context.Context) (int, error) {
+	total := 0
+	for ctx.Err() == nil {
+		published, err := uc.repo.Relay(ctx, uc.batchSize, uc.publisher.Publish)
+		total += published
+		if err != nil {
+			return total, err
+		}
+		if published < uc.batchSize {
+			break
+		}
+	}
+	return total, nil
+}
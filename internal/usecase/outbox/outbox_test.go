@@ -0,0 +1,108 @@
+package outbox_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/outbox"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type MockOutboxRepo struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepo) Relay(
+	ctx context.Context,
+	limit int,
+	publish func(ctx context.Context, events []entity.Event) error,
+) (int, error) {
+	args := m.Called(ctx, limit)
+	events := args.Get(0).([]entity.Event)
+	if len(events) > 0 {
+		if err := publish(ctx, events); err != nil {
+			return 0, err
+		}
+	}
+	return len(events), args.Error(1)
+}
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, events []entity.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func newEvents(n int) []entity.Event {
+	events := make([]entity.Event, n)
+	for i := range events {
+		events[i] = entity.Event{ID: uuid.New(), Type: entity.EventPVZCreated, Version: 1}
+	}
+	return events
+}
+
+func TestUseCase_Relay(t *testing.T) {
+	ctx := context.Background()
+	full := newEvents(2)
+	partial := newEvents(1)
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockOutboxRepo, *MockPublisher)
+		expected      int
+		expectedError bool
+	}{
+		{
+			name: "nothing pending",
+			mockSetup: func(repo *MockOutboxRepo, pub *MockPublisher) {
+				repo.On("Relay", ctx, 2).Return([]entity.Event{}, nil).Once()
+			},
+		},
+		{
+			name: "drains backlog batch by batch",
+			mockSetup: func(repo *MockOutboxRepo, pub *MockPublisher) {
+				repo.On("Relay", ctx, 2).Return(full, nil).Once()
+				repo.On("Relay", ctx, 2).Return(partial, nil).Once()
+				pub.On("Publish", ctx, full).Return(nil).Once()
+				pub.On("Publish", ctx, partial).Return(nil).Once()
+			},
+			expected: 3,
+		},
+		{
+			name: "publisher failure stops relay",
+			mockSetup: func(repo *MockOutboxRepo, pub *MockPublisher) {
+				repo.On("Relay", ctx, 2).Return(full, nil).Once()
+				pub.On("Publish", ctx, full).Return(errors.New("receiver down")).Once()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockOutboxRepo)
+			pub := new(MockPublisher)
+			uc := outbox.NewUseCase(repo, pub, 2)
+
+			tt.mockSetup(repo, pub)
+
+			published, err := uc.Relay(ctx)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, published)
+
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+		})
+	}
+}
@@ -5,21 +5,209 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/infrastructure/repo"
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/google/uuid"
+	"math/big"
+	"strings"
 )
 
+const pickupCodeDigits = 6
+
 type Usecase struct {
-	repo repo.ProductRepo
+	repo                  repo.ProductRepo
+	pickupCodeSecret      []byte
+	maxPickupCodeAttempts int
 }
 
-func NewProductUsecase(repo repo.ProductRepo) *Usecase {
-	return &Usecase{repo: repo}
+func NewProductUsecase(repo repo.ProductRepo, pickupCodeSecret []byte, maxPickupCodeAttempts int) *Usecase {
+	return &Usecase{
+		repo:                  repo,
+		pickupCodeSecret:      pickupCodeSecret,
+		maxPickupCodeAttempts: maxPickupCodeAttempts,
+	}
 }
 
-func (uc *Usecase) AddProduct(ctx context.Context, product *dto.PostAddProductRequest) (*entity.Product, error) {
-	return uc.repo.AddProduct(ctx, product.PvzID, product.ProductType)
+func (uc *Usecase) AddProduct(
+	ctx context.Context,
+	product *dto.PostAddProductRequest,
+	actor entity.Principal,
+) (*entity.Product, error) {
+	item := dto.AddProductItem{
+		ProductType: product.ProductType,
+		Category:    product.Category,
+		PickupCode:  product.PickupCode,
+		Barcode:     product.Barcode,
+	}
+	if err := validateProduct(item); err != nil {
+		return nil, err
+	}
+
+	newProduct, err := uc.buildProduct(item, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.AddProduct(ctx, product.PvzID, newProduct)
+}
+
+func (uc *Usecase) AddProducts(
+	ctx context.Context,
+	batch *dto.PostAddProductsBatchRequest,
+	actor entity.Principal,
+) ([]entity.ProductBatchResult, error) {
+	results := make([]entity.ProductBatchResult, len(batch.Products))
+	pending := make([]*entity.Product, 0, len(batch.Products))
+	positions := make([]int, 0, len(batch.Products))
+
+	for i, item := range batch.Products {
+		if err := validateProduct(item); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		newProduct, err := uc.buildProduct(item, actor)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, newProduct)
+		positions = append(positions, i)
+	}
+
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	added, err := uc.repo.AddProducts(ctx, batch.PvzID, pending)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range added {
+		results[positions[i]] = result
+	}
+
+	return results, nil
 }
 
 func (uc *Usecase) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
 	return uc.repo.DeleteProductLIFO(ctx, pvzID)
 }
+
+func (uc *Usecase) DeleteProduct(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	productID uuid.UUID,
+	reason string,
+	actor entity.Principal,
+) (*entity.ProductDeletion, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, entity.ErrInvalidDeletionReason
+	}
+
+	return uc.repo.DeleteProduct(ctx, pvzID, productID, reason, actor.Actor())
+}
+
+func (uc *Usecase) GetProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+// IssueProduct checks the pickup code; a moderator may omit it to issue a
+// product whose code is missing or locked after too many invalid attempts.
+func (uc *Usecase) IssueProduct(
+	ctx context.Context,
+	id uuid.UUID,
+	pickupCode string,
+	actor entity.Principal,
+) (*entity.Product, error) {
+	if pickupCode == "" {
+		if actor.Role != entity.UserRoleModerator {
+			return nil, entity.ErrInvalidPickupCode
+		}
+		return uc.repo.IssueProductWithoutCode(ctx, id, actor.Actor())
+	}
+
+	return uc.repo.IssueProduct(
+		ctx,
+		id,
+		HashPickupCode(uc.pickupCodeSecret, pickupCode),
+		uc.maxPickupCodeAttempts,
+		actor.Actor(),
+	)
+}
+
+func (uc *Usecase) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ProductStatusChange, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListStatusHistory(ctx, id)
+}
+
+func (uc *Usecase) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	if !entity.IsValidBarcode(barcode) {
+		return nil, entity.ErrInvalidBarcode
+	}
+
+	return uc.repo.FindByBarcode(ctx, barcode)
+}
+
+func validateProduct(item dto.AddProductItem) error {
+	if !item.ProductType.IsValidProductType() {
+		return entity.ErrInvalidProductType
+	}
+	if item.Category != "" && !entity.IsValidCatalogueName(item.Category) {
+		return entity.ErrInvalidProductCategory
+	}
+	if item.Barcode != "" && !entity.IsValidBarcode(item.Barcode) {
+		return entity.ErrInvalidBarcode
+	}
+	if item.PickupCode != "" && !entity.IsValidPickupCode(item.PickupCode) {
+		return entity.ErrInvalidPickupCode
+	}
+	return nil
+}
+
+func (uc *Usecase) buildProduct(item dto.AddProductItem, actor entity.Principal) (*entity.Product, error) {
+	pickupCode := item.PickupCode
+	if pickupCode == "" {
+		code, err := generatePickupCode()
+		if err != nil {
+			return nil, err
+		}
+		pickupCode = code
+	}
+
+	return &entity.Product{
+		Type:           item.ProductType,
+		Category:       item.Category,
+		Barcode:        item.Barcode,
+		CreatedBy:      actor.Actor(),
+		PickupCode:     pickupCode,
+		PickupCodeHash: HashPickupCode(uc.pickupCodeSecret, pickupCode),
+	}, nil
+}
+
+// HashPickupCode keys the hash with a server secret: pickup codes are short, so
+// a plain hash would be reversed by trying every code.
+func HashPickupCode(secret []byte, code string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generatePickupCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < pickupCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pickup code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", pickupCodeDigits, n), nil
+}
@@ -13,29 +13,99 @@ import (
 	"time"
 )
 
+const maxPickupCodeAttempts = 5
+
+var pickupCodeSecret = []byte("pickup-code-secret")
+
 type MockProductRepo struct {
 	mock.Mock
 }
 
-func (m *MockProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error) {
-	args := m.Called(ctx, pvzID, productType)
+func (m *MockProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, product *entity.Product) (*entity.Product, error) {
+	args := m.Called(ctx, pvzID, product)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Product), args.Error(1)
 }
 
+func (m *MockProductRepo) AddProducts(ctx context.Context, pvzID uuid.UUID, products []*entity.Product) ([]entity.ProductBatchResult, error) {
+	args := m.Called(ctx, pvzID, products)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductBatchResult), args.Error(1)
+}
+
 func (m *MockProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
 	args := m.Called(ctx, pvzID)
 	return args.Error(0)
 }
 
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, deletedBy *uuid.UUID) (*entity.ProductDeletion, error) {
+	args := m.Called(ctx, pvzID, productID, reason, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductDeletion), args.Error(1)
+}
+
+func (m *MockProductRepo) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProduct(ctx context.Context, id uuid.UUID, pickupCodeHash string, maxAttempts int, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, pickupCodeHash, maxAttempts, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductStatusChange), args.Error(1)
+}
+
+func (m *MockProductRepo) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductLocation), args.Error(1)
+}
+
 func TestUsecase_AddProduct(t *testing.T) {
 	ctx := context.Background()
 	pvzID := uuid.New()
 	productType := entity.ElectronicsProductType
 	now := time.Now()
 	receptionID := uuid.New()
+	userID := uuid.New()
+	actor := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
 
 	tests := []struct {
 		name          string
@@ -51,11 +121,17 @@ func TestUsecase_AddProduct(t *testing.T) {
 				ProductType: productType,
 			},
 			mockSetup: func(mockRepo *MockProductRepo) {
-				mockRepo.On("AddProduct", ctx, pvzID, productType).Return(&entity.Product{
+				mockRepo.On("AddProduct", ctx, pvzID, mock.MatchedBy(func(p *entity.Product) bool {
+					return p.Type == productType &&
+						*p.CreatedBy == userID &&
+						len(p.PickupCode) == 6 &&
+						p.PickupCodeHash == product.HashPickupCode(pickupCodeSecret, p.PickupCode)
+				})).Return(&entity.Product{
 					ID:          uuid.New(),
 					DateTime:    now,
 					Type:        productType,
 					ReceptionID: receptionID,
+					CreatedBy:   &userID,
 				}, nil)
 			},
 			expectedResp: &entity.Product{
@@ -71,7 +147,7 @@ func TestUsecase_AddProduct(t *testing.T) {
 				ProductType: productType,
 			},
 			mockSetup: func(mockRepo *MockProductRepo) {
-				mockRepo.On("AddProduct", ctx, pvzID, productType).Return(nil, errors.New("failed to add product"))
+				mockRepo.On("AddProduct", ctx, pvzID, mock.Anything).Return(nil, errors.New("failed to add product"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("failed to add product"),
@@ -83,21 +159,74 @@ func TestUsecase_AddProduct(t *testing.T) {
 				ProductType: "invalid-type",
 			},
 			mockSetup: func(mockRepo *MockProductRepo) {
-				mockRepo.On("AddProduct", ctx, pvzID, entity.ProductType("invalid-type")).Return(nil, errors.New("invalid product type"))
+				mockRepo.On("AddProduct", ctx, pvzID, mock.Anything).Return(nil, entity.ErrInvalidProductType)
+			},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidProductType,
+		},
+		{
+			name: "malformed barcode",
+			request: &dto.PostAddProductRequest{
+				PvzID:       pvzID,
+				ProductType: productType,
+				Barcode:     "bad barcode",
+			},
+			mockSetup:     func(mockRepo *MockProductRepo) {},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidBarcode,
+		},
+		{
+			name: "barcode already accepted",
+			request: &dto.PostAddProductRequest{
+				PvzID:       pvzID,
+				ProductType: productType,
+				Barcode:     "4601234567890",
+			},
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("AddProduct", ctx, pvzID, mock.MatchedBy(func(p *entity.Product) bool {
+					return p.Barcode == "4601234567890"
+				})).Return(nil, entity.ErrBarcodeConflict)
+			},
+			expectedResp:  nil,
+			expectedError: entity.ErrBarcodeConflict,
+		},
+		{
+			name: "malformed product type",
+			request: &dto.PostAddProductRequest{
+				PvzID:       pvzID,
+				ProductType: "",
+			},
+			mockSetup:     func(mockRepo *MockProductRepo) {},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidProductType,
+		},
+		{
+			name: "product with category",
+			request: &dto.PostAddProductRequest{
+				PvzID:       pvzID,
+				ProductType: entity.ShoesProductType,
+				Category:    "кроссовки",
+			},
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("AddProduct", ctx, pvzID, mock.MatchedBy(func(p *entity.Product) bool {
+					return p.Type == entity.ShoesProductType &&
+						p.Category == "кроссовки" &&
+						*p.CreatedBy == userID
+				})).Return(nil, entity.ErrProductCategoryInactive)
 			},
 			expectedResp:  nil,
-			expectedError: errors.New("invalid product type"),
+			expectedError: entity.ErrProductCategoryInactive,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockProductRepo)
-			usecase := product.NewProductUsecase(mockRepo)
+			usecase := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts)
 
 			tt.mockSetup(mockRepo)
 
-			resp, err := usecase.AddProduct(ctx, tt.request)
+			resp, err := usecase.AddProduct(ctx, tt.request, actor)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -154,7 +283,7 @@ func TestUsecase_DeleteProductLIFO(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockProductRepo)
-			usecase := product.NewProductUsecase(mockRepo)
+			usecase := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts)
 
 			tt.mockSetup(mockRepo)
 
@@ -171,3 +300,335 @@ func TestUsecase_DeleteProductLIFO(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_IssueProduct(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+	userID := uuid.New()
+	employee := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
+	moderator := entity.Principal{UserID: userID, Role: entity.UserRoleModerator}
+
+	tests := []struct {
+		name          string
+		pickupCode    string
+		actor         entity.Principal
+		mockSetup     func(*MockProductRepo)
+		expectedError error
+	}{
+		{
+			name:       "successful issue",
+			pickupCode: "123456",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("IssueProduct", ctx, productID, product.HashPickupCode(pickupCodeSecret, "123456"), maxPickupCodeAttempts, &userID).Return(&entity.Product{
+					ID:     productID,
+					Status: entity.IssuedProductStatus,
+				}, nil)
+			},
+		},
+		{
+			name:          "empty pickup code",
+			pickupCode:    "",
+			mockSetup:     func(mockRepo *MockProductRepo) {},
+			expectedError: entity.ErrInvalidPickupCode,
+		},
+		{
+			name:       "wrong pickup code",
+			pickupCode: "000000",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("IssueProduct", ctx, productID, product.HashPickupCode(pickupCodeSecret, "000000"), maxPickupCodeAttempts, &userID).Return(nil, entity.ErrInvalidPickupCode)
+			},
+			expectedError: entity.ErrInvalidPickupCode,
+		},
+		{
+			name:       "product already issued",
+			pickupCode: "123456",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("IssueProduct", ctx, productID, product.HashPickupCode(pickupCodeSecret, "123456"), maxPickupCodeAttempts, &userID).Return(nil, entity.ErrInvalidProductStatusTransition)
+			},
+			expectedError: entity.ErrInvalidProductStatusTransition,
+		},
+		{
+			name:       "too many attempts",
+			pickupCode: "123456",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("IssueProduct", ctx, productID, product.HashPickupCode(pickupCodeSecret, "123456"), maxPickupCodeAttempts, &userID).Return(nil, entity.ErrPickupCodeAttemptsExceeded)
+			},
+			expectedError: entity.ErrPickupCodeAttemptsExceeded,
+		},
+		{
+			name:  "moderator issues without code",
+			actor: moderator,
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("IssueProductWithoutCode", ctx, productID, &userID).Return(&entity.Product{
+					ID:     productID,
+					Status: entity.IssuedProductStatus,
+				}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepo)
+			usecase := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts)
+
+			tt.mockSetup(mockRepo)
+
+			actor := tt.actor
+			if actor.Role == "" {
+				actor = employee
+			}
+			resp, err := usecase.IssueProduct(ctx, productID, tt.pickupCode, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.IssuedProductStatus, resp.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_StatusHistory(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+
+	t.Run("unknown product", func(t *testing.T) {
+		mockRepo := new(MockProductRepo)
+		mockRepo.On("GetByID", ctx, productID).Return(nil, entity.ErrProductNotFound)
+
+		_, err := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts).StatusHistory(ctx, productID)
+
+		assert.ErrorIs(t, err, entity.ErrProductNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("history of existing product", func(t *testing.T) {
+		received := entity.ReceivedProductStatus
+		mockRepo := new(MockProductRepo)
+		mockRepo.On("GetByID", ctx, productID).Return(&entity.Product{ID: productID}, nil)
+		mockRepo.On("ListStatusHistory", ctx, productID).Return([]entity.ProductStatusChange{
+			{ProductID: productID, To: entity.ReceivedProductStatus},
+			{ProductID: productID, From: &received, To: entity.StoredProductStatus},
+		}, nil)
+
+		history, err := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts).StatusHistory(ctx, productID)
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUsecase_FindByBarcode(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name          string
+		barcode       string
+		mockSetup     func(*MockProductRepo)
+		expectedError error
+	}{
+		{
+			name:    "found",
+			barcode: "4601234567890",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("FindByBarcode", ctx, "4601234567890").Return(&entity.ProductLocation{
+					Product: entity.Product{Barcode: "4601234567890", PVZID: pvzID},
+					PVZ:     entity.PVZ{ID: &pvzID},
+				}, nil)
+			},
+		},
+		{
+			name:    "not found",
+			barcode: "4601234567890",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("FindByBarcode", ctx, "4601234567890").Return(nil, entity.ErrProductNotFound)
+			},
+			expectedError: entity.ErrProductNotFound,
+		},
+		{
+			name:          "malformed barcode",
+			barcode:       "46012 34567",
+			mockSetup:     func(mockRepo *MockProductRepo) {},
+			expectedError: entity.ErrInvalidBarcode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepo)
+			tt.mockSetup(mockRepo)
+
+			location, err := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts).FindByBarcode(ctx, tt.barcode)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, location)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, pvzID, *location.PVZ.ID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_AddProducts(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	userID := uuid.New()
+	actor := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name          string
+		items         []dto.AddProductItem
+		mockSetup     func(*MockProductRepo)
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name: "valid items are added, invalid ones rejected in place",
+			items: []dto.AddProductItem{
+				{ProductType: entity.ElectronicsProductType, Barcode: "4601234567890"},
+				{ProductType: ""},
+				{ProductType: entity.ShoesProductType, Barcode: "bad barcode"},
+				{ProductType: entity.ClothesProductType},
+				{ProductType: entity.ClothesProductType, PickupCode: "12"},
+			},
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("AddProducts", ctx, pvzID, mock.MatchedBy(func(products []*entity.Product) bool {
+					return len(products) == 2 &&
+						products[0].Barcode == "4601234567890" &&
+						products[1].Type == entity.ClothesProductType &&
+						*products[0].CreatedBy == userID &&
+						products[1].PickupCodeHash == product.HashPickupCode(pickupCodeSecret, products[1].PickupCode)
+				})).Return([]entity.ProductBatchResult{
+					{Product: &entity.Product{Type: entity.ElectronicsProductType}},
+					{Err: entity.ErrProductTypeInactive},
+				}, nil)
+			},
+			expectedErrs: []error{
+				nil,
+				entity.ErrInvalidProductType,
+				entity.ErrInvalidBarcode,
+				entity.ErrProductTypeInactive,
+				entity.ErrInvalidPickupCode,
+			},
+		},
+		{
+			name: "all items invalid",
+			items: []dto.AddProductItem{
+				{ProductType: ""},
+			},
+			mockSetup:    func(mockRepo *MockProductRepo) {},
+			expectedErrs: []error{entity.ErrInvalidProductType},
+		},
+		{
+			name: "no active reception",
+			items: []dto.AddProductItem{
+				{ProductType: entity.ElectronicsProductType},
+			},
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("AddProducts", ctx, pvzID, mock.Anything).Return(nil, entity.ErrNoActiveReception)
+			},
+			expectedError: entity.ErrNoActiveReception,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepo)
+			tt.mockSetup(mockRepo)
+
+			results, err := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts).AddProducts(ctx, &dto.PostAddProductsBatchRequest{
+				PvzID:    pvzID,
+				Products: tt.items,
+			}, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, results)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, results, len(tt.expectedErrs))
+				for i, expected := range tt.expectedErrs {
+					if expected == nil {
+						assert.NoError(t, results[i].Err)
+						assert.NotNil(t, results[i].Product)
+					} else {
+						assert.ErrorIs(t, results[i].Err, expected)
+					}
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsecase_DeleteProduct(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	actor := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name          string
+		reason        string
+		mockSetup     func(*MockProductRepo)
+		expectedError error
+	}{
+		{
+			name:   "successful soft delete",
+			reason: "  mis-scan ",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("DeleteProduct", ctx, pvzID, productID, "mis-scan", &userID).Return(&entity.ProductDeletion{
+					ProductID: productID,
+					Reason:    "mis-scan",
+					DeletedBy: &userID,
+				}, nil)
+			},
+		},
+		{
+			name:          "blank reason",
+			reason:        "   ",
+			mockSetup:     func(mockRepo *MockProductRepo) {},
+			expectedError: entity.ErrInvalidDeletionReason,
+		},
+		{
+			name:   "reception already closed",
+			reason: "mis-scan",
+			mockSetup: func(mockRepo *MockProductRepo) {
+				mockRepo.On("DeleteProduct", ctx, pvzID, productID, "mis-scan", &userID).Return(nil, entity.ErrReceptionNotInProgress)
+			},
+			expectedError: entity.ErrReceptionNotInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepo)
+			tt.mockSetup(mockRepo)
+
+			deletion, err := product.NewProductUsecase(mockRepo, pickupCodeSecret, maxPickupCodeAttempts).DeleteProduct(ctx, pvzID, productID, tt.reason, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, deletion)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, productID, deletion.ProductID)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"PVZ-avito-tech/internal/infrastructure/repo"
 	"PVZ-avito-tech/internal/pkg/logger"
 	"context"
+	"errors"
+	"github.com/google/uuid"
 )
 
 type UseCase struct {
 	pvzRepo       repo.PVZRepo
 	receptionRepo repo.ReceptionRepo
 	productRepo   repo.ProductRepo
+	cityRepo      repo.CityRepo
+	txManager     repo.TxManager
 	log           logger.Interface
 }
 
@@ -19,25 +23,81 @@ func NewPVZUseCase(
 	pvzRepo repo.PVZRepo,
 	receptionRepo repo.ReceptionRepo,
 	productRepo repo.ProductRepo,
+	cityRepo repo.CityRepo,
+	txManager repo.TxManager,
 	log logger.Interface,
 ) *UseCase {
 	return &UseCase{
 		pvzRepo:       pvzRepo,
 		receptionRepo: receptionRepo,
 		productRepo:   productRepo,
+		cityRepo:      cityRepo,
+		txManager:     txManager,
 		log:           log,
 	}
 }
 
 func (uc *UseCase) CreatePVZ(ctx context.Context, pvz *entity.PVZ) (*entity.PVZ, error) {
-	err := uc.pvzRepo.Create(ctx, pvz)
+	if !pvz.City.IsValidCity() {
+		return nil, entity.ErrInvalidCity
+	}
+
+	exists, err := uc.cityRepo.Exists(ctx, pvz.City)
 	if err != nil {
 		return nil, err
 	}
+	if !exists {
+		return nil, entity.ErrInvalidCity
+	}
+
+	// A city deleted after the cached check fails the foreign key, which the
+	// repo reports as ErrInvalidCity.
+	if err := uc.pvzRepo.Create(ctx, pvz); err != nil {
+		return nil, err
+	}
 
-	return pvz, err
+	return pvz, nil
 }
 
 func (uc *UseCase) GetPVZWithReceptions(ctx context.Context, filter dto.ReceptionFilter) (*[]dto.PVZInfo, error) {
 	return uc.pvzRepo.GetPVZWithReceptions(ctx, filter)
 }
+
+func (uc *UseCase) GetPVZPage(ctx context.Context, filter dto.ReceptionFilter) (*dto.PVZPage, error) {
+	items, err := uc.pvzRepo.GetPVZWithReceptions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewPVZPage(*items, filter), nil
+}
+
+func (uc *UseCase) GetPVZ(ctx context.Context, id uuid.UUID) (*dto.PVZDetails, error) {
+	var (
+		pvz    *entity.PVZ
+		active *entity.Reception
+	)
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pvz, err = uc.pvzRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		active, err = uc.receptionRepo.GetActiveByPVZ(ctx, id)
+		if err != nil && !errors.Is(err, entity.ErrNoActiveReception) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PVZDetails{
+		ID:               *pvz.ID,
+		RegistrationDate: *pvz.RegistrationDate,
+		City:             pvz.City,
+		ActiveReception:  active,
+	}, nil
+}
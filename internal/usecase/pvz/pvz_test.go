@@ -31,19 +31,43 @@ func (m *MockPVZRepo) GetPVZWithReceptions(ctx context.Context, filter dto.Recep
 	return args.Get(0).(*[]dto.PVZInfo), args.Error(1)
 }
 
+func (m *MockPVZRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.PVZ, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PVZ), args.Error(1)
+}
+
 type MockReceptionRepo struct {
 	mock.Mock
 }
 
-func (m *MockReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
-	args := m.Called(ctx, pvzID)
+func (m *MockReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID, manifest []entity.ManifestItem) (*entity.Reception, error) {
+	args := m.Called(ctx, pvzID, createdBy, manifest)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Reception), args.Error(1)
 }
 
-func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID, closedBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, pvzID, closedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) GetActiveByPVZ(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
 	args := m.Called(ctx, pvzID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -51,23 +75,187 @@ func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid
 	return args.Get(0).(*entity.Reception), args.Error(1)
 }
 
+func (m *MockReceptionRepo) ReopenReception(ctx context.Context, id uuid.UUID, window time.Duration, reopenedBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id, window, reopenedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) CancelReception(ctx context.Context, id uuid.UUID, reason string, cancelledBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id, reason, cancelledBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) ListStatusHistory(ctx context.Context, receptionID uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReceptionStatusChange), args.Error(1)
+}
+
+func (m *MockReceptionRepo) CloseStaleReceptions(ctx context.Context, idleFor time.Duration, limit int, reason string) ([]entity.Reception, error) {
+	args := m.Called(ctx, idleFor, limit, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OpenReceptionStats), args.Error(1)
+}
+
+func (m *MockReceptionRepo) GetDiscrepancyReport(ctx context.Context, receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscrepancyReport), args.Error(1)
+}
+
 type MockProductRepo struct {
 	mock.Mock
 }
 
-func (m *MockProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error) {
-	args := m.Called(ctx, pvzID, productType)
+func (m *MockProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, product *entity.Product) (*entity.Product, error) {
+	args := m.Called(ctx, pvzID, product)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Product), args.Error(1)
 }
 
+func (m *MockProductRepo) AddProducts(ctx context.Context, pvzID uuid.UUID, products []*entity.Product) ([]entity.ProductBatchResult, error) {
+	args := m.Called(ctx, pvzID, products)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductBatchResult), args.Error(1)
+}
+
 func (m *MockProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
 	args := m.Called(ctx, pvzID)
 	return args.Error(0)
 }
 
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, deletedBy *uuid.UUID) (*entity.ProductDeletion, error) {
+	args := m.Called(ctx, pvzID, productID, reason, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductDeletion), args.Error(1)
+}
+
+func (m *MockProductRepo) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProduct(ctx context.Context, id uuid.UUID, pickupCodeHash string, maxAttempts int, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, pickupCodeHash, maxAttempts, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductStatusChange), args.Error(1)
+}
+
+func (m *MockProductRepo) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductLocation), args.Error(1)
+}
+
+type MockCityRepo struct {
+	mock.Mock
+}
+
+func (m *MockCityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	args := m.Called(ctx, city)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockTxManager struct {
+	calls int
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
 func TestUseCase_CreatePVZ(t *testing.T) {
 	ctx := context.Background()
 	id := uuid.New()
@@ -76,7 +264,7 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 	tests := []struct {
 		name          string
 		pvz           *entity.PVZ
-		mockSetup     func(*MockPVZRepo, *MockReceptionRepo, *MockProductRepo)
+		mockSetup     func(*MockPVZRepo, *MockCityRepo)
 		expectedResp  *entity.PVZ
 		expectedError error
 	}{
@@ -87,7 +275,8 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 				City:             entity.CityMoscow,
 				RegistrationDate: &now,
 			},
-			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo, mockProductRepo *MockProductRepo) {
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {
+				mockCityRepo.On("Exists", ctx, entity.CityMoscow).Return(true, nil)
 				mockPVZRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.PVZ) bool {
 					return p.ID == &id && p.City == entity.CityMoscow && p.RegistrationDate == &now
 				})).Return(nil)
@@ -106,7 +295,8 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 				City:             entity.CityMoscow,
 				RegistrationDate: &now,
 			},
-			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo, mockProductRepo *MockProductRepo) {
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {
+				mockCityRepo.On("Exists", ctx, entity.CityMoscow).Return(true, nil)
 				mockPVZRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.PVZ) bool {
 					return p.ID == &id && p.City == entity.CityMoscow && p.RegistrationDate == &now
 				})).Return(errors.New("failed to create pvz"))
@@ -121,13 +311,49 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 				City:             "Invalid City",
 				RegistrationDate: &now,
 			},
-			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo, mockProductRepo *MockProductRepo) {
-				mockPVZRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.PVZ) bool {
-					return p.ID == &id && p.City == "Invalid City" && p.RegistrationDate == &now
-				})).Return(errors.New("invalid city"))
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {
+				mockCityRepo.On("Exists", ctx, entity.City("Invalid City")).Return(false, nil)
+			},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidCity,
+		},
+		{
+			name: "city deleted after the cached check",
+			pvz: &entity.PVZ{
+				ID:               &id,
+				City:             entity.CityMoscow,
+				RegistrationDate: &now,
+			},
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {
+				mockCityRepo.On("Exists", ctx, entity.CityMoscow).Return(true, nil)
+				mockPVZRepo.On("Create", ctx, mock.Anything).Return(entity.ErrInvalidCity)
+			},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidCity,
+		},
+		{
+			name: "malformed city name",
+			pvz: &entity.PVZ{
+				ID:               &id,
+				City:             " Москва",
+				RegistrationDate: &now,
+			},
+			mockSetup:     func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {},
+			expectedResp:  nil,
+			expectedError: entity.ErrInvalidCity,
+		},
+		{
+			name: "city dictionary error",
+			pvz: &entity.PVZ{
+				ID:               &id,
+				City:             entity.CityKazan,
+				RegistrationDate: &now,
+			},
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockCityRepo *MockCityRepo) {
+				mockCityRepo.On("Exists", ctx, entity.CityKazan).Return(false, errors.New("db error"))
 			},
 			expectedResp:  nil,
-			expectedError: errors.New("invalid city"),
+			expectedError: errors.New("db error"),
 		},
 	}
 
@@ -136,11 +362,12 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 			mockPVZRepo := new(MockPVZRepo)
 			mockReceptionRepo := new(MockReceptionRepo)
 			mockProductRepo := new(MockProductRepo)
+			mockCityRepo := new(MockCityRepo)
 			loggerMock := logger.NewMock()
 
-			usecase := pvz.NewPVZUseCase(mockPVZRepo, mockReceptionRepo, mockProductRepo, loggerMock)
+			usecase := pvz.NewPVZUseCase(mockPVZRepo, mockReceptionRepo, mockProductRepo, mockCityRepo, new(MockTxManager), loggerMock)
 
-			tt.mockSetup(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+			tt.mockSetup(mockPVZRepo, mockCityRepo)
 
 			resp, err := usecase.CreatePVZ(ctx, tt.pvz)
 
@@ -159,6 +386,7 @@ func TestUseCase_CreatePVZ(t *testing.T) {
 			mockPVZRepo.AssertExpectations(t)
 			mockReceptionRepo.AssertExpectations(t)
 			mockProductRepo.AssertExpectations(t)
+			mockCityRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -270,7 +498,7 @@ func TestUseCase_GetPVZWithReceptions(t *testing.T) {
 			mockProductRepo := new(MockProductRepo)
 			loggerMock := logger.NewMock()
 
-			usecase := pvz.NewPVZUseCase(mockPVZRepo, mockReceptionRepo, mockProductRepo, loggerMock)
+			usecase := pvz.NewPVZUseCase(mockPVZRepo, mockReceptionRepo, mockProductRepo, new(MockCityRepo), new(MockTxManager), loggerMock)
 
 			tt.mockSetup(mockPVZRepo, mockReceptionRepo, mockProductRepo)
 
@@ -291,3 +519,83 @@ func TestUseCase_GetPVZWithReceptions(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_GetPVZ(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*MockPVZRepo, *MockReceptionRepo)
+		expectedActive bool
+		expectedError  error
+	}{
+		{
+			name: "pvz with open reception",
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo) {
+				mockPVZRepo.On("GetByID", ctx, pvzID).Return(&entity.PVZ{ID: &pvzID, City: entity.CityMoscow, RegistrationDate: &now}, nil)
+				mockReceptionRepo.On("GetActiveByPVZ", ctx, pvzID).Return(&entity.Reception{
+					ID:       receptionID,
+					PVZID:    pvzID,
+					DateTime: now,
+					Status:   entity.InProgressStatus,
+				}, nil)
+			},
+			expectedActive: true,
+		},
+		{
+			name: "pvz without open reception",
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo) {
+				mockPVZRepo.On("GetByID", ctx, pvzID).Return(&entity.PVZ{ID: &pvzID, City: entity.CityMoscow, RegistrationDate: &now}, nil)
+				mockReceptionRepo.On("GetActiveByPVZ", ctx, pvzID).Return(nil, entity.ErrNoActiveReception)
+			},
+		},
+		{
+			name: "pvz not found",
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo) {
+				mockPVZRepo.On("GetByID", ctx, pvzID).Return(nil, entity.ErrPVZNotFound)
+			},
+			expectedError: entity.ErrPVZNotFound,
+		},
+		{
+			name: "reception lookup error",
+			mockSetup: func(mockPVZRepo *MockPVZRepo, mockReceptionRepo *MockReceptionRepo) {
+				mockPVZRepo.On("GetByID", ctx, pvzID).Return(&entity.PVZ{ID: &pvzID, City: entity.CityMoscow, RegistrationDate: &now}, nil)
+				mockReceptionRepo.On("GetActiveByPVZ", ctx, pvzID).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPVZRepo := new(MockPVZRepo)
+			mockReceptionRepo := new(MockReceptionRepo)
+			usecase := pvz.NewPVZUseCase(mockPVZRepo, mockReceptionRepo, new(MockProductRepo), new(MockCityRepo), new(MockTxManager), logger.NewMock())
+
+			tt.mockSetup(mockPVZRepo, mockReceptionRepo)
+
+			resp, err := usecase.GetPVZ(ctx, pvzID)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, pvzID, resp.ID)
+				assert.Equal(t, entity.CityMoscow, resp.City)
+				if tt.expectedActive {
+					assert.Equal(t, receptionID, resp.ActiveReception.ID)
+				} else {
+					assert.Nil(t, resp.ActiveReception)
+				}
+			}
+
+			mockPVZRepo.AssertExpectations(t)
+			mockReceptionRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -5,25 +5,158 @@ import (
 	"PVZ-avito-tech/internal/entity"
 	"PVZ-avito-tech/internal/infrastructure/repo"
 	"context"
+	"fmt"
 	"github.com/google/uuid"
+	"strings"
+	"time"
 )
 
+const staleSweepBatchSize = 100
+
 type UseCase struct {
 	receptionRepo repo.ReceptionRepo
+	productRepo   repo.ProductRepo
+	txManager     repo.TxManager
+	reopenWindow  time.Duration
 }
 
 func NewUseCase(
 	receptionRepo repo.ReceptionRepo,
+	productRepo repo.ProductRepo,
+	txManager repo.TxManager,
+	reopenWindow time.Duration,
 ) *UseCase {
 	return &UseCase{
 		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
+		txManager:     txManager,
+		reopenWindow:  reopenWindow,
+	}
+}
+
+func (uc *UseCase) CreateReception(
+	ctx context.Context,
+	request dto.ReceptionsRequest,
+	actor entity.Principal,
+) (*entity.Reception, error) {
+	if err := entity.ValidateManifest(request.Manifest); err != nil {
+		return nil, err
+	}
+
+	var reception *entity.Reception
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		reception, err = uc.receptionRepo.CreateReception(ctx, request.PvzId, actor.Actor(), request.Manifest)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reception, nil
+}
+
+func (uc *UseCase) CloseReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	var reception *entity.Reception
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		reception, err = uc.receptionRepo.CloseActiveReception(ctx, id, actor.Actor())
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return reception, nil
 }
 
-func (uc *UseCase) CreateReception(ctx context.Context, request dto.ReceptionsRequest) (*entity.Reception, error) {
-	return uc.receptionRepo.CreateReception(ctx, request.PvzId)
+func (uc *UseCase) GetReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	return uc.receptionRepo.GetByID(ctx, id)
 }
 
-func (uc *UseCase) CloseReception(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
-	return uc.receptionRepo.CloseActiveReception(ctx, id)
+func (uc *UseCase) ReopenReception(ctx context.Context, id uuid.UUID, actor entity.Principal) (*entity.Reception, error) {
+	var reception *entity.Reception
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		reception, err = uc.receptionRepo.ReopenReception(ctx, id, uc.reopenWindow, actor.Actor())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reception, nil
+}
+
+func (uc *UseCase) CancelReception(
+	ctx context.Context,
+	id uuid.UUID,
+	reason string,
+	actor entity.Principal,
+) (*entity.Reception, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, entity.ErrInvalidCancellationReason
+	}
+
+	var reception *entity.Reception
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		reception, err = uc.receptionRepo.CancelReception(ctx, id, reason, actor.Actor())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reception, nil
+}
+
+func (uc *UseCase) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	var history []entity.ReceptionStatusChange
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.receptionRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		history, err = uc.receptionRepo.ListStatusHistory(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (uc *UseCase) CloseStaleReceptions(ctx context.Context, idleFor time.Duration) ([]entity.Reception, error) {
+	reason := fmt.Sprintf("closed automatically after %s without activity", idleFor)
+	return uc.receptionRepo.CloseStaleReceptions(ctx, idleFor, staleSweepBatchSize, reason)
+}
+
+func (uc *UseCase) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	return uc.receptionRepo.OpenStats(ctx)
+}
+
+func (uc *UseCase) DiscrepancyReport(ctx context.Context, id uuid.UUID) (*entity.DiscrepancyReport, error) {
+	return uc.receptionRepo.GetDiscrepancyReport(ctx, id)
+}
+
+func (uc *UseCase) ListProducts(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	var products []entity.Product
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := uc.receptionRepo.GetByID(ctx, receptionID); err != nil {
+			return err
+		}
+
+		var err error
+		products, err = uc.productRepo.ListByReception(ctx, receptionID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
 }
@@ -17,15 +17,31 @@ type MockReceptionRepo struct {
 	mock.Mock
 }
 
-func (m *MockReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
-	args := m.Called(ctx, pvzID)
+func (m *MockReceptionRepo) CreateReception(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID, manifest []entity.ManifestItem) (*entity.Reception, error) {
+	args := m.Called(ctx, pvzID, createdBy, manifest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID, closedBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, pvzID, closedBy)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Reception), args.Error(1)
 }
 
-func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+func (m *MockReceptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) GetActiveByPVZ(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
 	args := m.Called(ctx, pvzID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -33,6 +49,144 @@ func (m *MockReceptionRepo) CloseActiveReception(ctx context.Context, pvzID uuid
 	return args.Get(0).(*entity.Reception), args.Error(1)
 }
 
+func (m *MockReceptionRepo) ReopenReception(ctx context.Context, id uuid.UUID, window time.Duration, reopenedBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id, window, reopenedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) CancelReception(ctx context.Context, id uuid.UUID, reason string, cancelledBy *uuid.UUID) (*entity.Reception, error) {
+	args := m.Called(ctx, id, reason, cancelledBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) ListStatusHistory(ctx context.Context, receptionID uuid.UUID) ([]entity.ReceptionStatusChange, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReceptionStatusChange), args.Error(1)
+}
+
+func (m *MockReceptionRepo) CloseStaleReceptions(ctx context.Context, idleFor time.Duration, limit int, reason string) ([]entity.Reception, error) {
+	args := m.Called(ctx, idleFor, limit, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Reception), args.Error(1)
+}
+
+func (m *MockReceptionRepo) OpenStats(ctx context.Context) (*entity.OpenReceptionStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.OpenReceptionStats), args.Error(1)
+}
+
+func (m *MockReceptionRepo) GetDiscrepancyReport(ctx context.Context, receptionID uuid.UUID) (*entity.DiscrepancyReport, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscrepancyReport), args.Error(1)
+}
+
+type MockProductRepo struct {
+	mock.Mock
+}
+
+func (m *MockProductRepo) AddProduct(ctx context.Context, pvzID uuid.UUID, product *entity.Product) (*entity.Product, error) {
+	args := m.Called(ctx, pvzID, product)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) AddProducts(ctx context.Context, pvzID uuid.UUID, products []*entity.Product) ([]entity.ProductBatchResult, error) {
+	args := m.Called(ctx, pvzID, products)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductBatchResult), args.Error(1)
+}
+
+func (m *MockProductRepo) DeleteProductLIFO(ctx context.Context, pvzID uuid.UUID) error {
+	args := m.Called(ctx, pvzID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepo) DeleteProduct(ctx context.Context, pvzID, productID uuid.UUID, reason string, deletedBy *uuid.UUID) (*entity.ProductDeletion, error) {
+	args := m.Called(ctx, pvzID, productID, reason, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductDeletion), args.Error(1)
+}
+
+func (m *MockProductRepo) ListByReception(ctx context.Context, receptionID uuid.UUID) ([]entity.Product, error) {
+	args := m.Called(ctx, receptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProduct(ctx context.Context, id uuid.UUID, pickupCodeHash string, maxAttempts int, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, pickupCodeHash, maxAttempts, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) IssueProductWithoutCode(ctx context.Context, id uuid.UUID, issuedBy *uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, id, issuedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *MockProductRepo) ListStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ProductStatusChange), args.Error(1)
+}
+
+func (m *MockProductRepo) FindByBarcode(ctx context.Context, barcode string) (*entity.ProductLocation, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProductLocation), args.Error(1)
+}
+
+type MockTxManager struct {
+	calls int
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
 func TestUseCase_CreateReception(t *testing.T) {
 	ctx := context.Background()
 	pvzID := uuid.New()
@@ -52,7 +206,7 @@ func TestUseCase_CreateReception(t *testing.T) {
 				PvzId: pvzID,
 			},
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CreateReception", ctx, pvzID).Return(&entity.Reception{
+				mockRepo.On("CreateReception", ctx, pvzID, (*uuid.UUID)(nil), ([]entity.ManifestItem)(nil)).Return(&entity.Reception{
 					ID:       receptionID,
 					DateTime: now,
 					PVZID:    pvzID,
@@ -73,18 +227,52 @@ func TestUseCase_CreateReception(t *testing.T) {
 				PvzId: pvzID,
 			},
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CreateReception", ctx, pvzID).Return(nil, errors.New("failed to create reception"))
+				mockRepo.On("CreateReception", ctx, pvzID, (*uuid.UUID)(nil), ([]entity.ManifestItem)(nil)).Return(nil, errors.New("failed to create reception"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("failed to create reception"),
 		},
+		{
+			name: "reception with expected manifest",
+			request: dto.ReceptionsRequest{
+				PvzId: pvzID,
+				Manifest: []entity.ManifestItem{
+					{Barcode: "4601234567890", Type: entity.ShoesProductType, Quantity: 1},
+					{Type: entity.ClothesProductType, Quantity: 3},
+				},
+			},
+			mockSetup: func(mockRepo *MockReceptionRepo) {
+				mockRepo.On("CreateReception", ctx, pvzID, (*uuid.UUID)(nil), []entity.ManifestItem{
+					{Barcode: "4601234567890", Type: entity.ShoesProductType, Quantity: 1},
+					{Type: entity.ClothesProductType, Quantity: 3},
+				}).Return(&entity.Reception{
+					ID:     receptionID,
+					PVZID:  pvzID,
+					Status: entity.InProgressStatus,
+				}, nil)
+			},
+			expectedResp: &entity.Reception{
+				ID:     receptionID,
+				PVZID:  pvzID,
+				Status: entity.InProgressStatus,
+			},
+		},
+		{
+			name: "invalid manifest",
+			request: dto.ReceptionsRequest{
+				PvzId:    pvzID,
+				Manifest: []entity.ManifestItem{{Type: entity.ClothesProductType, Quantity: 0}},
+			},
+			mockSetup:     func(mockRepo *MockReceptionRepo) {},
+			expectedError: entity.ErrInvalidManifest,
+		},
 		{
 			name: "pvz not found",
 			request: dto.ReceptionsRequest{
 				PvzId: pvzID,
 			},
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CreateReception", ctx, pvzID).Return(nil, errors.New("pvz not found"))
+				mockRepo.On("CreateReception", ctx, pvzID, (*uuid.UUID)(nil), ([]entity.ManifestItem)(nil)).Return(nil, errors.New("pvz not found"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("pvz not found"),
@@ -94,11 +282,11 @@ func TestUseCase_CreateReception(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockReceptionRepo)
-			usecase := reception.NewUseCase(mockRepo)
+			usecase := reception.NewUseCase(mockRepo, new(MockProductRepo), new(MockTxManager), time.Hour)
 
 			tt.mockSetup(mockRepo)
 
-			resp, err := usecase.CreateReception(ctx, tt.request)
+			resp, err := usecase.CreateReception(ctx, tt.request, entity.Principal{Role: entity.UserRoleEmployee})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -121,6 +309,8 @@ func TestUseCase_CloseReception(t *testing.T) {
 	ctx := context.Background()
 	pvzID := uuid.New()
 	receptionID := uuid.New()
+	employeeID := uuid.New()
+	actor := entity.Principal{UserID: employeeID, Role: entity.UserRoleEmployee}
 	now := time.Now()
 
 	tests := []struct {
@@ -134,7 +324,7 @@ func TestUseCase_CloseReception(t *testing.T) {
 			name:  "successful reception closing",
 			pvzID: pvzID,
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CloseActiveReception", ctx, pvzID).Return(&entity.Reception{
+				mockRepo.On("CloseActiveReception", ctx, pvzID, &employeeID).Return(&entity.Reception{
 					ID:       receptionID,
 					DateTime: now,
 					PVZID:    pvzID,
@@ -153,7 +343,7 @@ func TestUseCase_CloseReception(t *testing.T) {
 			name:  "error during reception closing",
 			pvzID: pvzID,
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CloseActiveReception", ctx, pvzID).Return(nil, errors.New("failed to close reception"))
+				mockRepo.On("CloseActiveReception", ctx, pvzID, &employeeID).Return(nil, errors.New("failed to close reception"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("failed to close reception"),
@@ -162,7 +352,7 @@ func TestUseCase_CloseReception(t *testing.T) {
 			name:  "pvz not found",
 			pvzID: pvzID,
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CloseActiveReception", ctx, pvzID).Return(nil, errors.New("pvz not found"))
+				mockRepo.On("CloseActiveReception", ctx, pvzID, &employeeID).Return(nil, errors.New("pvz not found"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("pvz not found"),
@@ -171,7 +361,7 @@ func TestUseCase_CloseReception(t *testing.T) {
 			name:  "no active reception",
 			pvzID: pvzID,
 			mockSetup: func(mockRepo *MockReceptionRepo) {
-				mockRepo.On("CloseActiveReception", ctx, pvzID).Return(nil, errors.New("no active reception"))
+				mockRepo.On("CloseActiveReception", ctx, pvzID, &employeeID).Return(nil, errors.New("no active reception"))
 			},
 			expectedResp:  nil,
 			expectedError: errors.New("no active reception"),
@@ -181,11 +371,13 @@ func TestUseCase_CloseReception(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockReceptionRepo)
-			usecase := reception.NewUseCase(mockRepo)
+			txManager := new(MockTxManager)
+			usecase := reception.NewUseCase(mockRepo, new(MockProductRepo), txManager, time.Hour)
 
 			tt.mockSetup(mockRepo)
 
-			resp, err := usecase.CloseReception(ctx, tt.pvzID)
+			resp, err := usecase.CloseReception(ctx, tt.pvzID, actor)
+			assert.Equal(t, 1, txManager.calls)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -203,3 +395,190 @@ func TestUseCase_CloseReception(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_ListProducts(t *testing.T) {
+	ctx := context.Background()
+	receptionID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockReceptionRepo, *MockProductRepo)
+		expectedLen   int
+		expectedError error
+	}{
+		{
+			name: "products of existing reception",
+			mockSetup: func(receptionRepo *MockReceptionRepo, productRepo *MockProductRepo) {
+				receptionRepo.On("GetByID", ctx, receptionID).Return(&entity.Reception{ID: receptionID}, nil)
+				productRepo.On("ListByReception", ctx, receptionID).Return([]entity.Product{
+					{ID: uuid.New(), ReceptionID: receptionID, Type: entity.ShoesProductType, DateTime: now},
+					{ID: uuid.New(), ReceptionID: receptionID, Type: entity.ClothesProductType, DateTime: now},
+				}, nil)
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "reception not found",
+			mockSetup: func(receptionRepo *MockReceptionRepo, productRepo *MockProductRepo) {
+				receptionRepo.On("GetByID", ctx, receptionID).Return(nil, entity.ErrReceptionNotFound)
+			},
+			expectedError: entity.ErrReceptionNotFound,
+		},
+		{
+			name: "products lookup error",
+			mockSetup: func(receptionRepo *MockReceptionRepo, productRepo *MockProductRepo) {
+				receptionRepo.On("GetByID", ctx, receptionID).Return(&entity.Reception{ID: receptionID}, nil)
+				productRepo.On("ListByReception", ctx, receptionID).Return(nil, errors.New("db error"))
+			},
+			expectedError: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receptionRepo := new(MockReceptionRepo)
+			productRepo := new(MockProductRepo)
+			txManager := new(MockTxManager)
+			usecase := reception.NewUseCase(receptionRepo, productRepo, txManager, time.Hour)
+
+			tt.mockSetup(receptionRepo, productRepo)
+
+			resp, err := usecase.ListProducts(ctx, receptionID)
+			assert.Equal(t, 1, txManager.calls)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp, tt.expectedLen)
+			}
+
+			receptionRepo.AssertExpectations(t)
+			productRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_ReopenReception(t *testing.T) {
+	ctx := context.Background()
+	receptionID := uuid.New()
+	moderatorID := uuid.New()
+	actor := entity.Principal{UserID: moderatorID, Role: entity.UserRoleModerator}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockReceptionRepo)
+		expectedError error
+	}{
+		{
+			name: "reopened within configured window",
+			mockSetup: func(mockRepo *MockReceptionRepo) {
+				mockRepo.On("ReopenReception", ctx, receptionID, 2*time.Hour, &moderatorID).Return(&entity.Reception{
+					ID:     receptionID,
+					Status: entity.InProgressStatus,
+				}, nil)
+			},
+		},
+		{
+			name: "window expired",
+			mockSetup: func(mockRepo *MockReceptionRepo) {
+				mockRepo.On("ReopenReception", ctx, receptionID, 2*time.Hour, &moderatorID).Return(nil, entity.ErrReceptionReopenWindowExpired)
+			},
+			expectedError: entity.ErrReceptionReopenWindowExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockReceptionRepo)
+			tt.mockSetup(mockRepo)
+
+			resp, err := reception.NewUseCase(mockRepo, new(MockProductRepo), new(MockTxManager), 2*time.Hour).ReopenReception(ctx, receptionID, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.InProgressStatus, resp.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_CancelReception(t *testing.T) {
+	ctx := context.Background()
+	receptionID := uuid.New()
+	userID := uuid.New()
+	actor := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name          string
+		reason        string
+		mockSetup     func(*MockReceptionRepo)
+		expectedError error
+	}{
+		{
+			name:   "cancelled with reason",
+			reason: " wrong pvz ",
+			mockSetup: func(mockRepo *MockReceptionRepo) {
+				mockRepo.On("CancelReception", ctx, receptionID, "wrong pvz", &userID).Return(&entity.Reception{
+					ID:                 receptionID,
+					Status:             entity.CancelledStatus,
+					CancellationReason: "wrong pvz",
+				}, nil)
+			},
+		},
+		{
+			name:          "blank reason",
+			reason:        "  ",
+			mockSetup:     func(mockRepo *MockReceptionRepo) {},
+			expectedError: entity.ErrInvalidCancellationReason,
+		},
+		{
+			name:   "illegal transition",
+			reason: "wrong pvz",
+			mockSetup: func(mockRepo *MockReceptionRepo) {
+				mockRepo.On("CancelReception", ctx, receptionID, "wrong pvz", &userID).Return(nil, entity.ErrInvalidReceptionStatusTransition)
+			},
+			expectedError: entity.ErrInvalidReceptionStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockReceptionRepo)
+			tt.mockSetup(mockRepo)
+
+			resp, err := reception.NewUseCase(mockRepo, new(MockProductRepo), new(MockTxManager), time.Hour).CancelReception(ctx, receptionID, tt.reason, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.CancelledStatus, resp.Status)
+				assert.Equal(t, "wrong pvz", resp.CancellationReason)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_CloseStaleReceptions(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockReceptionRepo)
+	closed := []entity.Reception{{ID: uuid.New(), Status: entity.CloseStatus}}
+	mockRepo.On("CloseStaleReceptions", ctx, 12*time.Hour, 100, "closed automatically after 12h0m0s without activity").Return(closed, nil)
+
+	resp, err := reception.NewUseCase(mockRepo, new(MockProductRepo), new(MockTxManager), time.Hour).CloseStaleReceptions(ctx, 12*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, closed, resp)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package report
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UseCase struct {
+	repo repo.ReportRepo
+}
+
+func NewUseCase(repo repo.ReportRepo) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+// Acceptance returns how many products of each type every PVZ accepted in closed
+// receptions per UTC day from StartDate to EndDate inclusive.
+func (uc *UseCase) Acceptance(ctx context.Context, filter dto.AcceptanceReportFilter) ([]entity.AcceptanceReportRow, error) {
+	from := filter.StartDate.UTC().Truncate(24 * time.Hour)
+	to := filter.EndDate.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if !to.After(from) || to.Sub(from) > entity.MaxAcceptanceReportDays*24*time.Hour {
+		return nil, entity.ErrInvalidAcceptanceReportPeriod
+	}
+
+	query := entity.AcceptanceReportFilter{
+		From:   from,
+		To:     to,
+		Cities: filter.Cities,
+	}
+	if filter.PVZID != "" {
+		pvzID, err := uuid.Parse(filter.PVZID)
+		if err != nil {
+			return nil, entity.ErrInvalidAcceptanceReportFilter
+		}
+		query.PVZID = &pvzID
+	}
+
+	return uc.repo.AcceptanceReport(ctx, query)
+}
@@ -0,0 +1,123 @@
+package report_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/report"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepo struct {
+	mock.Mock
+}
+
+func (m *MockReportRepo) AcceptanceReport(ctx context.Context, filter entity.AcceptanceReportFilter) ([]entity.AcceptanceReportRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AcceptanceReportRow), args.Error(1)
+}
+
+func TestUseCase_Acceptance(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	rows := []entity.AcceptanceReportRow{{
+		PVZID:       pvzID,
+		City:        entity.CityKazan,
+		Day:         "2025-03-02",
+		ProductType: entity.ShoesProductType,
+		Accepted:    3,
+	}}
+
+	tests := []struct {
+		name          string
+		filter        dto.AcceptanceReportFilter
+		mockSetup     func(*MockReportRepo)
+		expected      []entity.AcceptanceReportRow
+		expectedError error
+	}{
+		{
+			name:   "end date is inclusive",
+			filter: dto.AcceptanceReportFilter{StartDate: start, EndDate: end},
+			mockSetup: func(repo *MockReportRepo) {
+				repo.On("AcceptanceReport", ctx, entity.AcceptanceReportFilter{
+					From: start,
+					To:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(rows, nil)
+			},
+			expected: rows,
+		},
+		{
+			name: "single day with filters",
+			filter: dto.AcceptanceReportFilter{
+				StartDate: start,
+				EndDate:   start,
+				PVZID:     pvzID.String(),
+				Cities:    []entity.City{entity.CityKazan},
+			},
+			mockSetup: func(repo *MockReportRepo) {
+				repo.On("AcceptanceReport", ctx, entity.AcceptanceReportFilter{
+					From:   start,
+					To:     start.AddDate(0, 0, 1),
+					PVZID:  &pvzID,
+					Cities: []entity.City{entity.CityKazan},
+				}).Return(rows, nil)
+			},
+			expected: rows,
+		},
+		{
+			name:          "inverted period",
+			filter:        dto.AcceptanceReportFilter{StartDate: end, EndDate: start},
+			expectedError: entity.ErrInvalidAcceptanceReportPeriod,
+		},
+		{
+			name:          "period too long",
+			filter:        dto.AcceptanceReportFilter{StartDate: start, EndDate: start.AddDate(0, 0, entity.MaxAcceptanceReportDays)},
+			expectedError: entity.ErrInvalidAcceptanceReportPeriod,
+		},
+		{
+			name:          "malformed pvz id",
+			filter:        dto.AcceptanceReportFilter{StartDate: start, EndDate: end, PVZID: "not-a-uuid"},
+			expectedError: entity.ErrInvalidAcceptanceReportFilter,
+		},
+		{
+			name:   "repository error",
+			filter: dto.AcceptanceReportFilter{StartDate: start, EndDate: end},
+			mockSetup: func(repo *MockReportRepo) {
+				repo.On("AcceptanceReport", ctx, mock.Anything).Return(nil, errors.New("db down"))
+			},
+			expectedError: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockReportRepo)
+			if tt.mockSetup != nil {
+				tt.mockSetup(repo)
+			}
+
+			uc := report.NewUseCase(repo)
+			result, err := uc.Acceptance(ctx, tt.filter)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
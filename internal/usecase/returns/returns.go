@@ -0,0 +1,55 @@
+package returns
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"context"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type UseCase struct {
+	repo repo.ReturnRepo
+}
+
+func NewUseCase(repo repo.ReturnRepo) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func (uc *UseCase) CreateReturn(
+	ctx context.Context,
+	request dto.CreateReturnRequest,
+	actor entity.Principal,
+) (*entity.Return, error) {
+	return uc.repo.CreateReturn(ctx, &entity.Return{
+		ProductID: request.ProductID,
+		PVZID:     request.PvzID,
+		Reason:    strings.TrimSpace(request.Reason),
+		CreatedBy: actor.Actor(),
+	})
+}
+
+func (uc *UseCase) ListReturns(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error) {
+	return uc.repo.ListByPVZ(ctx, pvzID, outstandingOnly)
+}
+
+func (uc *UseCase) OpenShipment(ctx context.Context, pvzID uuid.UUID, actor entity.Principal) (*entity.ReturnShipment, error) {
+	return uc.repo.OpenShipment(ctx, pvzID, actor.Actor())
+}
+
+func (uc *UseCase) AddToShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error) {
+	return uc.repo.AddToActiveShipment(ctx, pvzID, returnID)
+}
+
+func (uc *UseCase) CloseShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error) {
+	return uc.repo.CloseActiveShipment(ctx, pvzID)
+}
+
+func (uc *UseCase) Report(ctx context.Context, filter dto.ReturnsReportFilter) ([]entity.ReturnStats, error) {
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return nil, entity.ErrInvalidReturnReportPeriod
+	}
+
+	return uc.repo.Stats(ctx, filter.StartDate, filter.EndDate)
+}
@@ -0,0 +1,174 @@
+package returns_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/usecase/returns"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type MockReturnRepo struct {
+	mock.Mock
+}
+
+func (m *MockReturnRepo) CreateReturn(ctx context.Context, ret *entity.Return) (*entity.Return, error) {
+	args := m.Called(ctx, ret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Return), args.Error(1)
+}
+
+func (m *MockReturnRepo) ListByPVZ(ctx context.Context, pvzID uuid.UUID, outstandingOnly bool) ([]entity.Return, error) {
+	args := m.Called(ctx, pvzID, outstandingOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Return), args.Error(1)
+}
+
+func (m *MockReturnRepo) OpenShipment(ctx context.Context, pvzID uuid.UUID, createdBy *uuid.UUID) (*entity.ReturnShipment, error) {
+	args := m.Called(ctx, pvzID, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReturnShipment), args.Error(1)
+}
+
+func (m *MockReturnRepo) AddToActiveShipment(ctx context.Context, pvzID uuid.UUID, returnID uuid.UUID) (*entity.Return, error) {
+	args := m.Called(ctx, pvzID, returnID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Return), args.Error(1)
+}
+
+func (m *MockReturnRepo) CloseActiveShipment(ctx context.Context, pvzID uuid.UUID) (*entity.ReturnShipment, error) {
+	args := m.Called(ctx, pvzID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReturnShipment), args.Error(1)
+}
+
+func (m *MockReturnRepo) Stats(ctx context.Context, from, to time.Time) ([]entity.ReturnStats, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.ReturnStats), args.Error(1)
+}
+
+func TestUseCase_CreateReturn(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	actor := entity.Principal{UserID: userID, Role: entity.UserRoleEmployee}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockReturnRepo)
+		expectedError error
+	}{
+		{
+			name: "successful return",
+			mockSetup: func(repo *MockReturnRepo) {
+				repo.On("CreateReturn", ctx, &entity.Return{
+					ProductID: productID,
+					PVZID:     pvzID,
+					Reason:    "не подошел размер",
+					CreatedBy: &userID,
+				}).Return(&entity.Return{ID: uuid.New(), ProductID: productID, PVZID: pvzID}, nil)
+			},
+		},
+		{
+			name: "product already returned",
+			mockSetup: func(repo *MockReturnRepo) {
+				repo.On("CreateReturn", ctx, mock.Anything).Return(nil, entity.ErrInvalidProductStatusTransition)
+			},
+			expectedError: entity.ErrInvalidProductStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockReturnRepo)
+			tt.mockSetup(repo)
+
+			ret, err := returns.NewUseCase(repo).CreateReturn(ctx, dto.CreateReturnRequest{
+				PvzID:     pvzID,
+				ProductID: productID,
+				Reason:    "  не подошел размер ",
+			}, actor)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, ret)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, productID, ret.ProductID)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Shipment(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	returnID := uuid.New()
+
+	t.Run("add without open shipment", func(t *testing.T) {
+		repo := new(MockReturnRepo)
+		repo.On("AddToActiveShipment", ctx, pvzID, returnID).Return(nil, entity.ErrNoActiveReturnShipment)
+
+		_, err := returns.NewUseCase(repo).AddToShipment(ctx, pvzID, returnID)
+
+		assert.ErrorIs(t, err, entity.ErrNoActiveReturnShipment)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("close open shipment", func(t *testing.T) {
+		repo := new(MockReturnRepo)
+		repo.On("CloseActiveShipment", ctx, pvzID).Return(&entity.ReturnShipment{PVZID: pvzID, Status: entity.CloseStatus}, nil)
+
+		shipment, err := returns.NewUseCase(repo).CloseShipment(ctx, pvzID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CloseStatus, shipment.Status)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUseCase_Report(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	t.Run("valid period", func(t *testing.T) {
+		repo := new(MockReturnRepo)
+		repo.On("Stats", ctx, start, end).Return([]entity.ReturnStats{{PVZID: uuid.New(), Total: 3, Outstanding: 1, Shipped: 2}}, nil)
+
+		stats, err := returns.NewUseCase(repo).Report(ctx, dto.ReturnsReportFilter{StartDate: start, EndDate: end})
+
+		assert.NoError(t, err)
+		assert.Len(t, stats, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		repo := new(MockReturnRepo)
+
+		_, err := returns.NewUseCase(repo).Report(ctx, dto.ReturnsReportFilter{StartDate: end, EndDate: start})
+
+		assert.ErrorIs(t, err, entity.ErrInvalidReturnReportPeriod)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,124 @@
+package token
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const refreshTokenBytes = 32
+
+type UseCase struct {
+	jwtService  auth.TokenService
+	refreshRepo repo.RefreshTokenRepo
+	revokedRepo repo.RevokedTokenRepo
+	txManager   repo.TxManager
+	refreshTTL  time.Duration
+}
+
+func NewUseCase(
+	jwtService auth.TokenService,
+	refreshRepo repo.RefreshTokenRepo,
+	revokedRepo repo.RevokedTokenRepo,
+	txManager repo.TxManager,
+	refreshTTL time.Duration,
+) *UseCase {
+	return &UseCase{
+		jwtService:  jwtService,
+		refreshRepo: refreshRepo,
+		revokedRepo: revokedRepo,
+		txManager:   txManager,
+		refreshTTL:  refreshTTL,
+	}
+}
+
+func (uc *UseCase) Issue(ctx context.Context, principal entity.Principal) (Pair, error) {
+	accessToken, err := uc.jwtService.Generate(principal)
+	if err != nil {
+		return Pair{}, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return Pair{}, err
+	}
+
+	err = uc.refreshRepo.Create(ctx, &entity.RefreshToken{
+		UserID:    principal.UserID,
+		Role:      principal.Role,
+		TokenHash: HashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(uc.refreshTTL),
+	})
+	if err != nil {
+		return Pair{}, err
+	}
+
+	return Pair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Refresh rotates the refresh token: the presented one is consumed and the new
+// pair is stored in the same transaction, so a failed issue keeps the old token.
+func (uc *UseCase) Refresh(ctx context.Context, refreshToken string) (Pair, error) {
+	var pair Pair
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := uc.refreshRepo.Consume(ctx, HashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+
+		pair, err = uc.Issue(ctx, entity.Principal{
+			UserID: stored.UserID,
+			Email:  stored.Email,
+			Role:   stored.Role,
+		})
+		return err
+	})
+	if err != nil {
+		return Pair{}, err
+	}
+
+	return pair, nil
+}
+
+func (uc *UseCase) Logout(ctx context.Context, refreshToken string, claims *auth.Claims) error {
+	if claims != nil && claims.ID != "" && claims.ExpiresAt != nil {
+		if err := uc.revokedRepo.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	err := uc.refreshRepo.Revoke(ctx, HashRefreshToken(refreshToken))
+	if err != nil && !errors.Is(err, entity.ErrInvalidRefreshToken) {
+		return err
+	}
+
+	return nil
+}
+
+func HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,295 @@
+package token_test
+
+import (
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/pkg/auth"
+	"PVZ-avito-tech/internal/usecase/token"
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type MockTokenService struct {
+	mock.Mock
+}
+
+func (m *MockTokenService) Generate(principal entity.Principal) (string, error) {
+	args := m.Called(principal)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenService) Validate(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	args := m.Called(ctx, tokenString)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Claims), args.Error(1)
+}
+
+type MockRefreshTokenRepo struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepo) Create(ctx context.Context, t *entity.RefreshToken) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepo) Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepo) Revoke(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+type MockRevokedTokenRepo struct {
+	mock.Mock
+}
+
+func (m *MockRevokedTokenRepo) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockRevokedTokenRepo) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	args := m.Called(ctx, tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockTxManager struct {
+	calls int
+}
+
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+func TestUseCase_Issue(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	principal := entity.Principal{
+		UserID: userID,
+		Email:  "employee@example.com",
+		Role:   entity.UserRoleEmployee,
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockTokenService, *MockRefreshTokenRepo)
+		expectedError bool
+	}{
+		{
+			name: "successful issue",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				jwtService.On("Generate", principal).Return("access", nil)
+				refreshRepo.On("Create", ctx, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					return rt.UserID == userID &&
+						rt.Role == entity.UserRoleEmployee &&
+						len(rt.TokenHash) == 64 &&
+						rt.ExpiresAt.After(time.Now().Add(time.Hour-time.Minute))
+				})).Return(nil)
+			},
+		},
+		{
+			name: "access token generation error",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				jwtService.On("Generate", principal).Return("", errors.New("sign failed"))
+			},
+			expectedError: true,
+		},
+		{
+			name: "refresh token persist error",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				jwtService.On("Generate", principal).Return("access", nil)
+				refreshRepo.On("Create", ctx, mock.Anything).Return(errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtService := new(MockTokenService)
+			refreshRepo := new(MockRefreshTokenRepo)
+			revokedRepo := new(MockRevokedTokenRepo)
+			uc := token.NewUseCase(jwtService, refreshRepo, revokedRepo, new(MockTxManager), time.Hour)
+
+			tt.mockSetup(jwtService, refreshRepo)
+
+			pair, err := uc.Issue(ctx, principal)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Empty(t, pair.AccessToken)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access", pair.AccessToken)
+				assert.NotEmpty(t, pair.RefreshToken)
+			}
+
+			jwtService.AssertExpectations(t)
+			refreshRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Refresh(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	raw := "refresh-token"
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockTokenService, *MockRefreshTokenRepo)
+		expectedError error
+	}{
+		{
+			name: "successful rotation",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				refreshRepo.On("Consume", ctx, token.HashRefreshToken(raw)).Return(&entity.RefreshToken{
+					UserID: userID,
+					Email:  "moderator@example.com",
+					Role:   entity.UserRoleModerator,
+				}, nil)
+				jwtService.On("Generate", entity.Principal{
+					UserID: userID,
+					Email:  "moderator@example.com",
+					Role:   entity.UserRoleModerator,
+				}).Return("new-access", nil)
+				refreshRepo.On("Create", ctx, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					return rt.UserID == userID && rt.TokenHash != token.HashRefreshToken(raw)
+				})).Return(nil)
+			},
+		},
+		{
+			name: "unknown or already used token",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				refreshRepo.On("Consume", ctx, token.HashRefreshToken(raw)).Return(nil, entity.ErrInvalidRefreshToken)
+			},
+			expectedError: entity.ErrInvalidRefreshToken,
+		},
+		{
+			name: "new token persist error",
+			mockSetup: func(jwtService *MockTokenService, refreshRepo *MockRefreshTokenRepo) {
+				refreshRepo.On("Consume", ctx, token.HashRefreshToken(raw)).Return(&entity.RefreshToken{
+					UserID: userID,
+					Role:   entity.UserRoleEmployee,
+				}, nil)
+				jwtService.On("Generate", mock.Anything).Return("new-access", nil)
+				refreshRepo.On("Create", ctx, mock.Anything).Return(errDB)
+			},
+			expectedError: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtService := new(MockTokenService)
+			refreshRepo := new(MockRefreshTokenRepo)
+			revokedRepo := new(MockRevokedTokenRepo)
+			txManager := new(MockTxManager)
+			uc := token.NewUseCase(jwtService, refreshRepo, revokedRepo, txManager, time.Hour)
+
+			tt.mockSetup(jwtService, refreshRepo)
+
+			pair, err := uc.Refresh(ctx, raw)
+
+			assert.Equal(t, 1, txManager.calls)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, pair.RefreshToken)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "new-access", pair.AccessToken)
+				assert.NotEqual(t, raw, pair.RefreshToken)
+			}
+
+			jwtService.AssertExpectations(t)
+			refreshRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Logout(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		refreshToken  string
+		mockSetup     func(*MockRefreshTokenRepo, *MockRevokedTokenRepo)
+		expectedError bool
+	}{
+		{
+			name:         "revokes access and refresh tokens",
+			refreshToken: "refresh",
+			mockSetup: func(refreshRepo *MockRefreshTokenRepo, revokedRepo *MockRevokedTokenRepo) {
+				revokedRepo.On("Revoke", ctx, "jti-1", expiresAt).Return(nil)
+				refreshRepo.On("Revoke", ctx, token.HashRefreshToken("refresh")).Return(nil)
+			},
+		},
+		{
+			name: "access token only",
+			mockSetup: func(refreshRepo *MockRefreshTokenRepo, revokedRepo *MockRevokedTokenRepo) {
+				revokedRepo.On("Revoke", ctx, "jti-1", expiresAt).Return(nil)
+			},
+		},
+		{
+			name:         "already revoked refresh token is ignored",
+			refreshToken: "refresh",
+			mockSetup: func(refreshRepo *MockRefreshTokenRepo, revokedRepo *MockRevokedTokenRepo) {
+				revokedRepo.On("Revoke", ctx, "jti-1", expiresAt).Return(nil)
+				refreshRepo.On("Revoke", ctx, token.HashRefreshToken("refresh")).Return(entity.ErrInvalidRefreshToken)
+			},
+		},
+		{
+			name:         "revocation list error",
+			refreshToken: "refresh",
+			mockSetup: func(refreshRepo *MockRefreshTokenRepo, revokedRepo *MockRevokedTokenRepo) {
+				revokedRepo.On("Revoke", ctx, "jti-1", expiresAt).Return(errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtService := new(MockTokenService)
+			refreshRepo := new(MockRefreshTokenRepo)
+			revokedRepo := new(MockRevokedTokenRepo)
+			uc := token.NewUseCase(jwtService, refreshRepo, revokedRepo, new(MockTxManager), time.Hour)
+
+			tt.mockSetup(refreshRepo, revokedRepo)
+
+			err := uc.Logout(ctx, tt.refreshToken, claims)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			refreshRepo.AssertExpectations(t)
+			revokedRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package token
+
+type Pair struct {
+	AccessToken  string
+	RefreshToken string
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import "time"
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	BatchSize   int
+	// Lease keeps claimed deliveries away from other workers while they are sent.
+	Lease time.Duration
+}
+
+type DeliveryStats struct {
+	Delivered int
+	Retried   int
+	Failed    int
+}
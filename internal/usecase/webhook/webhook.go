@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	"PVZ-avito-tech/internal/infrastructure/publisher"
+	"PVZ-avito-tech/internal/infrastructure/repo"
+	"PVZ-avito-tech/internal/pkg/netguard"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	_defaultDeliveriesLimit = 100
+	_minSecretLength        = 16
+	_maxSecretLength        = 256
+	_maxErrorLength         = 1000
+)
+
+type UseCase struct {
+	repo     repo.WebhookRepo
+	cityRepo repo.CityRepo
+	sender   publisher.Sender
+	policy   RetryPolicy
+	now      func() time.Time
+	lookupIP func(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+func NewUseCase(
+	repo repo.WebhookRepo,
+	cityRepo repo.CityRepo,
+	sender publisher.Sender,
+	policy RetryPolicy,
+) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		cityRepo: cityRepo,
+		sender:   sender,
+		policy:   policy,
+		now:      time.Now,
+		lookupIP: net.DefaultResolver.LookupNetIP,
+	}
+}
+
+func (uc *UseCase) CreateSubscription(
+	ctx context.Context,
+	request dto.CreateWebhookSubscriptionRequest,
+	actor entity.Principal,
+) (*dto.WebhookSubscriptionCreated, error) {
+	target, err := url.Parse(strings.TrimSpace(request.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, entity.ErrInvalidWebhookURL
+	}
+	if err := uc.checkPublicHost(ctx, target.Hostname()); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := uniqueEventTypes(request.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.City != nil {
+		if !request.City.IsValidCity() {
+			return nil, entity.ErrInvalidCity
+		}
+		exists, err := uc.cityRepo.Exists(ctx, *request.City)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entity.ErrInvalidCity
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	if len(secret) < _minSecretLength || len(secret) > _maxSecretLength {
+		return nil, entity.ErrInvalidWebhookSecret
+	}
+
+	sub := &entity.WebhookSubscription{
+		URL:        target.String(),
+		EventTypes: eventTypes,
+		PVZID:      request.PvzID,
+		City:       request.City,
+		Secret:     secret,
+		CreatedBy:  actor.Actor(),
+	}
+	if err := uc.repo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return &dto.WebhookSubscriptionCreated{WebhookSubscription: *sub, Secret: secret}, nil
+}
+
+func (uc *UseCase) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	return uc.repo.ListSubscriptions(ctx)
+}
+
+func (uc *UseCase) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return uc.repo.DeactivateSubscription(ctx, id)
+}
+
+func (uc *UseCase) Deliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	filter dto.WebhookDeliveryFilter,
+) ([]entity.WebhookDelivery, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, entity.ErrInvalidWebhookDeliveryStatus
+	}
+	if filter.Limit == 0 {
+		filter.Limit = _defaultDeliveriesLimit
+	}
+
+	if _, err := uc.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.ListDeliveries(ctx, subscriptionID, filter.Status, filter.Limit)
+}
+
+// Publish fans outbox events out into deliveries of the matching subscriptions.
+func (uc *UseCase) Publish(ctx context.Context, events []entity.Event) error {
+	_, err := uc.repo.EnqueueDeliveries(ctx, events)
+	return err
+}
+
+// Deliver sends one batch of due deliveries and schedules retries for failures.
+func (uc *UseCase) Deliver(ctx context.Context) (DeliveryStats, error) {
+	var stats DeliveryStats
+
+	deliveries, err := uc.repo.ClaimDueDeliveries(ctx, uc.policy.BatchSize, uc.policy.Lease)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		attempt := entity.WebhookAttempt{DeliveryID: delivery.ID}
+		attempt.StatusCode, err = uc.sender.Send(ctx, delivery.URL, delivery.Secret, delivery.Event)
+		switch {
+		case err == nil:
+			attempt.Delivered = true
+			stats.Delivered++
+		case delivery.Attempts+1 >= uc.policy.MaxAttempts:
+			attempt.Error = truncate(err.Error(), _maxErrorLength)
+			stats.Failed++
+		default:
+			attempt.Error = truncate(err.Error(), _maxErrorLength)
+			next := uc.now().Add(Backoff(delivery.Attempts+1, uc.policy.BaseDelay, uc.policy.MaxDelay))
+			attempt.NextAttemptAt = &next
+			stats.Retried++
+		}
+
+		if err := uc.repo.RecordAttempt(ctx, attempt); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// Backoff returns the delay before the next attempt after the given number of
+// failed attempts: base, 2*base, 4*base, ... capped at max.
+func Backoff(failedAttempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < failedAttempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// checkPublicHost rejects hosts that resolve to loopback, link-local or private
+// addresses. The sender checks the address again when it dials.
+func (uc *UseCase) checkPublicHost(ctx context.Context, host string) error {
+	addrs, err := uc.lookupIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return entity.ErrInvalidWebhookURL
+	}
+	for _, addr := range addrs {
+		if !netguard.IsPublic(addr) {
+			return entity.ErrWebhookURLNotPublic
+		}
+	}
+	return nil
+}
+
+func uniqueEventTypes(types []entity.EventType) ([]entity.EventType, error) {
+	seen := make(map[entity.EventType]struct{}, len(types))
+	unique := make([]entity.EventType, 0, len(types))
+	for _, t := range types {
+		if !t.IsValid() {
+			return nil, entity.ErrInvalidWebhookEventTypes
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		unique = append(unique, t)
+	}
+	if len(unique) == 0 {
+		return nil, entity.ErrInvalidWebhookEventTypes
+	}
+	return unique, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,401 @@
+package webhook_test
+
+import (
+	"PVZ-avito-tech/internal/controller/http/dto"
+	"PVZ-avito-tech/internal/entity"
+	webhookPublisher "PVZ-avito-tech/internal/infrastructure/publisher/webhook"
+	"PVZ-avito-tech/internal/usecase/webhook"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type MockWebhookRepo struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepo) CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepo) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepo) DeactivateSubscription(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) EnqueueDeliveries(ctx context.Context, events []entity.Event) (int, error) {
+	args := m.Called(ctx, events)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepo) RecordAttempt(ctx context.Context, attempt entity.WebhookAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	status entity.WebhookDeliveryStatus,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, status, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+type MockCityRepo struct {
+	mock.Mock
+}
+
+func (m *MockCityRepo) Create(ctx context.Context, city *entity.CityInfo) error {
+	args := m.Called(ctx, city)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) List(ctx context.Context) ([]entity.CityInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Rename(ctx context.Context, id uuid.UUID, name entity.City) (*entity.CityInfo, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CityInfo), args.Error(1)
+}
+
+func (m *MockCityRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCityRepo) Exists(ctx context.Context, name entity.City) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+var policy = webhook.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    time.Minute,
+	BatchSize:   10,
+	Lease:       time.Minute,
+}
+
+func TestUseCase_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+	moderator := entity.Principal{UserID: uuid.New(), Role: entity.UserRoleModerator}
+	city := entity.CityKazan
+
+	tests := []struct {
+		name          string
+		request       dto.CreateWebhookSubscriptionRequest
+		mockSetup     func(*MockWebhookRepo, *MockCityRepo)
+		expectedError error
+	}{
+		{
+			name: "city subscription with generated secret",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "https://203.0.113.10/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed, entity.EventReceptionClosed},
+				City:       &city,
+			},
+			mockSetup: func(repo *MockWebhookRepo, cityRepo *MockCityRepo) {
+				cityRepo.On("Exists", ctx, city).Return(true, nil)
+				repo.On("CreateSubscription", ctx, mock.MatchedBy(func(sub *entity.WebhookSubscription) bool {
+					return sub.URL == "https://203.0.113.10/hooks" &&
+						len(sub.EventTypes) == 1 &&
+						len(sub.Secret) == 64 &&
+						*sub.CreatedBy == moderator.UserID
+				})).Return(nil)
+			},
+		},
+		{
+			name: "relative url",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrInvalidWebhookURL,
+		},
+		{
+			name: "loopback url",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "http://127.0.0.1:8080/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "host resolving to loopback",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "http://localhost/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "link-local url",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "http://169.254.169.254/latest/meta-data",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "private url",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "http://[fd00::1]/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrWebhookURLNotPublic,
+		},
+		{
+			name: "unknown event type",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "https://203.0.113.10/hooks",
+				EventTypes: []entity.EventType{"reception.archived"},
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrInvalidWebhookEventTypes,
+		},
+		{
+			name: "short secret",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "https://203.0.113.10/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+				Secret:     "short",
+			},
+			mockSetup:     func(*MockWebhookRepo, *MockCityRepo) {},
+			expectedError: entity.ErrInvalidWebhookSecret,
+		},
+		{
+			name: "unknown city",
+			request: dto.CreateWebhookSubscriptionRequest{
+				URL:        "https://203.0.113.10/hooks",
+				EventTypes: []entity.EventType{entity.EventReceptionClosed},
+				City:       &city,
+			},
+			mockSetup: func(repo *MockWebhookRepo, cityRepo *MockCityRepo) {
+				cityRepo.On("Exists", ctx, city).Return(false, nil)
+			},
+			expectedError: entity.ErrInvalidCity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepo)
+			cityRepo := new(MockCityRepo)
+			uc := webhook.NewUseCase(repo, cityRepo, webhookPublisher.NewSender(), policy)
+
+			tt.mockSetup(repo, cityRepo)
+
+			created, err := uc.CreateSubscription(ctx, tt.request, moderator)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, created)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, created.WebhookSubscription.Secret, created.Secret)
+			}
+
+			repo.AssertExpectations(t)
+			cityRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Deliver(t *testing.T) {
+	ctx := context.Background()
+	secret := "0123456789abcdef"
+	pvzID := uuid.New()
+	event, err := entity.NewEvent(entity.EventReceptionClosed, uuid.New(), pvzID, entity.ReceptionStatusChangedData{PVZID: pvzID})
+	assert.NoError(t, err)
+
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhookPublisher.TimestampHeader), 10, 64)
+		if r.Header.Get(webhookPublisher.SignatureHeader) != webhookPublisher.Sign(secret, timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	delivery := func(path string, attempts int) entity.WebhookDelivery {
+		return entity.WebhookDelivery{
+			ID:       uuid.New(),
+			EventID:  event.ID,
+			Attempts: attempts,
+			Event:    event,
+			URL:      receiver.URL + path,
+			Secret:   secret,
+		}
+	}
+	delivered := delivery("/ok", 0)
+	retried := delivery("/down", 1)
+	exhausted := delivery("/down", 2)
+
+	repo := new(MockWebhookRepo)
+	repo.On("ClaimDueDeliveries", ctx, policy.BatchSize, policy.Lease).
+		Return([]entity.WebhookDelivery{delivered, retried, exhausted}, nil)
+	repo.On("RecordAttempt", ctx, mock.MatchedBy(func(a entity.WebhookAttempt) bool {
+		return a.DeliveryID == delivered.ID && a.Delivered && a.StatusCode == http.StatusOK && a.Error == ""
+	})).Return(nil)
+	repo.On("RecordAttempt", ctx, mock.MatchedBy(func(a entity.WebhookAttempt) bool {
+		return a.DeliveryID == retried.ID && !a.Delivered &&
+			a.StatusCode == http.StatusServiceUnavailable &&
+			a.NextAttemptAt != nil &&
+			time.Until(*a.NextAttemptAt) > 15*time.Second
+	})).Return(nil)
+	repo.On("RecordAttempt", ctx, mock.MatchedBy(func(a entity.WebhookAttempt) bool {
+		return a.DeliveryID == exhausted.ID && !a.Delivered && a.NextAttemptAt == nil && a.Error != ""
+	})).Return(nil)
+
+	uc := webhook.NewUseCase(repo, new(MockCityRepo), webhookPublisher.NewSender(), policy)
+
+	stats, err := uc.Deliver(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryStats{Delivered: 1, Retried: 1, Failed: 1}, stats)
+	assert.Equal(t, int32(3), calls.Load())
+	repo.AssertExpectations(t)
+}
+
+func TestUseCase_Deliveries(t *testing.T) {
+	ctx := context.Background()
+	subID := uuid.New()
+
+	tests := []struct {
+		name          string
+		filter        dto.WebhookDeliveryFilter
+		mockSetup     func(*MockWebhookRepo)
+		expectedError error
+	}{
+		{
+			name:   "default limit",
+			filter: dto.WebhookDeliveryFilter{Status: entity.WebhookDeliveryFailed},
+			mockSetup: func(repo *MockWebhookRepo) {
+				repo.On("GetSubscription", ctx, subID).Return(&entity.WebhookSubscription{ID: subID}, nil)
+				repo.On("ListDeliveries", ctx, subID, entity.WebhookDeliveryFailed, 100).Return([]entity.WebhookDelivery{}, nil)
+			},
+		},
+		{
+			name:          "invalid status",
+			filter:        dto.WebhookDeliveryFilter{Status: "lost"},
+			mockSetup:     func(*MockWebhookRepo) {},
+			expectedError: entity.ErrInvalidWebhookDeliveryStatus,
+		},
+		{
+			name: "unknown subscription",
+			mockSetup: func(repo *MockWebhookRepo) {
+				repo.On("GetSubscription", ctx, subID).Return(nil, entity.ErrWebhookSubscriptionNotFound)
+			},
+			expectedError: entity.ErrWebhookSubscriptionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepo)
+			uc := webhook.NewUseCase(repo, new(MockCityRepo), webhookPublisher.NewSender(), policy)
+
+			tt.mockSetup(repo)
+
+			_, err := uc.Deliveries(ctx, subID, tt.filter)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseCase_Publish(t *testing.T) {
+	ctx := context.Background()
+	events := []entity.Event{{ID: uuid.New(), Type: entity.EventReceptionClosed}}
+
+	repo := new(MockWebhookRepo)
+	repo.On("EnqueueDeliveries", ctx, events).Return(0, errors.New("db error"))
+
+	err := webhook.NewUseCase(repo, new(MockCityRepo), webhookPublisher.NewSender(), policy).Publish(ctx, events)
+
+	assert.Error(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestBackoff(t *testing.T) {
+	base, maxDelay := 10*time.Second, time.Minute
+
+	assert.Equal(t, 10*time.Second, webhook.Backoff(1, base, maxDelay))
+	assert.Equal(t, 20*time.Second, webhook.Backoff(2, base, maxDelay))
+	assert.Equal(t, 40*time.Second, webhook.Backoff(3, base, maxDelay))
+	assert.Equal(t, time.Minute, webhook.Backoff(4, base, maxDelay))
+	assert.Equal(t, time.Minute, webhook.Backoff(30, base, maxDelay))
+}
@@ -0,0 +1,8 @@
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS created_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_receptions_created_by ON receptions (created_by);
+CREATE INDEX idx_products_created_by ON products (created_by);
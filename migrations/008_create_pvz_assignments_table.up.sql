@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS pvz_assignments
+(
+    user_id     UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    pvz_id      UUID        NOT NULL REFERENCES pvz (id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, pvz_id)
+);
+
+CREATE INDEX idx_pvz_assignments_pvz_id ON pvz_assignments (pvz_id);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS cities
+(
+    id         UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    name       VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO cities (name)
+VALUES ('Москва'),
+       ('Санкт-Петербург'),
+       ('Казань')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO cities (name)
+SELECT DISTINCT city
+FROM pvz
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE pvz
+    ADD CONSTRAINT fk_pvz_city
+        FOREIGN KEY (city) REFERENCES cities (name)
+            ON UPDATE CASCADE
+            ON DELETE RESTRICT;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS product_types
+(
+    id         UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    name       VARCHAR(255) NOT NULL UNIQUE,
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS product_categories
+(
+    id         UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    type_id    UUID         NOT NULL REFERENCES product_types (id) ON DELETE RESTRICT,
+    name       VARCHAR(255) NOT NULL,
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (type_id, name)
+);
+
+INSERT INTO product_types (name)
+VALUES ('электроника'),
+       ('одежда'),
+       ('обувь')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO product_types (name)
+SELECT DISTINCT type
+FROM products
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE products
+    ADD CONSTRAINT fk_products_type
+        FOREIGN KEY (type) REFERENCES product_types (name)
+            ON UPDATE CASCADE
+            ON DELETE RESTRICT;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES product_categories (id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_type_id ON product_categories (type_id);
@@ -0,0 +1 @@
+CREATE INDEX idx_pvz_created_at_id ON pvz (created_at DESC, id DESC);
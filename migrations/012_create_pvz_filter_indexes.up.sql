@@ -0,0 +1,2 @@
+CREATE INDEX idx_receptions_pvz_id_created_at ON receptions (pvz_id, created_at DESC);
+CREATE INDEX idx_products_type_reception_id ON products (type, reception_id);
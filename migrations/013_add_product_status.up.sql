@@ -0,0 +1,33 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status           VARCHAR(20) NOT NULL DEFAULT 'received'
+        CHECK (status IN ('received', 'stored', 'issued', 'returned')),
+    ADD COLUMN IF NOT EXISTS pickup_code_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS pickup_code_attempts INT NOT NULL DEFAULT 0;
+
+UPDATE products p
+SET status = 'stored'
+FROM receptions r
+WHERE r.id = p.reception_id
+  AND r.status = 'close';
+
+CREATE TABLE IF NOT EXISTS product_status_history
+(
+    id          UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    product_id  UUID        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status   VARCHAR(20) NOT NULL,
+    changed_by  UUID REFERENCES users (id) ON DELETE SET NULL,
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO product_status_history (product_id, from_status, to_status, changed_by, changed_at)
+SELECT id, NULL, 'received', created_by, created_at
+FROM products;
+
+INSERT INTO product_status_history (product_id, from_status, to_status, changed_at)
+SELECT id, 'received', 'stored', NOW()
+FROM products
+WHERE status = 'stored';
+
+CREATE INDEX idx_product_status_history_product_id ON product_status_history (product_id, changed_at);
+CREATE INDEX idx_products_status ON products (status);
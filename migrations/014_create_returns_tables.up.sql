@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS return_shipments
+(
+    id         UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    pvz_id     UUID        NOT NULL REFERENCES pvz (id) ON DELETE CASCADE,
+    status     VARCHAR(20) NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'close')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at  TIMESTAMPTZ,
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_unique_active_return_shipment
+    ON return_shipments (pvz_id)
+    WHERE status = 'in_progress';
+
+CREATE TABLE IF NOT EXISTS returns
+(
+    id          UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    product_id  UUID        NOT NULL UNIQUE REFERENCES products (id) ON DELETE CASCADE,
+    pvz_id      UUID        NOT NULL REFERENCES pvz (id) ON DELETE CASCADE,
+    reason      TEXT        NOT NULL DEFAULT '',
+    shipment_id UUID REFERENCES return_shipments (id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by  UUID REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_returns_pvz_id_created_at ON returns (pvz_id, created_at);
+CREATE INDEX idx_returns_shipment_id ON returns (shipment_id);
@@ -0,0 +1,8 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS barcode VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_products_active_barcode
+    ON products (barcode)
+    WHERE barcode IS NOT NULL AND status IN ('received', 'stored');
+
+CREATE INDEX idx_products_barcode_created_at ON products (barcode, created_at DESC);
@@ -0,0 +1,10 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS deleted_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by      UUID REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS deletion_reason TEXT;
+
+DROP INDEX IF EXISTS idx_products_active_barcode;
+
+CREATE UNIQUE INDEX idx_products_active_barcode
+    ON products (barcode)
+    WHERE barcode IS NOT NULL AND status IN ('received', 'stored') AND deleted_at IS NULL;
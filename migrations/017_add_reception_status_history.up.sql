@@ -0,0 +1,23 @@
+ALTER TABLE receptions
+    ADD COLUMN IF NOT EXISTS closed_at           TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+UPDATE receptions
+SET closed_at = created_at
+WHERE status = 'close';
+
+ALTER TABLE receptions
+    ADD CONSTRAINT chk_receptions_status CHECK (status IN ('in_progress', 'close', 'cancelled'));
+
+CREATE TABLE IF NOT EXISTS reception_status_history
+(
+    id           UUID PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    reception_id UUID        NOT NULL REFERENCES receptions (id) ON DELETE CASCADE,
+    from_status  VARCHAR(20),
+    to_status    VARCHAR(20) NOT NULL,
+    reason       TEXT,
+    changed_by   UUID REFERENCES users (id) ON DELETE SET NULL,
+    changed_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reception_status_history_reception_id ON reception_status_history (reception_id, changed_at);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reception_manifest_items
+(
+    id           UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    reception_id UUID         NOT NULL REFERENCES receptions (id) ON DELETE CASCADE,
+    barcode      VARCHAR(64),
+    type         VARCHAR(255) NOT NULL,
+    quantity     INTEGER      NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_reception_manifest_items_reception_id ON reception_manifest_items (reception_id);
+CREATE UNIQUE INDEX idx_reception_manifest_items_barcode
+    ON reception_manifest_items (reception_id, barcode)
+    WHERE barcode IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS reception_discrepancy_reports
+(
+    reception_id UUID PRIMARY KEY REFERENCES receptions (id) ON DELETE CASCADE,
+    report       JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
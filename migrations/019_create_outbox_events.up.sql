@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    seq            BIGSERIAL PRIMARY KEY,
+    id             UUID        NOT NULL UNIQUE,
+    event_type     VARCHAR(64) NOT NULL,
+    event_version  INT         NOT NULL,
+    aggregate_id   UUID        NOT NULL,
+    pvz_id         UUID        NOT NULL,
+    payload        JSONB       NOT NULL,
+    occurred_at    TIMESTAMPTZ NOT NULL,
+    attempts       INT         NOT NULL DEFAULT 0,
+    last_error     TEXT,
+    published_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events (seq) WHERE published_at IS NULL;
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION notify_outbox_event() RETURNS TRIGGER AS
+$$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.seq::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_outbox_events_notify
+    AFTER INSERT
+    ON outbox_events
+    FOR EACH ROW
+EXECUTE FUNCTION notify_outbox_event();
+
+CREATE INDEX idx_outbox_events_pvz_seq ON outbox_events (pvz_id, seq);
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    actor_id    UUID,
+    actor_role  VARCHAR(50),
+    action      VARCHAR(100)             NOT NULL,
+    entity_type VARCHAR(50)              NOT NULL,
+    entity_id   UUID NOT NULL,
+    pvz_id      UUID NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    request_id  VARCHAR(128)
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, id);
+CREATE INDEX idx_audit_log_actor ON audit_log (actor_id, id);
+CREATE INDEX idx_audit_log_occurred_at ON audit_log (occurred_at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER trg_audit_log_no_truncate
+    BEFORE TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_log_append_only();
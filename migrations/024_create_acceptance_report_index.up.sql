@@ -0,0 +1 @@
+CREATE INDEX idx_products_accepted_created_at ON products (created_at) WHERE deleted_at IS NULL;
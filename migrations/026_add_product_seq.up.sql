@@ -0,0 +1,22 @@
+-- Products of one batch share the transaction timestamp, so their order within a
+-- reception (listing, LIFO deletion) comes from an insertion sequence instead.
+CREATE SEQUENCE IF NOT EXISTS products_seq_seq;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+UPDATE products p
+SET seq = ordered.n
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY created_at, id) AS n FROM products) ordered
+WHERE ordered.id = p.id;
+
+SELECT setval('products_seq_seq', COALESCE(MAX(seq), 0) + 1, false) FROM products;
+
+ALTER TABLE products
+    ALTER COLUMN seq SET DEFAULT nextval('products_seq_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+
+ALTER SEQUENCE products_seq_seq OWNED BY products.seq;
+
+DROP INDEX IF EXISTS idx_products_reception_id_created;
+CREATE INDEX idx_products_reception_id_seq ON products (reception_id, seq);
@@ -0,0 +1,4 @@
+-- The relay claims a batch and publishes it outside of the transaction; the
+-- claim keeps other replicas off the batch until it expires.
+ALTER TABLE outbox_events
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;